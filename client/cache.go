@@ -0,0 +1,426 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/aexvir/lnk/proto"
+)
+
+// Cached is a Client that keeps the links returned by GetLink on a size bounded
+// lru cache, for services that resolve the same slugs over and over.
+// Links are cached for a ttl, and links that were not found for a shorter one.
+// The links created and deleted through the client are invalidated right away; the
+// changes done by other clients are only seen once the entries expire, unless the
+// cache is invalidated with the change stream of the service.
+// The rest of calls go straight to the wrapped client.
+type Cached struct {
+	Client
+
+	ttl         time.Duration
+	negativettl time.Duration
+	maxentries  int
+	watch       bool
+
+	entries    map[string]*list.Element
+	byslug     map[string]map[string]struct{} // keys of the entries of each slug
+	lru        *list.List
+	generation uint64 // increased on every invalidation
+	stats      CacheStats
+	mutex      sync.Mutex
+	now        func() time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// CacheStats are the counters of a Cached client.
+type CacheStats struct {
+	// Hits is the amount of calls answered from the cache, including NegativeHits.
+	Hits uint64
+	// NegativeHits is the amount of calls answered with a cached not found error.
+	NegativeHits uint64
+	// Misses is the amount of calls that went to the service.
+	Misses uint64
+	// Evictions is the amount of entries discarded to make room for others.
+	Evictions uint64
+	// Invalidations is the amount of entries discarded because their link changed, or
+	// because the cache was purged.
+	Invalidations uint64
+	// Entries is the amount of entries on the cache.
+	Entries int
+}
+
+type cacheentry struct {
+	key     string
+	slug    string
+	link    *proto.LinkDetails
+	err     error // set for links that were not found
+	expires time.Time
+}
+
+// NewCachedClient wraps the client with a cache for GetLink.
+// By default up to 1000 links are cached for a minute, and links not found for 10
+// seconds; this can be customized via CacheOpts.
+// The cached client has to be closed, which closes the wrapped client too.
+func NewCachedClient(client Client, opts ...CacheOpt) (*Cached, error) {
+	cache := Cached{
+		Client:      client,
+		ttl:         time.Minute,
+		negativettl: 10 * time.Second,
+		maxentries:  1000,
+		entries:     make(map[string]*list.Element),
+		byslug:      make(map[string]map[string]struct{}),
+		lru:         list.New(),
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&cache); err != nil {
+			return nil, err
+		}
+	}
+
+	if cache.watch {
+		ctx, cancel := context.WithCancel(context.Background())
+		cache.cancel = cancel
+		cache.done = make(chan struct{})
+		go cache.follow(ctx)
+	}
+
+	return &cache, nil
+}
+
+// GetLink for a specific slug, from the cache if it was requested recently with the
+// same request.
+// The returned link is a copy that can be modified freely.
+func (c *Cached) GetLink(ctx context.Context, req *proto.GetLinkReq) (*proto.LinkDetails, error) {
+	key, err := cachekey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	if entry, found := c.lookup(key); found {
+		c.mutex.Unlock()
+
+		if entry.err != nil {
+			return nil, entry.err
+		}
+		return protobuf.Clone(entry.link).(*proto.LinkDetails), nil
+	}
+	c.stats.Misses++
+	generation := c.generation
+	c.mutex.Unlock()
+
+	link, err := c.Client.GetLink(ctx, req)
+	if err != nil && !(IsNotFound(err) && c.negativettl > 0) {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// the link may have changed while it was being fetched
+	if generation == c.generation {
+		c.store(&cacheentry{key: key, slug: req.Slug, link: link, err: err})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return protobuf.Clone(link).(*proto.LinkDetails), nil
+}
+
+// CreateLink and invalidate the cached entries of its slug, as it may have been
+// cached as not found or overwritten an existing link.
+func (c *Cached) CreateLink(ctx context.Context, req *proto.CreateLinkReq) (*proto.LinkId, error) {
+	link, err := c.Client.CreateLink(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Invalidate(link.Slug)
+	return link, nil
+}
+
+// BatchCreateLinks and invalidate the cached entries of the slugs of the links that
+// were created.
+func (c *Cached) BatchCreateLinks(ctx context.Context, req *proto.BatchCreateLinksReq) (*proto.BatchCreateLinksResp, error) {
+	resp, err := c.Client.BatchCreateLinks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range resp.Results {
+		if result.Error == "" {
+			c.Invalidate(result.Slug)
+		}
+	}
+
+	return resp, nil
+}
+
+// DeleteLink and invalidate the cached entries of its slug.
+func (c *Cached) DeleteLink(ctx context.Context, slug string) error {
+	defer c.Invalidate(slug)
+	return c.Client.DeleteLink(ctx, slug)
+}
+
+// BatchDeleteLinks and invalidate the cached entries of their slugs.
+func (c *Cached) BatchDeleteLinks(ctx context.Context, req *proto.BatchDeleteLinksReq) (*proto.BatchDeleteLinksResp, error) {
+	defer func() {
+		for _, slug := range req.Slugs {
+			c.Invalidate(slug)
+		}
+	}()
+
+	return c.Client.BatchDeleteLinks(ctx, req)
+}
+
+// ImportLinks and invalidate the cached entries of the slugs of the links that were
+// stored, unless it was a dry run.
+func (c *Cached) ImportLinks(ctx context.Context, req *proto.ImportLinksReq) (*proto.ImportLinksResp, error) {
+	resp, err := c.Client.ImportLinks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.DryRun {
+		return resp, nil
+	}
+
+	for _, result := range resp.Results {
+		if result.Outcome != "skipped" && result.Error == "" {
+			c.Invalidate(result.Slug)
+		}
+	}
+
+	return resp, nil
+}
+
+// RestoreStore and purge the cache, as any link may have changed.
+func (c *Cached) RestoreStore(ctx context.Context, r io.Reader) (*proto.RestoreStoreResp, error) {
+	defer c.Purge()
+	return c.Client.RestoreStore(ctx, r)
+}
+
+// Invalidate discards the cached entries of the slug.
+func (c *Cached) Invalidate(slug string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	for key := range c.byslug[slug] {
+		c.remove(c.entries[key])
+		c.stats.Invalidations++
+	}
+}
+
+// Purge discards every cached entry.
+func (c *Cached) Purge() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	c.stats.Invalidations += uint64(len(c.entries))
+	c.entries = make(map[string]*list.Element)
+	c.byslug = make(map[string]map[string]struct{})
+	c.lru.Init()
+}
+
+// Stats returns the counters of the cache.
+func (c *Cached) Stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	return stats
+}
+
+// Close stops following the change stream, if it's being followed, and closes the
+// wrapped client.
+func (c *Cached) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+
+	return c.Client.Close()
+}
+
+// lookup returns the cached entry of the key, if there's one that didn't expire.
+// It must be called with the lock held.
+func (c *Cached) lookup(key string) (*cacheentry, bool) {
+	elem, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheentry)
+	if !c.now().Before(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	c.stats.Hits++
+	if entry.err != nil {
+		c.stats.NegativeHits++
+	}
+
+	return entry, true
+}
+
+// store the entry, evicting the least recently used ones if the cache is full.
+// It must be called with the lock held.
+func (c *Cached) store(entry *cacheentry) {
+	ttl := c.ttl
+	if entry.err != nil {
+		ttl = c.negativettl
+	}
+	entry.expires = c.now().Add(ttl)
+
+	if elem, found := c.entries[entry.key]; found {
+		c.remove(elem)
+	}
+
+	for len(c.entries) >= c.maxentries {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	if c.byslug[entry.slug] == nil {
+		c.byslug[entry.slug] = make(map[string]struct{})
+	}
+	c.byslug[entry.slug][entry.key] = struct{}{}
+}
+
+// remove the entry from the cache. It must be called with the lock held.
+func (c *Cached) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheentry)
+	delete(c.entries, entry.key)
+
+	delete(c.byslug[entry.slug], entry.key)
+	if len(c.byslug[entry.slug]) == 0 {
+		delete(c.byslug, entry.slug)
+	}
+}
+
+// follow the change stream of the service invalidating the links that change, until
+// the context is done.
+// As changes may be missed while the stream is down, the whole cache is purged when
+// it has to be opened again.
+func (c *Cached) follow(ctx context.Context) {
+	defer close(c.done)
+
+	var after *uint64
+	backoff := DefaultRetryPolicy()
+
+	for attempt := 1; ; attempt++ {
+		received := false
+		err := c.Client.WatchLinks(
+			ctx, &proto.WatchLinksReq{AfterSequence: after},
+			func(change *proto.LinkChange) error {
+				received = true
+				sequence := change.Sequence
+				after = &sequence
+
+				if change.Kind == "restored" {
+					// the restore may have changed any link
+					c.Purge()
+				} else {
+					c.Invalidate(change.Slug)
+				}
+				return nil
+			},
+		)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.Purge()
+
+		if received {
+			attempt = 1
+		} else if after != nil && err != nil {
+			// the changes after the last one seen may not be available anymore
+			after = nil
+		}
+
+		timer := time.NewTimer(backoff.backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// cachekey identifies the request, as links are requested with different queries.
+func cachekey(req *proto.GetLinkReq) (string, error) {
+	encoded, err := protobuf.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("error building cache key: %w", err)
+	}
+
+	return string(encoded), nil
+}
+
+type CacheOpt func(cache *Cached) error
+
+// WithCacheTTL sets how long links are cached for.
+func WithCacheTTL(ttl time.Duration) CacheOpt {
+	return func(cache *Cached) error {
+		if ttl <= 0 {
+			return errors.New("ttl must be positive")
+		}
+		cache.ttl = ttl
+		return nil
+	}
+}
+
+// WithNegativeTTL sets how long links that were not found are cached for.
+// Zero disables caching them.
+func WithNegativeTTL(ttl time.Duration) CacheOpt {
+	return func(cache *Cached) error {
+		if ttl < 0 {
+			return errors.New("negative ttl can't be negative")
+		}
+		cache.negativettl = ttl
+		return nil
+	}
+}
+
+// WithCacheSize sets how many entries can be cached; the least recently used ones
+// are evicted to make room for new ones.
+func WithCacheSize(entries int) CacheOpt {
+	return func(cache *Cached) error {
+		if entries < 1 {
+			return errors.New("cache size must be positive")
+		}
+		cache.maxentries = entries
+		return nil
+	}
+}
+
+// WithChangeInvalidation invalidates the cached links as soon as they change,
+// following the change stream of the service on the background.
+func WithChangeInvalidation() CacheOpt {
+	return func(cache *Cached) error {
+		cache.watch = true
+		return nil
+	}
+}
@@ -0,0 +1,337 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/aexvir/lnk/proto"
+)
+
+// stubclient serves links from a map, counting the calls to GetLink.
+type stubclient struct {
+	Client
+
+	mutex   sync.Mutex
+	links   map[string]string
+	calls   int32
+	failing bool
+	changes chan *proto.LinkChange
+}
+
+func (sc *stubclient) GetLink(ctx context.Context, req *proto.GetLinkReq) (*proto.LinkDetails, error) {
+	atomic.AddInt32(&sc.calls, 1)
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if sc.failing {
+		return nil, errors.New("connection refused")
+	}
+
+	target, found := sc.links[req.Slug]
+	if !found {
+		return nil, &Error{Code: codes.NotFound, Message: "no link with slug " + req.Slug + " found"}
+	}
+
+	return &proto.LinkDetails{Slug: req.Slug, Target: target}, nil
+}
+
+func (sc *stubclient) CreateLink(ctx context.Context, req *proto.CreateLinkReq) (*proto.LinkId, error) {
+	sc.set(req.GetSlug(), req.Target)
+	return &proto.LinkId{Slug: req.GetSlug()}, nil
+}
+
+func (sc *stubclient) DeleteLink(ctx context.Context, slug string) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	delete(sc.links, slug)
+	return nil
+}
+
+func (sc *stubclient) BatchCreateLinks(ctx context.Context, req *proto.BatchCreateLinksReq) (*proto.BatchCreateLinksResp, error) {
+	var resp proto.BatchCreateLinksResp
+	for _, link := range req.Links {
+		sc.set(link.GetSlug(), link.Target)
+		resp.Results = append(resp.Results, &proto.BatchResult{Slug: link.GetSlug()})
+	}
+
+	return &resp, nil
+}
+
+func (sc *stubclient) BatchDeleteLinks(ctx context.Context, req *proto.BatchDeleteLinksReq) (*proto.BatchDeleteLinksResp, error) {
+	var resp proto.BatchDeleteLinksResp
+	for _, slug := range req.Slugs {
+		_ = sc.DeleteLink(ctx, slug)
+		resp.Results = append(resp.Results, &proto.BatchResult{Slug: slug})
+	}
+
+	return &resp, nil
+}
+
+func (sc *stubclient) RestoreStore(ctx context.Context, r io.Reader) (*proto.RestoreStoreResp, error) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.links = map[string]string{"a": "http://restored.com"}
+	return &proto.RestoreStoreResp{Links: 1}, nil
+}
+
+func (sc *stubclient) WatchLinks(ctx context.Context, req *proto.WatchLinksReq, fn func(change *proto.LinkChange) error) error {
+	if req.Milestones {
+		return errors.New("the cache doesn't need milestones")
+	}
+
+	for {
+		select {
+		case change := <-sc.changes:
+			if err := fn(change); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (sc *stubclient) Close() error {
+	return nil
+}
+
+func (sc *stubclient) set(slug, target string) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.links[slug] = target
+}
+
+func newstubclient() *stubclient {
+	return &stubclient{
+		links:   map[string]string{"a": "http://a.com", "b": "http://b.com", "c": "http://c.com"},
+		changes: make(chan *proto.LinkChange),
+	}
+}
+
+func TestCachedGetLink(t *testing.T) {
+	stub := newstubclient()
+	cache, err := NewCachedClient(stub)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	link, err := cache.GetLink(ctx, &proto.GetLinkReq{Slug: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://a.com", link.Target)
+
+	link.Target = "modified"
+
+	link, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://a.com", link.Target, "cached links shouldn't be affected by changes to the returned ones")
+	assert.EqualValues(t, 1, stub.calls, "the second call should be answered from the cache")
+
+	_, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "a", Granularity: "week"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, stub.calls, "requests with different queries should be cached separately")
+
+	stub.set("a", "http://new.com")
+	require.NoError(t, cache.DeleteLink(ctx, "b"))
+	_, err = cache.CreateLink(ctx, &proto.CreateLinkReq{Target: "http://new.com", Slug: ptr("a")})
+	require.NoError(t, err)
+
+	link, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://new.com", link.Target, "links created through the client should be invalidated")
+
+	assert.Equal(
+		t,
+		CacheStats{Hits: 1, Misses: 3, Invalidations: 2, Entries: 1},
+		cache.Stats(),
+	)
+}
+
+func TestCachedBatches(t *testing.T) {
+	stub := newstubclient()
+	cache, err := NewCachedClient(stub)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	for _, slug := range []string{"a", "b", "d"} {
+		_, _ = cache.GetLink(ctx, &proto.GetLinkReq{Slug: slug})
+	}
+	require.Equal(t, 3, cache.Stats().Entries)
+
+	_, err = cache.BatchCreateLinks(ctx, &proto.BatchCreateLinksReq{Links: []*proto.CreateLinkReq{{Target: "http://d.com", Slug: ptr("d")}}})
+	require.NoError(t, err)
+
+	link, err := cache.GetLink(ctx, &proto.GetLinkReq{Slug: "d"})
+	require.NoError(t, err, "links created in batches should be invalidated")
+	assert.Equal(t, "http://d.com", link.Target)
+
+	_, err = cache.BatchDeleteLinks(ctx, &proto.BatchDeleteLinksReq{Slugs: []string{"a", "b"}})
+	require.NoError(t, err)
+
+	_, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "a"})
+	assert.True(t, IsNotFound(err), "links deleted in batches should be invalidated")
+	assert.EqualValues(t, 5, stub.calls)
+}
+
+func TestCachedRestore(t *testing.T) {
+	stub := newstubclient()
+	cache, err := NewCachedClient(stub)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	ctx := context.Background()
+
+	for _, slug := range []string{"a", "b"} {
+		_, _ = cache.GetLink(ctx, &proto.GetLinkReq{Slug: slug})
+	}
+
+	_, err = cache.RestoreStore(ctx, strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Zero(t, cache.Stats().Entries, "restoring a snapshot should purge the cache")
+
+	link, err := cache.GetLink(ctx, &proto.GetLinkReq{Slug: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://restored.com", link.Target)
+}
+
+func TestCachedNotFound(t *testing.T) {
+	stub := newstubclient()
+	cache, err := NewCachedClient(stub, WithNegativeTTL(time.Second))
+	require.NoError(t, err)
+	defer cache.Close()
+
+	now := time.Date(2022, 6, 11, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "missing"})
+		assert.True(t, IsNotFound(err), "missing links should be reported as not found")
+	}
+	assert.EqualValues(t, 1, stub.calls, "missing links should be cached")
+
+	now = now.Add(2 * time.Second)
+	_, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "missing"})
+	assert.True(t, IsNotFound(err))
+	assert.EqualValues(t, 2, stub.calls, "missing links should be cached for the negative ttl")
+
+	_, err = cache.CreateLink(ctx, &proto.CreateLinkReq{Target: "http://found.com", Slug: ptr("missing")})
+	require.NoError(t, err)
+
+	link, err := cache.GetLink(ctx, &proto.GetLinkReq{Slug: "missing"})
+	require.NoError(t, err, "creating the link should invalidate the not found entry")
+	assert.Equal(t, "http://found.com", link.Target)
+
+	stub.failing = true
+	for i := 0; i < 2; i++ {
+		_, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "c"})
+		require.Error(t, err)
+	}
+	assert.EqualValues(t, 5, stub.calls, "other errors shouldn't be cached")
+
+	assert.EqualValues(t, 1, cache.Stats().NegativeHits)
+}
+
+func TestCachedExpiration(t *testing.T) {
+	stub := newstubclient()
+	cache, err := NewCachedClient(stub, WithCacheTTL(time.Minute), WithCacheSize(2))
+	require.NoError(t, err)
+	defer cache.Close()
+
+	now := time.Date(2022, 6, 11, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	get := func(slug string) {
+		_, err := cache.GetLink(ctx, &proto.GetLinkReq{Slug: slug})
+		require.NoError(t, err)
+	}
+
+	get("a")
+	get("b")
+	get("a") // a is now the most recently used
+	get("c") // evicts b
+	assert.EqualValues(t, 3, stub.calls)
+
+	get("a")
+	assert.EqualValues(t, 3, stub.calls, "recently used links should be kept")
+	get("b")
+	assert.EqualValues(t, 4, stub.calls, "the least recently used link should have been evicted")
+
+	now = now.Add(2 * time.Minute)
+	get("b")
+	assert.EqualValues(t, 5, stub.calls, "links should expire after the ttl")
+
+	stats := cache.Stats()
+	assert.EqualValues(t, 2, stats.Evictions)
+	assert.Equal(t, 2, stats.Entries)
+}
+
+func TestCachedChangeInvalidation(t *testing.T) {
+	stub := newstubclient()
+	cache, err := NewCachedClient(stub, WithChangeInvalidation())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "a"})
+	require.NoError(t, err)
+	_, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "b"})
+	require.NoError(t, err)
+
+	stub.set("a", "http://changed.com")
+	stub.changes <- &proto.LinkChange{Sequence: 2, Kind: "updated", Slug: "a"}
+	stub.changes <- &proto.LinkChange{Sequence: 3, Kind: "created", Slug: "z"} // wait for the previous change
+
+	link, err := cache.GetLink(ctx, &proto.GetLinkReq{Slug: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://changed.com", link.Target, "changed links should be invalidated")
+
+	_, err = cache.GetLink(ctx, &proto.GetLinkReq{Slug: "b"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, stub.calls, "unchanged links should be kept")
+
+	stub.changes <- &proto.LinkChange{Sequence: 4, Kind: "restored"}
+	stub.changes <- &proto.LinkChange{Sequence: 5, Kind: "created", Slug: "z"} // wait for the previous change
+	assert.Zero(t, cache.Stats().Entries, "restoring the store should purge the cache")
+
+	require.NoError(t, cache.Close(), "closing should stop following the changes")
+}
+
+func TestCacheOptions(t *testing.T) {
+	tests := map[string]CacheOpt{
+		"zero ttl":           WithCacheTTL(0),
+		"negative ttl":       WithNegativeTTL(-time.Second),
+		"empty cache":        WithCacheSize(0),
+		"negative size":      WithCacheSize(-1),
+		"negative cache ttl": WithCacheTTL(-time.Second),
+	}
+
+	for name, opt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewCachedClient(newstubclient(), opt)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
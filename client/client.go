@@ -1,109 +1,392 @@
 package client
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"time"
 
+	"google.golang.org/genproto/googleapis/api/httpbody"
+
 	"github.com/aexvir/lnk/proto"
 )
 
-// Lnk is a client for the lnk service.
+// Client of the lnk service, implemented over both the rest api and grpc.
+// Failed requests return an *Error with the status the service replied with.
+type Client interface {
+	ListLinks(ctx context.Context) (*proto.LinkList, error)
+	CreateLink(ctx context.Context, req *proto.CreateLinkReq) (*proto.LinkId, error)
+	BatchCreateLinks(ctx context.Context, req *proto.BatchCreateLinksReq) (*proto.BatchCreateLinksResp, error)
+	GetLink(ctx context.Context, req *proto.GetLinkReq) (*proto.LinkDetails, error)
+	GetLinkStats(ctx context.Context, req *proto.LinkStatsReq) (*proto.LinkStats, error)
+	GetLinkQR(ctx context.Context, req *proto.LinkQRReq) (*httpbody.HttpBody, error)
+	ExportStats(ctx context.Context, req *proto.ExportStatsReq, w io.Writer) error
+	GetOverview(ctx context.Context, req *proto.OverviewReq) (*proto.Overview, error)
+	WatchHits(ctx context.Context, req *proto.WatchHitsReq, fn func(event *proto.HitEvent) error) error
+	WatchLinks(ctx context.Context, req *proto.WatchLinksReq, fn func(change *proto.LinkChange) error) error
+	DeleteLink(ctx context.Context, slug string) error
+	BatchDeleteLinks(ctx context.Context, req *proto.BatchDeleteLinksReq) (*proto.BatchDeleteLinksResp, error)
+	ImportLinks(ctx context.Context, req *proto.ImportLinksReq) (*proto.ImportLinksResp, error)
+	SnapshotStore(ctx context.Context, w io.Writer) error
+	RestoreStore(ctx context.Context, r io.Reader) (*proto.RestoreStoreResp, error)
+
+	CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error)
+	ListWebhooks(ctx context.Context) (*proto.WebhookList, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	ListDeadLetters(ctx context.Context, req *proto.DeadLettersReq) (*proto.DeliveryList, error)
+	RetryDeadLetter(ctx context.Context, id string) error
+
+	// Close releases the connections of the client.
+	Close() error
+}
+
+var (
+	_ Client = (*Lnk)(nil)
+	_ Client = (*GRPC)(nil)
+	_ Client = (*Cached)(nil)
+)
+
+// New instantiates a client for the lnk service over the transport set via
+// WithTransport, the rest api by default.
+func New(opts ...ClientOpt) (Client, error) {
+	cfg, err := newsettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.transport == TransportGRPC {
+		return newgrpcclient(cfg)
+	}
+
+	return newlnkclient(cfg), nil
+}
+
+// Lnk is a client for the rest api of the lnk service.
 type Lnk struct {
 	baseurl string
 	client  *http.Client
+	header  http.Header
+	retrier *retrier
+	timeout time.Duration
 }
 
-// NewLnkClient instantiates a new client for the lnk service.
-// Not all features are implemented currently.
+// NewLnkClient instantiates a new client for the rest api of the lnk service.
 // Customize it via ClientOpts.
 func NewLnkClient(opts ...ClientOpt) (*Lnk, error) {
-	client := Lnk{
-		baseurl: "http://localhost:8000",
-		client: &http.Client{
-			Transport: DefaultTransport(),
-		},
+	cfg, err := newsettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newlnkclient(cfg), nil
+}
+
+func newlnkclient(cfg *settings) *Lnk {
+	client := cfg.httpclient
+	if client == nil {
+		transport := DefaultTransport()
+		transport.TLSClientConfig = cfg.tls
+		client = &http.Client{Transport: transport}
 	}
 
-	for _, opt := range opts {
-		err := opt(&client)
-		if err != nil {
-			return nil, err
+	// the gateway forwards the headers with this prefix as grpc metadata
+	header := make(http.Header, len(cfg.metadata))
+	for key, values := range cfg.metadata {
+		for _, value := range values {
+			header.Add("Grpc-Metadata-"+key, value)
 		}
 	}
 
-	return &client, nil
+	return &Lnk{
+		baseurl: cfg.baseurl,
+		client:  client,
+		header:  header,
+		retrier: &retrier{policy: cfg.retry, breaker: cfg.breaker},
+		timeout: cfg.timeout,
+	}
+}
+
+// Close releases the idle connections of the client.
+func (lc *Lnk) Close() error {
+	lc.client.CloseIdleConnections()
+	return nil
 }
 
-// CreateLink for a target url with an optional custom slug.
-func (lc *Lnk) CreateLink(target string, slug *string) (*proto.LinkId, error) {
-	req := proto.CreateLinkReq{
-		Target: target,
-		Slug:   slug,
+// ListLinks returns all the links.
+func (lc *Lnk) ListLinks(ctx context.Context) (*proto.LinkList, error) {
+	var list proto.LinkList
+	if err := lc.call(ctx, http.MethodGet, "/api/links", nil, nil, &list); err != nil {
+		return nil, err
 	}
-	payload, err := json.Marshal(req)
+
+	return &list, nil
+}
+
+// CreateLink for a target url, with an optional custom slug and the rest of link
+// settings specified on the request.
+// If retries are enabled, requests without idempotency key get a random one, so
+// they can be retried without creating duplicated links.
+func (lc *Lnk) CreateLink(ctx context.Context, req *proto.CreateLinkReq) (*proto.LinkId, error) {
+	ctx = lc.retrier.withkey(ctx, req.IdempotencyKey)
+
+	var link proto.LinkId
+	if err := lc.call(ctx, http.MethodPost, "/api/links", nil, req, &link); err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// BatchCreateLinks creates many links on a single call, with a result for each one.
+// Like with CreateLink, requests without idempotency key get a random one if retries
+// are enabled.
+func (lc *Lnk) BatchCreateLinks(ctx context.Context, req *proto.BatchCreateLinksReq) (*proto.BatchCreateLinksResp, error) {
+	ctx = lc.retrier.withkey(ctx, req.IdempotencyKey)
+
+	var resp proto.BatchCreateLinksResp
+	if err := lc.call(ctx, http.MethodPost, "/api/links:batchCreate", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// GetLink for a specific slug, with its hits over time as specified on the request.
+func (lc *Lnk) GetLink(ctx context.Context, req *proto.GetLinkReq) (*proto.LinkDetails, error) {
+	var link proto.LinkDetails
+	err := lc.call(ctx, http.MethodGet, linkpath(req.Slug), queryparams(req, "slug"), nil, &link)
 	if err != nil {
-		return nil, fmt.Errorf("error serializing payload: %w", err)
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/api/links", lc.baseurl)
-	resp, err := lc.client.Post(url, "application/json", bytes.NewReader(payload))
+	return &link, nil
+}
+
+// GetLinkStats returns the visit breakdowns of a link.
+func (lc *Lnk) GetLinkStats(ctx context.Context, req *proto.LinkStatsReq) (*proto.LinkStats, error) {
+	var stats proto.LinkStats
+	err := lc.call(ctx, http.MethodGet, linkpath(req.Slug)+"/stats", queryparams(req, "slug"), nil, &stats)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("request failed; status: %d", resp.StatusCode)
+	return &stats, nil
+}
+
+// GetLinkQR renders the QR code of a link.
+func (lc *Lnk) GetLinkQR(ctx context.Context, req *proto.LinkQRReq) (*httpbody.HttpBody, error) {
+	ctx, cancel := lc.withtimeout(ctx)
+	defer cancel()
+
+	resp, err := lc.request(ctx, http.MethodGet, linkpath(req.Slug)+"/qr", queryparams(req, "slug"), nil)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	var link proto.LinkId
-	err = json.NewDecoder(resp.Body).Decode(&link)
+	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+		return nil, fmt.Errorf("error reading response: %w", err)
 	}
 
-	return &link, nil
+	return &httpbody.HttpBody{ContentType: resp.Header.Get("Content-Type"), Data: data}, nil
+}
+
+// ExportStats writes the export of the link stats to w, as it's streamed.
+// Every line of the export is terminated by a newline.
+func (lc *Lnk) ExportStats(ctx context.Context, req *proto.ExportStatsReq, w io.Writer) error {
+	return lc.stream(
+		ctx, "/api/links/export", queryparams(req),
+		func(line []byte) error {
+			if _, err := w.Write(append(line, '\n')); err != nil {
+				return fmt.Errorf("error writing export: %w", err)
+			}
+			return nil
+		},
+	)
+}
+
+// GetOverview returns the summary of the usage of all links.
+func (lc *Lnk) GetOverview(ctx context.Context, req *proto.OverviewReq) (*proto.Overview, error) {
+	var overview proto.Overview
+	if err := lc.call(ctx, http.MethodGet, "/api/overview", queryparams(req), nil, &overview); err != nil {
+		return nil, err
+	}
+
+	return &overview, nil
+}
+
+// WatchHits calls fn with every visit to the links as it happens, until the
+// context is done or fn fails.
+func (lc *Lnk) WatchHits(ctx context.Context, req *proto.WatchHitsReq, fn func(event *proto.HitEvent) error) error {
+	return lc.streammessages(
+		ctx, "/api/hits/watch", queryparams(req),
+		func(result []byte) error {
+			var event proto.HitEvent
+			if err := unmarshaler.Unmarshal(result, &event); err != nil {
+				return fmt.Errorf("error decoding event: %w", err)
+			}
+			return fn(&event)
+		},
+	)
+}
+
+// WatchLinks calls fn with every change done to the links as it happens, until the
+// context is done or fn fails.
+func (lc *Lnk) WatchLinks(ctx context.Context, req *proto.WatchLinksReq, fn func(change *proto.LinkChange) error) error {
+	return lc.streammessages(
+		ctx, "/api/links/watch", queryparams(req),
+		func(result []byte) error {
+			var change proto.LinkChange
+			if err := unmarshaler.Unmarshal(result, &change); err != nil {
+				return fmt.Errorf("error decoding change: %w", err)
+			}
+			return fn(&change)
+		},
+	)
+}
+
+// DeleteLink with the specified slug.
+func (lc *Lnk) DeleteLink(ctx context.Context, slug string) error {
+	return lc.call(ctx, http.MethodDelete, linkpath(slug), nil, nil, nil)
+}
+
+// BatchDeleteLinks deletes many links on a single call, with a result for each one.
+// Requests without idempotency key get a random one if retries are enabled, so the
+// retries don't report the links deleted by the first attempt as missing.
+func (lc *Lnk) BatchDeleteLinks(ctx context.Context, req *proto.BatchDeleteLinksReq) (*proto.BatchDeleteLinksResp, error) {
+	ctx = lc.retrier.withkey(ctx, req.IdempotencyKey)
+
+	var resp proto.BatchDeleteLinksResp
+	if err := lc.call(ctx, http.MethodPost, "/api/links:batchDelete", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
 }
 
-// GetLink for a specific slug.
-func (lc *Lnk) GetLink(slug string) (*proto.LinkDetails, error) {
-	url := fmt.Sprintf("%s/api/links/%s", lc.baseurl, slug)
+// ImportLinks uploads a file with links, hits included, and returns what happened to
+// each of them. The file is sent as it is, with the content type of the body, and
+// the rest of fields of the request as query parameters.
+func (lc *Lnk) ImportLinks(ctx context.Context, req *proto.ImportLinksReq) (*proto.ImportLinksResp, error) {
+	ctx, cancel := lc.withtimeout(ctx)
+	defer cancel()
 
-	resp, err := lc.client.Get(url)
+	contenttype := req.GetData().GetContentType()
+	if contenttype == "" {
+		contenttype = "application/octet-stream"
+	}
+
+	resp, err := lc.send(ctx, http.MethodPost, "/api/links:import", queryparams(req, "data"), req.GetData().GetData(), contenttype)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		return nil, err
 	}
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var link proto.LinkDetails
-		err = json.NewDecoder(resp.Body).Decode(&link)
-		if err != nil {
-			return nil, fmt.Errorf("error decoding response: %w", err)
-		}
+	var result proto.ImportLinksResp
+	if err := decode(resp, &result); err != nil {
+		return nil, err
+	}
 
-		return &link, nil
+	return &result, nil
+}
+
+// SnapshotStore writes a snapshot of the whole store to w.
+func (lc *Lnk) SnapshotStore(ctx context.Context, w io.Writer) error {
+	ctx, cancel := lc.withtimeout(ctx)
+	defer cancel()
 
-	case http.StatusNotFound:
-		return nil, fmt.Errorf("slug %s not found", slug)
+	resp, err := lc.request(ctx, http.MethodGet, "/api/admin/snapshot", nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-	default:
-		return nil, fmt.Errorf("unexpected response status code: %d", resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("error reading snapshot: %w", err)
 	}
+
+	return nil
 }
 
-type ClientOpt func(*Lnk) error
+// RestoreStore replaces the whole contents of the store with the ones of the snapshot
+// read from r, which is sent as it is.
+func (lc *Lnk) RestoreStore(ctx context.Context, r io.Reader) (*proto.RestoreStoreResp, error) {
+	// the snapshot is kept in memory so it can be sent again on retries
+	snapshot, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", err)
+	}
+
+	ctx, cancel := lc.withtimeout(ctx)
+	defer cancel()
+
+	resp, err := lc.send(ctx, http.MethodPost, "/api/admin/restore", nil, snapshot, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+
+	var result proto.RestoreStoreResp
+	if err := decode(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateWebhook subscribes a url to the events of the links.
+// The returned webhook is the only one that includes the secret.
+func (lc *Lnk) CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error) {
+	var hook proto.Webhook
+	if err := lc.call(ctx, http.MethodPost, "/api/webhooks", nil, req, &hook); err != nil {
+		return nil, err
+	}
+
+	return &hook, nil
+}
 
-func WithBaseUrl(url string) ClientOpt {
-	return func(lc *Lnk) error {
-		lc.baseurl = url
-		return nil
+// ListWebhooks returns all the webhooks, without their secrets.
+func (lc *Lnk) ListWebhooks(ctx context.Context) (*proto.WebhookList, error) {
+	var list proto.WebhookList
+	if err := lc.call(ctx, http.MethodGet, "/api/webhooks", nil, nil, &list); err != nil {
+		return nil, err
 	}
+
+	return &list, nil
+}
+
+// DeleteWebhook with the specified id.
+func (lc *Lnk) DeleteWebhook(ctx context.Context, id string) error {
+	return lc.call(ctx, http.MethodDelete, "/api/webhooks/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ListDeadLetters returns the webhook deliveries that ran out of attempts.
+func (lc *Lnk) ListDeadLetters(ctx context.Context, req *proto.DeadLettersReq) (*proto.DeliveryList, error) {
+	var list proto.DeliveryList
+	err := lc.call(ctx, http.MethodGet, "/api/webhooks/deadletters", queryparams(req), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// RetryDeadLetter queues the dead letter with the specified id for delivery again.
+func (lc *Lnk) RetryDeadLetter(ctx context.Context, id string) error {
+	path := fmt.Sprintf("/api/webhooks/deadletters/%s/retry", url.PathEscape(id))
+	return lc.call(ctx, http.MethodPost, path, nil, nil, nil)
+}
+
+func linkpath(slug string) string {
+	return "/api/links/" + url.PathEscape(slug)
 }
 
+// DefaultTransport of the rest client, which bounds how long connecting takes and
+// keeps idle connections around for reuse.
+// Responses aren't bounded, as streams last until they're cancelled; use WithTimeout
+// for bounding the rest of the calls.
 func DefaultTransport() *http.Transport {
 	return &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
@@ -111,5 +394,10 @@ func DefaultTransport() *http.Transport {
 			Timeout:   5 * time.Second,
 			KeepAlive: 5 * time.Second,
 		}).DialContext,
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
 	}
 }
@@ -1,17 +1,93 @@
 package client
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/aexvir/lnk/proto"
 )
 
+// respondproto writes the message the way the gateway does.
+func respondproto(t *testing.T, w http.ResponseWriter, status int, msg protobuf.Message) {
+	payload, err := protojson.Marshal(msg)
+	require.NoError(t, err, "shouldn't fail encoding the response")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(payload)
+}
+
+// bodytracker is a transport that keeps track of the response bodies left open.
+type bodytracker struct {
+	mutex sync.Mutex
+	open  int
+}
+
+func (bt *bodytracker) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	bt.mutex.Lock()
+	bt.open++
+	bt.mutex.Unlock()
+
+	resp.Body = &trackedbody{ReadCloser: resp.Body, tracker: bt}
+	return resp, nil
+}
+
+func (bt *bodytracker) opened() int {
+	bt.mutex.Lock()
+	defer bt.mutex.Unlock()
+	return bt.open
+}
+
+type trackedbody struct {
+	io.ReadCloser
+	tracker *bodytracker
+	once    sync.Once
+}
+
+func (tb *trackedbody) Close() error {
+	tb.once.Do(
+		func() {
+			tb.tracker.mutex.Lock()
+			tb.tracker.open--
+			tb.tracker.mutex.Unlock()
+		},
+	)
+	return tb.ReadCloser.Close()
+}
+
+// newclient returns a client for the server, checking that every response body
+// is closed by the end of the test.
+func newclient(t *testing.T, server *httptest.Server) *Lnk {
+	tracker := &bodytracker{}
+
+	client, err := NewLnkClient(WithBaseUrl(server.URL), WithHTTPClient(&http.Client{Transport: tracker}))
+	require.NoError(t, err, "nothing to fail here for")
+
+	t.Cleanup(func() { assert.Zero(t, tracker.opened(), "every response body should be closed") })
+
+	return client
+}
+
 func TestClientCreateLink(t *testing.T) {
 	tests := map[string]struct {
 		target string // used as flag to control server behaviour on this test
@@ -38,25 +114,18 @@ func TestClientCreateLink(t *testing.T) {
 	downstream := httptest.NewServer(
 		http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
 				var payload proto.CreateLinkReq
-				err := json.NewDecoder(r.Body).Decode(&payload)
+				err = protojson.Unmarshal(body, &payload)
 				require.NoError(t, err, "the client should always serialize the payload correctly")
 
 				switch payload.Target {
 				case "random":
-					w.WriteHeader(http.StatusOK)
-					err = json.NewEncoder(w).Encode(proto.LinkId{Slug: "random"})
-					if err != nil {
-						t.Fatal(err)
-					}
+					respondproto(t, w, http.StatusOK, &proto.LinkId{Slug: "random"})
 				case "specific":
-					w.WriteHeader(http.StatusOK)
-					err = json.NewEncoder(w).Encode(proto.LinkId{Slug: *payload.Slug})
-					if err != nil {
-						t.Fatal(err)
-					}
-				case "malformed":
-					w.WriteHeader(http.StatusNotFound)
+					respondproto(t, w, http.StatusOK, &proto.LinkId{Slug: payload.GetSlug()})
 				default:
 					w.WriteHeader(http.StatusInternalServerError)
 				}
@@ -65,16 +134,15 @@ func TestClientCreateLink(t *testing.T) {
 	)
 	defer downstream.Close()
 
-	client, err := NewLnkClient(WithBaseUrl(downstream.URL))
-	require.NoError(t, err, "nothing to fail here for")
+	client := newclient(t, downstream)
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			var slug *string
+			req := proto.CreateLinkReq{Target: test.target}
 			if test.slug != "" {
-				slug = &test.slug
+				req.Slug = &test.slug
 			}
-			gotLink, gotErr := client.CreateLink(test.target, slug)
+			gotLink, gotErr := client.CreateLink(context.Background(), &req)
 
 			if test.wantErr != "" {
 				require.Error(t, gotErr, "should have failed on this request")
@@ -90,7 +158,7 @@ func TestClientCreateLink(t *testing.T) {
 }
 
 func TestClientGetLink(t *testing.T) {
-	testlink := proto.LinkDetails{
+	testlink := &proto.LinkDetails{
 		Slug:   "exists",
 		Target: "http://google.com",
 		Hits:   42,
@@ -105,11 +173,11 @@ func TestClientGetLink(t *testing.T) {
 	tests := map[string]struct {
 		slug string
 
-		wantLink proto.LinkDetails
+		wantLink *proto.LinkDetails
 		wantErr  string
 	}{
 		"empty slug": {
-			wantErr: "unexpected response status code",
+			wantErr: "status: 500",
 		},
 		"correct slug but missing": {
 			slug:    "missing",
@@ -130,14 +198,15 @@ func TestClientGetLink(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				switch r.URL.Path {
 				case "/api/links/exists":
-					w.WriteHeader(http.StatusOK)
-					err := json.NewEncoder(w).Encode(testlink)
-					require.NoError(t, err, "shouldn't fail encoding the response")
+					assert.Equal(t, "week", r.URL.Query().Get("granularity"), "the query should be sent")
+					assert.Equal(t, "2022-06-01T00:00:00Z", r.URL.Query().Get("from"), "timestamps should be sent as rfc3339")
+					respondproto(t, w, http.StatusOK, testlink)
 				case "/api/links/malformed":
 					w.WriteHeader(http.StatusOK)
 					_, _ = w.Write([]byte("hehe"))
 				case "/api/links/missing":
 					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"code":5,"message":"slug missing not found","details":[]}`))
 				default:
 					w.WriteHeader(http.StatusInternalServerError)
 				}
@@ -146,12 +215,16 @@ func TestClientGetLink(t *testing.T) {
 	)
 	defer downstream.Close()
 
-	client, err := NewLnkClient(WithBaseUrl(downstream.URL))
-	require.NoError(t, err, "nothing to fail here for")
+	client := newclient(t, downstream)
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			gotLink, gotErr := client.GetLink(test.slug)
+			req := proto.GetLinkReq{
+				Slug:        test.slug,
+				Granularity: "week",
+				From:        timestamppb.New(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)),
+			}
+			gotLink, gotErr := client.GetLink(context.Background(), &req)
 
 			if test.wantErr != "" {
 				require.Error(t, gotErr, "should have failed on this request")
@@ -161,8 +234,329 @@ func TestClientGetLink(t *testing.T) {
 
 			require.NoError(t, gotErr, "shouldn't error here")
 			require.NotNil(t, gotLink, "there should be a non-nil link here")
-			assert.Equal(t, test.wantLink, *gotLink, "the returned link is not matching expectations")
+			assert.True(t, protobuf.Equal(test.wantLink, gotLink), "the returned link is not matching expectations")
+		})
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		body   string
+
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		"rpc status": {
+			status:      http.StatusInternalServerError,
+			body:        `{"code":2,"message":"error getting link: no link with slug abc found","details":[]}`,
+			wantCode:    codes.Unknown,
+			wantMessage: "error getting link: no link with slug abc found",
+		},
+		"rpc status with specific code": {
+			status:      http.StatusBadRequest,
+			body:        `{"code":3,"message":"invalid stats query"}`,
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "invalid stats query",
+		},
+		"rpc status of a stream": {
+			status:      http.StatusInternalServerError,
+			body:        `{"error":{"code":2,"message":"error getting link: no url found for slug: abc","details":[]}}`,
+			wantCode:    codes.Unknown,
+			wantMessage: "error getting link: no url found for slug: abc",
+		},
+		"body from a proxy": {
+			status:      http.StatusBadGateway,
+			body:        "<html>bad gateway</html>",
+			wantCode:    codes.Unknown,
+			wantMessage: "<html>bad gateway</html>",
+		},
+		"empty body": {
+			status:      http.StatusServiceUnavailable,
+			wantCode:    codes.Unavailable,
+			wantMessage: "Service Unavailable",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			downstream := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(test.status)
+						_, _ = w.Write([]byte(test.body))
+					},
+				),
+			)
+			defer downstream.Close()
+
+			client := newclient(t, downstream)
+
+			err := client.DeleteLink(context.Background(), "abc")
+			require.Error(t, err)
+
+			var rerr *Error
+			require.ErrorAs(t, err, &rerr, "errors should be typed")
+			assert.Equal(t, test.status, rerr.HTTPStatus)
+			assert.Equal(t, test.wantCode, rerr.Code)
+			assert.Equal(t, test.wantMessage, rerr.Message)
+
+			st, ok := status.FromError(err)
+			require.True(t, ok, "errors should be convertible to grpc statuses")
+			assert.Equal(t, test.wantCode, st.Code())
 		})
 	}
+}
+
+func TestClientListAndDelete(t *testing.T) {
+	deleted := make(chan string, 1)
+
+	downstream := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/links":
+					respondproto(t, w, http.StatusOK, &proto.LinkList{Links: []*proto.LinkDetails{{Slug: "aaa"}, {Slug: "bbb"}}})
+				case r.Method == http.MethodDelete && r.URL.Path == "/api/links/aaa":
+					deleted <- "aaa"
+					_, _ = w.Write([]byte("{}"))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			},
+		),
+	)
+	defer downstream.Close()
+
+	client := newclient(t, downstream)
+
+	list, err := client.ListLinks(context.Background())
+	require.NoError(t, err)
+	require.Len(t, list.Links, 2)
+	assert.Equal(t, "aaa", list.Links[0].Slug)
+
+	require.NoError(t, client.DeleteLink(context.Background(), "aaa"))
+	assert.Equal(t, "aaa", <-deleted)
+
+	err = client.DeleteLink(context.Background(), "zzz")
+	assert.True(t, IsNotFound(err), "missing links should be reported as not found")
+}
+
+func TestClientBatches(t *testing.T) {
+	keys := make(chan string, 2)
+
+	downstream := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				keys <- r.Header.Get(IdempotencyHeader)
+				body, _ := io.ReadAll(r.Body)
+
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/api/links:batchCreate":
+					var req proto.BatchCreateLinksReq
+					require.NoError(t, protojson.Unmarshal(body, &req))
+
+					var resp proto.BatchCreateLinksResp
+					for _, link := range req.Links {
+						resp.Results = append(resp.Results, &proto.BatchResult{Slug: link.GetSlug()})
+						resp.Succeeded++
+					}
+					respondproto(t, w, http.StatusOK, &resp)
+
+				case r.Method == http.MethodPost && r.URL.Path == "/api/links:batchDelete":
+					var req proto.BatchDeleteLinksReq
+					require.NoError(t, protojson.Unmarshal(body, &req))
+
+					resp := proto.BatchDeleteLinksResp{Failed: uint32(len(req.Slugs))}
+					for _, slug := range req.Slugs {
+						resp.Results = append(resp.Results, &proto.BatchResult{Slug: slug, Error: "not found"})
+					}
+					respondproto(t, w, http.StatusOK, &resp)
+
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			},
+		),
+	)
+	defer downstream.Close()
+
+	client := newclient(t, downstream)
+	ctx := context.Background()
+
+	created, err := client.BatchCreateLinks(
+		ctx,
+		&proto.BatchCreateLinksReq{
+			Links: []*proto.CreateLinkReq{{Target: "http://a.com", Slug: ptr("a")}, {Target: "http://b.com", Slug: ptr("b")}},
+		},
+	)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, created.Succeeded)
+	assert.Equal(t, "b", created.Results[1].Slug)
+	assert.NotEmpty(t, <-keys, "batches should get a random idempotency key so they can be retried")
+
+	deleted, err := client.BatchDeleteLinks(ctx, &proto.BatchDeleteLinksReq{Slugs: []string{"x", "y"}, IdempotencyKey: "key"})
+	require.NoError(t, err, "failures of the items shouldn't fail the call")
+	assert.EqualValues(t, 2, deleted.Failed)
+	assert.Equal(t, "not found", deleted.Results[0].Error)
+	assert.Equal(t, "key", <-keys, "the key of the request should be sent")
+}
+
+func TestClientImportLinks(t *testing.T) {
+	downstream := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/links:import" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, "slug,url\na,http://a.com\n", string(body), "the file should be sent as it is")
+				assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+				assert.Equal(t, "csv", r.URL.Query().Get("format"))
+				assert.Equal(t, []string{"target=url", "hits="}, r.URL.Query()["columns"])
+				assert.Equal(t, "rename", r.URL.Query().Get("conflicts"))
+				assert.Equal(t, "true", r.URL.Query().Get("dryRun"))
+
+				respondproto(
+					t, w, http.StatusOK,
+					&proto.ImportLinksResp{
+						Results: []*proto.ImportResult{{Line: 2, Slug: "a-2", OriginalSlug: "a", Target: "http://a.com", Outcome: "renamed"}},
+						Renamed: 1,
+						DryRun:  true,
+					},
+				)
+			},
+		),
+	)
+	defer downstream.Close()
+
+	client := newclient(t, downstream)
+
+	resp, err := client.ImportLinks(
+		context.Background(),
+		&proto.ImportLinksReq{
+			Format:    "csv",
+			Data:      &httpbody.HttpBody{ContentType: "text/csv", Data: []byte("slug,url\na,http://a.com\n")},
+			Columns:   []string{"target=url", "hits="},
+			Conflicts: "rename",
+			DryRun:    true,
+		},
+	)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, resp.Renamed)
+	assert.Equal(t, "a-2", resp.Results[0].Slug)
+	assert.True(t, resp.DryRun)
+}
+
+func TestClientSnapshots(t *testing.T) {
+	snapshot := []byte("LNKSNAP\x01\x1f\x8b")
+
+	downstream := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/admin/snapshot":
+					w.Header().Set("Content-Type", "application/octet-stream")
+					_, _ = w.Write(snapshot)
+				case r.Method == http.MethodPost && r.URL.Path == "/api/admin/restore":
+					body, _ := io.ReadAll(r.Body)
+					assert.Equal(t, snapshot, body, "the snapshot should be sent as it is")
+					respondproto(t, w, http.StatusOK, &proto.RestoreStoreResp{Version: 1, Links: 42})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			},
+		),
+	)
+	defer downstream.Close()
+
+	client := newclient(t, downstream)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	require.NoError(t, client.SnapshotStore(ctx, &buf))
+	assert.Equal(t, snapshot, buf.Bytes())
+
+	restored, err := client.RestoreStore(ctx, &buf)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, restored.Links)
+}
+
+func TestClientStreams(t *testing.T) {
+	downstream := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/links/export":
+					assert.Equal(t, []string{"aaa", "bbb"}, r.URL.Query()["slugs"], "repeated fields should be sent")
+					_, _ = w.Write([]byte("slug,target,date,hits,unique_visitors\naaa,http://a,2022-06-11,1,1\n"))
+				case "/api/hits/watch":
+					_, _ = w.Write([]byte(`{"result":{"slug":"aaa","target":"http://a"}}` + "\n"))
+					_, _ = w.Write([]byte(`{"result":{"slug":"bbb","target":"http://b"}}` + "\n"))
+					_, _ = w.Write([]byte(`{"error":{"code":14,"message":"shutting down"}}` + "\n"))
+				case "/api/links/watch":
+					assert.Equal(t, "41", r.URL.Query().Get("afterSequence"), "optional fields should be sent")
+					_, _ = w.Write([]byte(`{"result":{"sequence":"42","kind":"created","slug":"aaa"}}` + "\n"))
+				}
+			},
+		),
+	)
+	defer downstream.Close()
+
+	client := newclient(t, downstream)
+	ctx := context.Background()
+
+	var export bytes.Buffer
+	err := client.ExportStats(ctx, &proto.ExportStatsReq{Slugs: []string{"aaa", "bbb"}}, &export)
+	require.NoError(t, err)
+	assert.Equal(t, "slug,target,date,hits,unique_visitors\naaa,http://a,2022-06-11,1,1\n", export.String())
+
+	var hits []string
+	err = client.WatchHits(
+		ctx, &proto.WatchHitsReq{},
+		func(event *proto.HitEvent) error {
+			hits = append(hits, event.Slug)
+			return nil
+		},
+	)
+	assert.Equal(t, []string{"aaa", "bbb"}, hits, "every event before the error should be received")
+
+	var rerr *Error
+	require.ErrorAs(t, err, &rerr, "errors sent mid stream should be returned")
+	assert.Equal(t, codes.Unavailable, rerr.Code)
+
+	after := uint64(41)
+	var changes []*proto.LinkChange
+	err = client.WatchLinks(
+		ctx, &proto.WatchLinksReq{AfterSequence: &after},
+		func(change *proto.LinkChange) error {
+			changes = append(changes, change)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.EqualValues(t, 42, changes[0].Sequence)
+	assert.Equal(t, "created", changes[0].Kind)
+}
+
+func TestClientMetadata(t *testing.T) {
+	downstream := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Grpc-Metadata-Authorization"), "metadata should be sent for the gateway to forward")
+				respondproto(t, w, http.StatusOK, &proto.LinkList{})
+			},
+		),
+	)
+	defer downstream.Close()
+
+	client, err := NewLnkClient(WithBaseUrl(downstream.URL), WithMetadata("authorization", "Bearer token"))
+	require.NoError(t, err)
+	defer client.Close()
 
+	_, err = client.ListLinks(context.Background())
+	require.NoError(t, err)
 }
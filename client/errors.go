@@ -0,0 +1,108 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Error is returned when the service rejects a request, with the details of the
+// google.rpc.Status the service replied with.
+type Error struct {
+	// HTTPStatus of the response; zero if the request wasn't made over http.
+	HTTPStatus int
+	Code       codes.Code
+	Message    string
+	Details    []*anypb.Any
+}
+
+func (e *Error) Error() string {
+	if e.HTTPStatus != 0 {
+		return fmt.Sprintf("request failed; status: %d, code: %s, message: %s", e.HTTPStatus, e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("request failed; code: %s, message: %s", e.Code, e.Message)
+}
+
+// GRPCStatus returns the error as a grpc status, so it can be inspected with the
+// functions of the grpc status package.
+func (e *Error) GRPCStatus() *status.Status {
+	return status.FromProto(&spb.Status{Code: int32(e.Code), Message: e.Message, Details: e.Details})
+}
+
+// IsNotFound checks whether the error is caused by a missing resource.
+func IsNotFound(err error) bool {
+	var rerr *Error
+	if !errors.As(err, &rerr) {
+		return false
+	}
+
+	return rerr.Code == codes.NotFound || rerr.HTTPStatus == http.StatusNotFound
+}
+
+// errorFromStatus builds the error from a google.rpc.Status.
+func errorFromStatus(httpstatus int, st *spb.Status) *Error {
+	return &Error{
+		HTTPStatus: httpstatus,
+		Code:       codes.Code(st.GetCode()),
+		Message:    st.GetMessage(),
+		Details:    st.GetDetails(),
+	}
+}
+
+// decodeError builds the error of a failed response from its body, which is expected
+// to be a json encoded google.rpc.Status, wrapped on an error chunk for streams; if
+// it's not, e.g. because it comes from a proxy, the error code is inferred from the
+// http status and the body is used as message.
+func decodeError(httpstatus int, body []byte) *Error {
+	payload := body
+
+	var chunk streamchunk
+	if err := json.Unmarshal(body, &chunk); err == nil && len(chunk.Error) > 0 {
+		payload = chunk.Error
+	}
+
+	var st spb.Status
+	if err := unmarshaler.Unmarshal(payload, &st); err == nil && (st.Code != 0 || st.Message != "") {
+		return errorFromStatus(httpstatus, &st)
+	}
+
+	message := string(body)
+	if message == "" {
+		message = http.StatusText(httpstatus)
+	}
+
+	return &Error{HTTPStatus: httpstatus, Code: codeFromHTTP(httpstatus), Message: message}
+}
+
+// codeFromHTTP maps http statuses to grpc codes, as the gateway does the opposite.
+func codeFromHTTP(httpstatus int) codes.Code {
+	switch httpstatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Unknown
+	}
+}
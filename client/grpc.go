@@ -0,0 +1,373 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/aexvir/lnk/proto"
+)
+
+// GRPC is a client that talks grpc directly to the lnk service.
+type GRPC struct {
+	conn  *grpc.ClientConn
+	links proto.LinksClient
+
+	metadata metadata.MD
+	retrier  *retrier
+	timeout  time.Duration
+}
+
+// NewGRPCClient instantiates a new grpc client for the lnk service.
+// The connection is established lazily on the first call.
+// Customize it via ClientOpts.
+func NewGRPCClient(opts ...ClientOpt) (*GRPC, error) {
+	cfg, err := newsettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newgrpcclient(cfg)
+}
+
+func newgrpcclient(cfg *settings) (*GRPC, error) {
+	client := GRPC{
+		metadata: cfg.metadata.Copy(),
+		retrier:  &retrier{policy: cfg.retry, breaker: cfg.breaker},
+		timeout:  cfg.timeout,
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.tls != nil {
+		creds = credentials.NewTLS(cfg.tls)
+	}
+
+	dialopts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(client.unary),
+		grpc.WithChainStreamInterceptor(client.stream),
+	}
+
+	if cfg.keepalive != nil {
+		dialopts = append(dialopts, grpc.WithKeepaliveParams(*cfg.keepalive))
+	}
+
+	conn, err := grpc.Dial(cfg.target, append(dialopts, cfg.dialopts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s: %w", cfg.target, err)
+	}
+
+	client.conn = conn
+	client.links = proto.NewLinksClient(conn)
+
+	return &client, nil
+}
+
+// unary sends the metadata of the client along the calls, bounds them with the
+// timeout, and retries them as the retry policy allows.
+func (gc *GRPC) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if gc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, gc.timeout)
+		defer cancel()
+	}
+
+	ctx, idempotent := gc.outgoing(ctx, method)
+
+	return gc.retrier.do(
+		ctx, idempotent,
+		func() error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		},
+	)
+}
+
+// stream sends the metadata of the client along the streams, retrying opening
+// them as the retry policy allows.
+func (gc *GRPC) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, idempotent := gc.outgoing(ctx, method)
+
+	var stream grpc.ClientStream
+	err := gc.retrier.do(
+		ctx, idempotent,
+		func() error {
+			var err error
+			stream, err = streamer(ctx, desc, cc, method, opts...)
+			return err
+		},
+	)
+
+	return stream, err
+}
+
+// outgoing adds the metadata of the client and the idempotency key to the outgoing
+// metadata of the context, and reports whether the method is safe to retry.
+func (gc *GRPC) outgoing(ctx context.Context, method string) (context.Context, bool) {
+	md := gc.metadata
+
+	key := idempotencykeyfrom(ctx)
+	if key != "" {
+		md = metadata.Join(md, metadata.Pairs(idempotencymetadata, key))
+	}
+
+	if current, ok := metadata.FromOutgoingContext(ctx); ok {
+		md = metadata.Join(md, current)
+	}
+
+	if len(md) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	return ctx, !unsafemethods[method] || (deduplicated[method] && key != "")
+}
+
+// Close closes the connection to the service.
+func (gc *GRPC) Close() error {
+	return gc.conn.Close()
+}
+
+// ListLinks returns all the links.
+func (gc *GRPC) ListLinks(ctx context.Context) (*proto.LinkList, error) {
+	list, err := gc.links.ListLinks(ctx, &emptypb.Empty{})
+	return list, fromgrpc(ctx, err)
+}
+
+// CreateLink for a target url, with an optional custom slug and the rest of link
+// settings specified on the request.
+// If retries are enabled, requests without idempotency key get a random one, so
+// they can be retried without creating duplicated links.
+func (gc *GRPC) CreateLink(ctx context.Context, req *proto.CreateLinkReq) (*proto.LinkId, error) {
+	ctx = gc.retrier.withkey(ctx, req.IdempotencyKey)
+
+	link, err := gc.links.CreateLink(ctx, req)
+	return link, fromgrpc(ctx, err)
+}
+
+// BatchCreateLinks creates many links on a single call, with a result for each one.
+// Like with CreateLink, requests without idempotency key get a random one if retries
+// are enabled.
+func (gc *GRPC) BatchCreateLinks(ctx context.Context, req *proto.BatchCreateLinksReq) (*proto.BatchCreateLinksResp, error) {
+	ctx = gc.retrier.withkey(ctx, req.IdempotencyKey)
+
+	resp, err := gc.links.BatchCreateLinks(ctx, req)
+	return resp, fromgrpc(ctx, err)
+}
+
+// GetLink for a specific slug, with its hits over time as specified on the request.
+func (gc *GRPC) GetLink(ctx context.Context, req *proto.GetLinkReq) (*proto.LinkDetails, error) {
+	link, err := gc.links.GetLink(ctx, req)
+	return link, fromgrpc(ctx, err)
+}
+
+// GetLinkStats returns the visit breakdowns of a link.
+func (gc *GRPC) GetLinkStats(ctx context.Context, req *proto.LinkStatsReq) (*proto.LinkStats, error) {
+	stats, err := gc.links.GetLinkStats(ctx, req)
+	return stats, fromgrpc(ctx, err)
+}
+
+// GetLinkQR renders the QR code of a link.
+func (gc *GRPC) GetLinkQR(ctx context.Context, req *proto.LinkQRReq) (*httpbody.HttpBody, error) {
+	qr, err := gc.links.GetLinkQR(ctx, req)
+	return qr, fromgrpc(ctx, err)
+}
+
+// ExportStats writes the export of the link stats to w, as it's streamed.
+// Every line of the export is terminated by a newline.
+func (gc *GRPC) ExportStats(ctx context.Context, req *proto.ExportStatsReq, w io.Writer) error {
+	stream, err := gc.links.ExportStats(ctx, req)
+	if err != nil {
+		return fromgrpc(ctx, err)
+	}
+
+	return receive(
+		ctx, stream.Recv,
+		func(line *httpbody.HttpBody) error {
+			if _, err := w.Write(append(line.Data, '\n')); err != nil {
+				return fmt.Errorf("error writing export: %w", err)
+			}
+			return nil
+		},
+	)
+}
+
+// GetOverview returns the summary of the usage of all links.
+func (gc *GRPC) GetOverview(ctx context.Context, req *proto.OverviewReq) (*proto.Overview, error) {
+	overview, err := gc.links.GetOverview(ctx, req)
+	return overview, fromgrpc(ctx, err)
+}
+
+// WatchHits calls fn with every visit to the links as it happens, until the
+// context is done or fn fails.
+func (gc *GRPC) WatchHits(ctx context.Context, req *proto.WatchHitsReq, fn func(event *proto.HitEvent) error) error {
+	stream, err := gc.links.WatchHits(ctx, req)
+	if err != nil {
+		return fromgrpc(ctx, err)
+	}
+
+	return receive(ctx, stream.Recv, fn)
+}
+
+// WatchLinks calls fn with every change done to the links as it happens, until the
+// context is done or fn fails.
+func (gc *GRPC) WatchLinks(ctx context.Context, req *proto.WatchLinksReq, fn func(change *proto.LinkChange) error) error {
+	stream, err := gc.links.WatchLinks(ctx, req)
+	if err != nil {
+		return fromgrpc(ctx, err)
+	}
+
+	return receive(ctx, stream.Recv, fn)
+}
+
+// DeleteLink with the specified slug.
+func (gc *GRPC) DeleteLink(ctx context.Context, slug string) error {
+	_, err := gc.links.DeleteLink(ctx, &proto.LinkId{Slug: slug})
+	return fromgrpc(ctx, err)
+}
+
+// BatchDeleteLinks deletes many links on a single call, with a result for each one.
+// Requests without idempotency key get a random one if retries are enabled, so the
+// retries don't report the links deleted by the first attempt as missing.
+func (gc *GRPC) BatchDeleteLinks(ctx context.Context, req *proto.BatchDeleteLinksReq) (*proto.BatchDeleteLinksResp, error) {
+	ctx = gc.retrier.withkey(ctx, req.IdempotencyKey)
+
+	resp, err := gc.links.BatchDeleteLinks(ctx, req)
+	return resp, fromgrpc(ctx, err)
+}
+
+// ImportLinks uploads a file with links, hits included, and returns what happened to
+// each of them.
+func (gc *GRPC) ImportLinks(ctx context.Context, req *proto.ImportLinksReq) (*proto.ImportLinksResp, error) {
+	resp, err := gc.links.ImportLinks(ctx, req)
+	return resp, fromgrpc(ctx, err)
+}
+
+// SnapshotStore writes a snapshot of the whole store to w.
+func (gc *GRPC) SnapshotStore(ctx context.Context, w io.Writer) error {
+	// snapshots come on a single message, whatever their size
+	snapshot, err := gc.links.SnapshotStore(ctx, &emptypb.Empty{}, grpc.MaxCallRecvMsgSize(math.MaxInt32))
+	if err != nil {
+		return fromgrpc(ctx, err)
+	}
+
+	if _, err := w.Write(snapshot.Data); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// restorechunksize is the size of the messages snapshots are streamed in.
+const restorechunksize = 1 << 20
+
+// RestoreStore replaces the whole contents of the store with the ones of the snapshot
+// read from r, which is streamed in chunks.
+func (gc *GRPC) RestoreStore(ctx context.Context, r io.Reader) (*proto.RestoreStoreResp, error) {
+	stream, err := gc.links.RestoreStore(ctx)
+	if err != nil {
+		return nil, fromgrpc(ctx, err)
+	}
+
+	chunk := make([]byte, restorechunksize)
+	for {
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			// the stream returns io.EOF when the server failed, whose error comes on CloseAndRecv
+			if err := stream.Send(&httpbody.HttpBody{Data: chunk[:n]}); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, fromgrpc(ctx, err)
+			}
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			_ = stream.CloseSend()
+			return nil, fmt.Errorf("error reading snapshot: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	return resp, fromgrpc(ctx, err)
+}
+
+// CreateWebhook subscribes a url to the events of the links.
+// The returned webhook is the only one that includes the secret.
+func (gc *GRPC) CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error) {
+	hook, err := gc.links.CreateWebhook(ctx, req)
+	return hook, fromgrpc(ctx, err)
+}
+
+// ListWebhooks returns all the webhooks, without their secrets.
+func (gc *GRPC) ListWebhooks(ctx context.Context) (*proto.WebhookList, error) {
+	list, err := gc.links.ListWebhooks(ctx, &emptypb.Empty{})
+	return list, fromgrpc(ctx, err)
+}
+
+// DeleteWebhook with the specified id.
+func (gc *GRPC) DeleteWebhook(ctx context.Context, id string) error {
+	_, err := gc.links.DeleteWebhook(ctx, &proto.WebhookId{Id: id})
+	return fromgrpc(ctx, err)
+}
+
+// ListDeadLetters returns the webhook deliveries that ran out of attempts.
+func (gc *GRPC) ListDeadLetters(ctx context.Context, req *proto.DeadLettersReq) (*proto.DeliveryList, error) {
+	list, err := gc.links.ListDeadLetters(ctx, req)
+	return list, fromgrpc(ctx, err)
+}
+
+// RetryDeadLetter queues the dead letter with the specified id for delivery again.
+func (gc *GRPC) RetryDeadLetter(ctx context.Context, id string) error {
+	_, err := gc.links.RetryDeadLetter(ctx, &proto.DeliveryId{Id: id})
+	return fromgrpc(ctx, err)
+}
+
+// receive calls fn with every message of the stream until it ends, fn fails, or
+// the context is done.
+func receive[T any](ctx context.Context, recv func() (T, error), fn func(msg T) error) error {
+	for {
+		msg, err := recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fromgrpc(ctx, err)
+		}
+
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// fromgrpc converts the errors returned by grpc into the ones returned by the rest
+// client, so both clients fail the same way.
+func fromgrpc(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// the rest client reports cancellations as the context error
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return errorFromStatus(0, st.Proto())
+}
@@ -0,0 +1,276 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/aexvir/lnk/proto"
+)
+
+// fakeserver implements a handful of rpcs with canned responses.
+type fakeserver struct {
+	proto.UnimplementedLinksServer
+
+	metadata chan metadata.MD
+	attempts int32 // attempts of the calls that fail with unavailable
+}
+
+func (fs *fakeserver) GetOverview(ctx context.Context, req *proto.OverviewReq) (*proto.Overview, error) {
+	if atomic.AddInt32(&fs.attempts, 1) < 3 {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+
+	return &proto.Overview{TotalLinks: 1}, nil
+}
+
+func (fs *fakeserver) CreateLink(ctx context.Context, req *proto.CreateLinkReq) (*proto.LinkId, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	fs.metadata <- md
+
+	atomic.AddInt32(&fs.attempts, 1)
+	return nil, status.Error(codes.Unavailable, "try again")
+}
+
+func (fs *fakeserver) CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error) {
+	atomic.AddInt32(&fs.attempts, 1)
+	return nil, status.Error(codes.Unavailable, "try again")
+}
+
+func (fs *fakeserver) GetLink(ctx context.Context, req *proto.GetLinkReq) (*proto.LinkDetails, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	fs.metadata <- md
+
+	if req.Slug != "exists" {
+		return nil, status.Errorf(codes.NotFound, "no link with slug %s found", req.Slug)
+	}
+
+	return &proto.LinkDetails{Slug: "exists", Target: "http://google.com", Hits: 42}, nil
+}
+
+func (fs *fakeserver) ExportStats(req *proto.ExportStatsReq, stream proto.Links_ExportStatsServer) error {
+	for _, line := range []string{"slug,target,date,hits,unique_visitors", "exists,http://google.com,2022-06-11,42,40"} {
+		if err := stream.Send(&httpbody.HttpBody{ContentType: "text/csv", Data: []byte(line)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fs *fakeserver) WatchHits(req *proto.WatchHitsReq, stream proto.Links_WatchHitsServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	fs.metadata <- md
+
+	if err := stream.Send(&proto.HitEvent{Slug: "exists"}); err != nil {
+		return err
+	}
+
+	<-stream.Context().Done()
+	return nil
+}
+
+func (fs *fakeserver) SnapshotStore(ctx context.Context, _ *emptypb.Empty) (*httpbody.HttpBody, error) {
+	// larger than the default limit of the messages clients receive
+	return &httpbody.HttpBody{Data: bytes.Repeat([]byte{1}, 5<<20)}, nil
+}
+
+func (fs *fakeserver) RestoreStore(stream proto.Links_RestoreStoreServer) error {
+	var chunks, size uint32
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		chunks++
+		size += uint32(len(chunk.Data))
+	}
+
+	// the chunks are reported as links and their size as webhooks
+	return stream.SendAndClose(&proto.RestoreStoreResp{Links: chunks, Webhooks: size})
+}
+
+func newgrpcserver(t *testing.T) (*fakeserver, ClientOpt) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	fake := &fakeserver{metadata: make(chan metadata.MD, 10)}
+	proto.RegisterLinksServer(server, fake)
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	dialer := grpc.WithContextDialer(
+		func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		},
+	)
+
+	return fake, WithDialOptions(dialer)
+}
+
+func TestGRPCClient(t *testing.T) {
+	fake, dialer := newgrpcserver(t)
+
+	client, err := New(WithTransport(TransportGRPC), WithTarget("bufnet"), WithMetadata("authorization", "Bearer token"), dialer)
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.IsType(t, &GRPC{}, client, "the transport option should pick the grpc client")
+
+	ctx := context.Background()
+
+	link, err := client.GetLink(ctx, &proto.GetLinkReq{Slug: "exists"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, link.Hits)
+	assert.Equal(t, []string{"Bearer token"}, (<-fake.metadata).Get("authorization"), "the metadata should be sent")
+
+	_, err = client.GetLink(metadata.AppendToOutgoingContext(ctx, "request-id", "abc"), &proto.GetLinkReq{Slug: "missing"})
+	require.Error(t, err)
+
+	md := <-fake.metadata
+	assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"), "the metadata should be merged with the one of the context")
+	assert.Equal(t, []string{"abc"}, md.Get("request-id"), "the metadata of the context should be kept")
+
+	var rerr *Error
+	require.ErrorAs(t, err, &rerr, "grpc errors should be converted")
+	assert.Equal(t, codes.NotFound, rerr.Code)
+	assert.Equal(t, "no link with slug missing found", rerr.Message)
+	assert.True(t, IsNotFound(err))
+
+	var export bytes.Buffer
+	require.NoError(t, client.ExportStats(ctx, &proto.ExportStatsReq{}, &export))
+	assert.Equal(t, "slug,target,date,hits,unique_visitors\nexists,http://google.com,2022-06-11,42,40\n", export.String(), "lines should be terminated like over rest")
+
+	var snapshot bytes.Buffer
+	require.NoError(t, client.SnapshotStore(ctx, &snapshot), "snapshots should be received whatever their size")
+	assert.Equal(t, 5<<20, snapshot.Len())
+
+	restored, err := client.RestoreStore(ctx, io.MultiReader(&snapshot, strings.NewReader("x")))
+	require.NoError(t, err, "snapshots should be restored whatever their size")
+	assert.EqualValues(t, 6, restored.Links, "snapshots should be streamed in chunks")
+	assert.EqualValues(t, 5<<20+1, restored.Webhooks, "the whole snapshot should be sent")
+
+	_, err = client.ListLinks(ctx)
+	require.ErrorAs(t, err, &rerr)
+	assert.Equal(t, codes.Unimplemented, rerr.Code)
+
+	wctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+
+	var hits []string
+	err = client.WatchHits(
+		wctx, &proto.WatchHitsReq{},
+		func(event *proto.HitEvent) error {
+			hits = append(hits, event.Slug)
+			return nil
+		},
+	)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "cancellations should be reported as the context error")
+	assert.Equal(t, []string{"exists"}, hits)
+	assert.Equal(t, []string{"Bearer token"}, (<-fake.metadata).Get("authorization"), "the metadata should be sent on streams")
+}
+
+func TestGRPCClientRetries(t *testing.T) {
+	fake, dialer := newgrpcserver(t)
+
+	client, err := NewGRPCClient(WithTarget("bufnet"), WithRetryPolicy(fastretries), dialer)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+
+	overview, err := client.GetOverview(ctx, &proto.OverviewReq{})
+	require.NoError(t, err, "unavailable errors should be retried")
+	assert.EqualValues(t, 1, overview.TotalLinks)
+	assert.EqualValues(t, 3, atomic.SwapInt32(&fake.attempts, 0))
+
+	_, err = client.CreateLink(ctx, &proto.CreateLinkReq{Target: "http://google.com"})
+	require.Error(t, err)
+	assert.EqualValues(t, 3, atomic.SwapInt32(&fake.attempts, 0), "creations should be retried with a random key")
+
+	key := (<-fake.metadata).Get(idempotencymetadata)
+	require.Len(t, key, 1, "a random key should be sent")
+	for i := 1; i < 3; i++ {
+		assert.Equal(t, key, (<-fake.metadata).Get(idempotencymetadata), "the same key should be sent on every attempt")
+	}
+
+	_, err = client.CreateLink(WithIdempotencyKey(ctx, "key"), &proto.CreateLinkReq{Target: "http://google.com"})
+	require.Error(t, err)
+	assert.EqualValues(t, 3, atomic.SwapInt32(&fake.attempts, 0), "creations with idempotency key should be retried")
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, []string{"key"}, (<-fake.metadata).Get(idempotencymetadata), "the key should be sent on every attempt")
+	}
+
+	_, err = client.CreateWebhook(WithIdempotencyKey(ctx, "key"), &proto.CreateWebhookReq{Url: "http://localhost/hook"})
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.SwapInt32(&fake.attempts, 0), "creations the service doesn't deduplicate shouldn't be retried even with idempotency key")
+
+	noretries, err := NewGRPCClient(WithTarget("bufnet"), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}), dialer)
+	require.NoError(t, err)
+	defer noretries.Close()
+
+	_, err = noretries.CreateLink(ctx, &proto.CreateLinkReq{Target: "http://google.com"})
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.SwapInt32(&fake.attempts, 0))
+	assert.Empty(t, (<-fake.metadata).Get(idempotencymetadata), "keys are only needed for retrying")
+}
+
+func TestNewClient(t *testing.T) {
+	tests := map[string]struct {
+		opts []ClientOpt
+
+		wantType Client
+		wantErr  string
+	}{
+		"rest by default": {
+			wantType: &Lnk{},
+		},
+		"grpc": {
+			opts:     []ClientOpt{WithTransport(TransportGRPC)},
+			wantType: &GRPC{},
+		},
+		"unknown transport": {
+			opts:    []ClientOpt{WithTransport(Transport(42))},
+			wantErr: "unknown transport",
+		},
+		"empty metadata key": {
+			opts:    []ClientOpt{WithMetadata("", "value")},
+			wantErr: "metadata key can't be empty",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := New(test.opts...)
+
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			defer client.Close()
+			assert.IsType(t, test.wantType, client)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+)
+
+// Transport the client talks to the service over.
+type Transport int
+
+const (
+	// TransportREST talks to the rest api exposed by the gateway.
+	TransportREST Transport = iota
+	// TransportGRPC talks grpc directly to the service.
+	TransportGRPC
+)
+
+// settings of the clients; the ones that don't apply to a transport are ignored
+// by its client.
+type settings struct {
+	transport Transport
+
+	baseurl    string
+	httpclient *http.Client
+
+	target    string
+	keepalive *keepalive.ClientParameters
+	dialopts  []grpc.DialOption
+
+	tls      *tls.Config
+	metadata metadata.MD
+
+	retry   RetryPolicy
+	timeout time.Duration
+	breaker *breaker
+}
+
+func newsettings(opts []ClientOpt) (*settings, error) {
+	cfg := settings{
+		transport: TransportREST,
+		baseurl:   "http://localhost:8000",
+		target:    "localhost:9000",
+		metadata:  metadata.MD{},
+		retry:     DefaultRetryPolicy(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return &cfg, nil
+}
+
+type ClientOpt func(cfg *settings) error
+
+// WithTransport sets the transport of the clients instantiated via New.
+func WithTransport(transport Transport) ClientOpt {
+	return func(cfg *settings) error {
+		if transport != TransportREST && transport != TransportGRPC {
+			return fmt.Errorf("unknown transport %d", transport)
+		}
+		cfg.transport = transport
+		return nil
+	}
+}
+
+// WithBaseUrl sets the url the rest api is reachable at.
+func WithBaseUrl(url string) ClientOpt {
+	return func(cfg *settings) error {
+		cfg.baseurl = strings.TrimSuffix(url, "/")
+		return nil
+	}
+}
+
+// WithHTTPClient sets the http client the rest requests are made with.
+// WithTLS doesn't apply to it, its transport has to be configured instead.
+func WithHTTPClient(client *http.Client) ClientOpt {
+	return func(cfg *settings) error {
+		if client == nil {
+			return fmt.Errorf("http client can't be nil")
+		}
+		cfg.httpclient = client
+		return nil
+	}
+}
+
+// WithTarget sets the address the grpc service is reachable at.
+func WithTarget(target string) ClientOpt {
+	return func(cfg *settings) error {
+		if target == "" {
+			return fmt.Errorf("target can't be empty")
+		}
+		cfg.target = target
+		return nil
+	}
+}
+
+// WithTLS makes the client connect over tls with the specified config.
+// Connections are made in plain text by default.
+func WithTLS(config *tls.Config) ClientOpt {
+	return func(cfg *settings) error {
+		if config == nil {
+			return fmt.Errorf("tls config can't be nil")
+		}
+		cfg.tls = config
+		return nil
+	}
+}
+
+// WithMetadata sends the key value pair as metadata on every call, e.g. for
+// authentication. Over the rest api it's sent as a header the gateway forwards
+// as metadata.
+func WithMetadata(key, value string) ClientOpt {
+	return func(cfg *settings) error {
+		if key == "" {
+			return fmt.Errorf("metadata key can't be empty")
+		}
+		cfg.metadata.Append(key, value)
+		return nil
+	}
+}
+
+// WithKeepalive sets how the grpc connection is kept alive while idle.
+func WithKeepalive(params keepalive.ClientParameters) ClientOpt {
+	return func(cfg *settings) error {
+		cfg.keepalive = &params
+		return nil
+	}
+}
+
+// WithDialOptions adds options to the ones the grpc connection is dialed with.
+func WithDialOptions(opts ...grpc.DialOption) ClientOpt {
+	return func(cfg *settings) error {
+		cfg.dialopts = append(cfg.dialopts, opts...)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets how the calls that fail with transient errors are retried.
+// By default the DefaultRetryPolicy is used.
+func WithRetryPolicy(policy RetryPolicy) ClientOpt {
+	return func(cfg *settings) error {
+		if policy.MaxAttempts < 1 {
+			return fmt.Errorf("there must be at least one attempt")
+		}
+		if policy.InitialBackoff < 0 || policy.MaxBackoff < policy.InitialBackoff {
+			return fmt.Errorf("invalid backoff range %s to %s", policy.InitialBackoff, policy.MaxBackoff)
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return fmt.Errorf("jitter must be between 0 and 1")
+		}
+		cfg.retry = policy
+		return nil
+	}
+}
+
+// WithTimeout sets how long each call can take overall, including its retries.
+// It doesn't apply to the calls that stream their results, which last until their
+// context is done.
+func WithTimeout(timeout time.Duration) ClientOpt {
+	return func(cfg *settings) error {
+		if timeout <= 0 {
+			return fmt.Errorf("timeout must be positive")
+		}
+		cfg.timeout = timeout
+		return nil
+	}
+}
+
+// WithCircuitBreaker makes the client fail right away with ErrCircuitOpen for the
+// cooldown period after the threshold of consecutive transient failures is reached,
+// instead of piling up calls to a service that's down.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOpt {
+	return func(cfg *settings) error {
+		if threshold < 1 {
+			return fmt.Errorf("threshold must be positive")
+		}
+		if cooldown <= 0 {
+			return fmt.Errorf("cooldown must be positive")
+		}
+		cfg.breaker = &breaker{threshold: threshold, cooldown: cooldown}
+		return nil
+	}
+}
@@ -0,0 +1,258 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxerrorbody caps how much of a failed response is read for building the error.
+const maxerrorbody = 64 << 10
+
+// maxstreamline caps the size of each of the lines of a streamed response.
+const maxstreamline = 4 << 20
+
+var unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// request sends the request to the api and returns the response if it succeeded,
+// retrying it as the retry policy allows.
+// The body of the response must be closed by the caller.
+func (lc *Lnk) request(ctx context.Context, method, path string, query url.Values, body proto.Message) (*http.Response, error) {
+	if body == nil {
+		return lc.send(ctx, method, path, query, nil, "")
+	}
+
+	payload, err := protojson.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing payload: %w", err)
+	}
+
+	return lc.send(ctx, method, path, query, payload, "application/json")
+}
+
+// send is request for payloads that are already encoded, like uploaded files.
+func (lc *Lnk) send(ctx context.Context, method, path string, query url.Values, payload []byte, contenttype string) (*http.Response, error) {
+	endpoint := lc.baseurl + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var resp *http.Response
+	err := lc.retrier.do(
+		ctx, idempotent(ctx, method, path),
+		func() error {
+			var err error
+			resp, err = lc.attempt(ctx, method, endpoint, payload, contenttype)
+			return err
+		},
+	)
+
+	return resp, err
+}
+
+// attempt sends the request once.
+func (lc *Lnk) attempt(ctx context.Context, method, endpoint string, payload []byte, contenttype string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %w", err)
+	}
+
+	for key, values := range lc.header {
+		req.Header[key] = values
+	}
+
+	if key := idempotencykeyfrom(ctx); key != "" {
+		req.Header.Set(IdempotencyHeader, key)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", contenttype)
+	}
+
+	resp, err := lc.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, maxerrorbody))
+		return nil, decodeError(resp.StatusCode, bytes.TrimSpace(content))
+	}
+
+	return resp, nil
+}
+
+// withtimeout bounds the context with the timeout of the client, if any.
+func (lc *Lnk) withtimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if lc.timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, lc.timeout)
+}
+
+// call sends the request and decodes the response into out, if not nil.
+func (lc *Lnk) call(ctx context.Context, method, path string, query url.Values, body, out proto.Message) error {
+	ctx, cancel := lc.withtimeout(ctx)
+	defer cancel()
+
+	resp, err := lc.request(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+
+	return decode(resp, out)
+}
+
+// decode the response into out, if not nil, closing its body.
+func decode(resp *http.Response, out proto.Message) error {
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := unmarshaler.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+
+	return nil
+}
+
+// streamchunk is each of the lines of a streamed response; the gateway wraps the
+// messages on a result field, and errors on an error one.
+type streamchunk struct {
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// stream sends the request and calls fn with each line of the streamed response,
+// until the stream ends, fn fails, or the context is done.
+func (lc *Lnk) stream(ctx context.Context, path string, query url.Values, fn func(line []byte) error) error {
+	resp, err := lc.request(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxstreamline)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		// errors that happen mid stream are sent as an error chunk
+		if bytes.HasPrefix(line, []byte(`{"error":`)) {
+			var chunk streamchunk
+			if err := json.Unmarshal(line, &chunk); err == nil && len(chunk.Error) > 0 {
+				var st spb.Status
+				if err := unmarshaler.Unmarshal(chunk.Error, &st); err == nil {
+					return errorFromStatus(resp.StatusCode, &st)
+				}
+			}
+		}
+
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+
+	return nil
+}
+
+// streammessages streams the response, decoding each of the messages with decode.
+func (lc *Lnk) streammessages(ctx context.Context, path string, query url.Values, decode func(result []byte) error) error {
+	return lc.stream(
+		ctx, path, query,
+		func(line []byte) error {
+			var chunk streamchunk
+			if err := json.Unmarshal(line, &chunk); err != nil {
+				return fmt.Errorf("error decoding stream: %w", err)
+			}
+
+			return decode(chunk.Result)
+		},
+	)
+}
+
+// queryparams encodes the populated fields of the message as query parameters,
+// skipping the ones that are part of the path.
+func queryparams(msg proto.Message, skip ...string) url.Values {
+	values := make(url.Values)
+
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipped[name] = true
+	}
+
+	msg.ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			name := string(fd.Name())
+			if skipped[name] {
+				return true
+			}
+
+			if fd.IsList() {
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					values.Add(fd.JSONName(), queryvalue(fd, list.Get(i)))
+				}
+				return true
+			}
+
+			values.Set(fd.JSONName(), queryvalue(fd, value))
+			return true
+		},
+	)
+
+	return values
+}
+
+func queryvalue(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		if ts, ok := value.Message().Interface().(*timestamppb.Timestamp); ok {
+			return ts.AsTime().Format(time.RFC3339Nano)
+		}
+		encoded, _ := protojson.Marshal(value.Message().Interface())
+		return string(encoded)
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(value.Bool())
+	case protoreflect.EnumKind:
+		return strconv.Itoa(int(value.Enum()))
+	default:
+		return value.String()
+	}
+}
@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/aexvir/lnk/proto"
+)
+
+// ErrCircuitOpen is returned without calling the service while the circuit breaker
+// is open, after too many consecutive transient failures.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// RetryPolicy of the calls that fail with a transient error, i.e. connection errors
+// and 502, 503 and 504 responses, or Unavailable over grpc.
+// Only idempotent calls are retried, which excludes the ones that create resources
+// unless they carry an idempotency key and the service deduplicates them, i.e. the
+// ones that create links and the batch deletes.
+type RetryPolicy struct {
+	// MaxAttempts of each call, including the first one; 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every retry
+	// up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Jitter is the fraction of every backoff that's randomized, from 0 to 1, so
+	// clients that failed at the same time don't retry in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy makes up to 3 attempts, backing off from 100ms up to 2s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Jitter:         0.2,
+	}
+}
+
+// backoff returns the wait before the retry that follows the attempt.
+func (rp RetryPolicy) backoff(attempt int) time.Duration {
+	wait := rp.InitialBackoff
+	for i := 1; i < attempt && wait < rp.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > rp.MaxBackoff {
+		wait = rp.MaxBackoff
+	}
+
+	return wait - time.Duration(rp.Jitter*rand.Float64()*float64(wait))
+}
+
+// retrier runs the calls of a client with its retry policy and circuit breaker.
+type retrier struct {
+	policy  RetryPolicy
+	breaker *breaker // nil if circuit breaking is disabled
+}
+
+// do calls attempt until it succeeds, fails with a non transient error or runs out
+// of attempts. Calls that aren't idempotent are attempted once.
+func (r *retrier) do(ctx context.Context, idempotent bool, attempt func() error) error {
+	attempts := r.policy.MaxAttempts
+	if !idempotent || attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for i := 1; ; i++ {
+		if !r.breaker.allow() {
+			// retries cut short by the breaker fail with the last error
+			if err != nil {
+				return err
+			}
+			return ErrCircuitOpen
+		}
+
+		err = attempt()
+		retry := transient(ctx, err)
+
+		if ctx.Err() != nil {
+			// cancelled calls say nothing about the health of the service
+			r.breaker.release()
+		} else {
+			r.breaker.record(!retry)
+		}
+
+		if err == nil || !retry || i >= attempts {
+			return err
+		}
+
+		timer := time.NewTimer(r.policy.backoff(i))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// transient checks whether the error is caused by a failure that may go away by
+// trying again.
+func transient(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var rerr *Error
+	if errors.As(err, &rerr) {
+		switch rerr.HTTPStatus {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		case 0:
+			return rerr.Code == codes.Unavailable
+		default:
+			return false
+		}
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st.Code() == codes.Unavailable
+	}
+
+	// the request didn't make it to the service
+	var uerr *url.Error
+	return errors.As(err, &uerr)
+}
+
+// breaker opens after a number of consecutive transient failures, rejecting calls
+// for a cooldown period; then it lets a single call through to probe the service,
+// closing again if it succeeds.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mutex     sync.Mutex
+	failures  int
+	openuntil time.Time
+	probing   bool
+}
+
+func (b *breaker) allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+
+	if b.probing || time.Now().Before(b.openuntil) {
+		return false
+	}
+
+	b.probing = true
+	return true
+}
+
+// record the outcome of a call; failures are only the transient ones, as other
+// errors mean the service is up.
+func (b *breaker) record(success bool) {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.probing = false
+
+	if success {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openuntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// release the probe of a call whose outcome is unknown.
+func (b *breaker) release() {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	b.probing = false
+	b.mutex.Unlock()
+}
+
+// IdempotencyHeader is the header the idempotency keys are sent on over rest; over
+// grpc they're sent as the idempotency-key metadata.
+const IdempotencyHeader = "Idempotency-Key"
+
+const idempotencymetadata = "idempotency-key"
+
+type idempotencykey struct{}
+
+// WithIdempotencyKey attaches the key to the calls made with the context, so the
+// service can recognize retries of the calls that create links and of the batch
+// deletes, which makes them safe to retry. Other calls that create resources are
+// never retried, as the service ignores their keys.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencykey{}, key)
+}
+
+// withkey attaches the idempotency key of the request to the context of a call that
+// creates resources; if it has none, and neither the context, a random one is used
+// if retries are enabled, so the call can be retried safely.
+func (r *retrier) withkey(ctx context.Context, key string) context.Context {
+	if key != "" {
+		return WithIdempotencyKey(ctx, key)
+	}
+
+	if r.policy.MaxAttempts < 2 || idempotencykeyfrom(ctx) != "" {
+		return ctx
+	}
+
+	return WithIdempotencyKey(ctx, uuid.NewString())
+}
+
+func idempotencykeyfrom(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencykey{}).(string)
+	return key
+}
+
+// idempotent checks whether a rest call is safe to retry; post requests are only
+// retried if the service deduplicates them and they carry an idempotency key.
+func idempotent(ctx context.Context, method, path string) bool {
+	return method != http.MethodPost || (deduplicated[path] && idempotencykeyfrom(ctx) != "")
+}
+
+// dedupedrpcs are the rpcs the service deduplicates by idempotency key, so retrying
+// them with the same key doesn't run them again. The rest of the ones that create or
+// change resources ignore the key.
+var dedupedrpcs = []string{"CreateLink", "BatchCreateLinks", "BatchDeleteLinks"}
+
+// unsafemethods are the grpc methods mapped to post requests, and deduplicated the
+// grpc methods and rest paths of the dedupedrpcs, which like over rest are the only
+// unsafe ones retried, if they carry an idempotency key.
+var unsafemethods, deduplicated = func() (map[string]bool, map[string]bool) {
+	unsafe := make(map[string]bool)
+	deduped := make(map[string]bool)
+
+	service := proto.File_lnk_proto.Services().ByName("Links")
+	methods := service.Methods()
+
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		rule, _ := protobuf.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if rule.GetPost() == "" {
+			continue
+		}
+
+		name := "/" + string(service.FullName()) + "/" + string(method.Name())
+		unsafe[name] = true
+
+		for _, rpc := range dedupedrpcs {
+			if string(method.Name()) == rpc {
+				deduped[name] = true
+				deduped[rule.GetPost()] = true
+			}
+		}
+	}
+
+	return unsafe, deduped
+}()
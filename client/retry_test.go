@@ -0,0 +1,277 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/proto"
+)
+
+// fastretries is a retry policy that doesn't slow tests down.
+var fastretries = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Jitter: 0.5}
+
+	tests := map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	}
+
+	for attempt, want := range tests {
+		for i := 0; i < 10; i++ {
+			got := policy.backoff(attempt)
+			assert.LessOrEqual(t, got, want, "backoff after attempt %d is too long", attempt)
+			assert.GreaterOrEqual(t, got, want/2, "backoff after attempt %d is jittered too much", attempt)
+		}
+	}
+}
+
+// randomkey is a placeholder for expecting a random idempotency key.
+const randomkey = "random"
+
+func TestClientRetries(t *testing.T) {
+	tests := map[string]struct {
+		failures int // amount of requests that fail before succeeding
+		status   int // status the failed requests fail with
+		call     func(ctx context.Context, client *Lnk) error
+
+		wantAttempts int32
+		wantErr      string
+		wantKey      string
+	}{
+		"reads are retried": {
+			failures:     2,
+			status:       http.StatusServiceUnavailable,
+			call:         func(ctx context.Context, client *Lnk) error { _, err := client.ListLinks(ctx); return err },
+			wantAttempts: 3,
+		},
+		"retries run out": {
+			failures:     5,
+			status:       http.StatusBadGateway,
+			call:         func(ctx context.Context, client *Lnk) error { _, err := client.ListLinks(ctx); return err },
+			wantAttempts: 3,
+			wantErr:      "status: 502",
+		},
+		"non transient errors aren't retried": {
+			failures:     5,
+			status:       http.StatusInternalServerError,
+			call:         func(ctx context.Context, client *Lnk) error { return client.DeleteLink(ctx, "abc") },
+			wantAttempts: 1,
+			wantErr:      "status: 500",
+		},
+		"creations aren't retried": {
+			failures: 1,
+			status:   http.StatusGatewayTimeout,
+			call: func(ctx context.Context, client *Lnk) error {
+				_, err := client.CreateWebhook(ctx, &proto.CreateWebhookReq{Url: "http://localhost/hook"})
+				return err
+			},
+			wantAttempts: 1,
+			wantErr:      "status: 504",
+		},
+		"creations the service doesn't deduplicate aren't retried even with idempotency key": {
+			failures: 1,
+			status:   http.StatusGatewayTimeout,
+			call: func(ctx context.Context, client *Lnk) error {
+				_, err := client.CreateWebhook(WithIdempotencyKey(ctx, "key"), &proto.CreateWebhookReq{Url: "http://localhost/hook"})
+				return err
+			},
+			wantAttempts: 1,
+			wantErr:      "status: 504",
+			wantKey:      "key",
+		},
+		"imports aren't retried even with idempotency key": {
+			failures: 1,
+			status:   http.StatusServiceUnavailable,
+			call: func(ctx context.Context, client *Lnk) error {
+				_, err := client.ImportLinks(WithIdempotencyKey(ctx, "key"), &proto.ImportLinksReq{Format: "csv"})
+				return err
+			},
+			wantAttempts: 1,
+			wantErr:      "status: 503",
+			wantKey:      "key",
+		},
+		"batch deletes with idempotency key are retried": {
+			failures: 1,
+			status:   http.StatusGatewayTimeout,
+			call: func(ctx context.Context, client *Lnk) error {
+				_, err := client.BatchDeleteLinks(ctx, &proto.BatchDeleteLinksReq{Slugs: []string{"abc"}, IdempotencyKey: "key"})
+				return err
+			},
+			wantAttempts: 2,
+			wantKey:      "key",
+		},
+		"link creations get a random key": {
+			failures: 2,
+			status:   http.StatusGatewayTimeout,
+			call: func(ctx context.Context, client *Lnk) error {
+				_, err := client.CreateLink(ctx, &proto.CreateLinkReq{Target: "http://google.com"})
+				return err
+			},
+			wantAttempts: 3,
+			wantKey:      randomkey,
+		},
+		"link creations use the key of the request": {
+			failures: 1,
+			status:   http.StatusGatewayTimeout,
+			call: func(ctx context.Context, client *Lnk) error {
+				_, err := client.CreateLink(ctx, &proto.CreateLinkReq{Target: "http://google.com", IdempotencyKey: "key"})
+				return err
+			},
+			wantAttempts: 2,
+			wantKey:      "key",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			var keys sync.Map
+
+			downstream := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						key := r.Header.Get(IdempotencyHeader)
+						keys.Store(key, true)
+
+						if test.wantKey != randomkey {
+							assert.Equal(t, test.wantKey, key)
+						}
+
+						if atomic.AddInt32(&attempts, 1) <= int32(test.failures) {
+							w.WriteHeader(test.status)
+							return
+						}
+						_, _ = w.Write([]byte("{}"))
+					},
+				),
+			)
+			defer downstream.Close()
+
+			client, err := NewLnkClient(WithBaseUrl(downstream.URL), WithRetryPolicy(fastretries))
+			require.NoError(t, err)
+
+			err = test.call(context.Background(), client)
+			assert.Equal(t, test.wantAttempts, atomic.LoadInt32(&attempts), "unexpected amount of attempts")
+
+			if test.wantKey == randomkey {
+				var sent []string
+				keys.Range(func(key, _ any) bool { sent = append(sent, key.(string)); return true })
+				require.Len(t, sent, 1, "every attempt should carry the same key")
+				assert.NotEmpty(t, sent[0])
+			}
+
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClientConnectionErrors(t *testing.T) {
+	downstream := httptest.NewServer(http.NotFoundHandler())
+	downstream.Close()
+
+	client, err := NewLnkClient(WithBaseUrl(downstream.URL), WithRetryPolicy(fastretries), WithCircuitBreaker(2, time.Hour))
+	require.NoError(t, err)
+
+	_, err = client.ListLinks(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error making request", "the connection error should be returned")
+
+	_, err = client.ListLinks(context.Background())
+	assert.ErrorIs(t, err, ErrCircuitOpen, "the failed attempts should have opened the circuit")
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	var attempts int32
+	var healthy atomic.Value
+	healthy.Store(false)
+
+	downstream := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				if !healthy.Load().(bool) {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write([]byte("{}"))
+			},
+		),
+	)
+	defer downstream.Close()
+
+	client, err := NewLnkClient(
+		WithBaseUrl(downstream.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithCircuitBreaker(2, 50*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err = client.ListLinks(ctx)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen, "the circuit should be closed until the threshold is reached")
+	}
+
+	_, err = client.ListLinks(ctx)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&attempts), "calls shouldn't reach the service while the circuit is open")
+
+	time.Sleep(60 * time.Millisecond)
+
+	_, err = client.ListLinks(ctx)
+	assert.NotErrorIs(t, err, ErrCircuitOpen, "a probe should be let through after the cooldown")
+	_, err = client.ListLinks(ctx)
+	assert.ErrorIs(t, err, ErrCircuitOpen, "a failed probe should open the circuit again")
+
+	time.Sleep(60 * time.Millisecond)
+	healthy.Store(true)
+
+	_, err = client.ListLinks(ctx)
+	require.NoError(t, err, "a successful probe should close the circuit")
+	_, err = client.ListLinks(ctx)
+	require.NoError(t, err)
+}
+
+func TestClientTimeout(t *testing.T) {
+	downstream := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(time.Second):
+				case <-r.Context().Done():
+				}
+			},
+		),
+	)
+	defer downstream.Close()
+
+	client, err := NewLnkClient(WithBaseUrl(downstream.URL), WithTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.ListLinks(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "the call should have been cut short")
+}
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// Hit is a visit to a link, as broadcast to subscribers.
+type Hit struct {
+	storage.Hit
+	// Target is the url the visitor was redirected to.
+	Target string
+}
+
+// Broker fans out the published hits to every subscriber interested in them.
+type Broker struct {
+	buffer int
+
+	subs   map[*Subscription]struct{}
+	closed bool
+	mutex  sync.RWMutex
+}
+
+// NewBroker instantiates a broker without subscribers.
+// By default each subscriber can have up to 256 pending hits; this can be
+// customized via Options.
+func NewBroker(opts ...Option) (*Broker, error) {
+	broker := Broker{
+		buffer: 256,
+		subs:   make(map[*Subscription]struct{}),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&broker); err != nil {
+			return nil, err
+		}
+	}
+
+	return &broker, nil
+}
+
+// Publish hands the hit over to the subscribers interested in its link.
+// It never blocks; subscribers whose buffer is full miss the hit.
+func (b *Broker) Publish(hit Hit) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for sub := range b.subs {
+		if !sub.wants(hit.Slug) {
+			continue
+		}
+
+		select {
+		case sub.events <- hit:
+		default:
+			atomic.AddUint64(&sub.dropped, 1)
+		}
+	}
+}
+
+// Subscribe starts receiving the hits of the specified links, or of every link if
+// none is specified.
+// Subscriptions have to be closed once they're not needed anymore.
+func (b *Broker) Subscribe(slugs ...string) *Subscription {
+	sub := Subscription{
+		broker: b,
+		events: make(chan Hit, b.buffer),
+	}
+
+	if len(slugs) > 0 {
+		sub.slugs = make(map[string]struct{}, len(slugs))
+		for _, slug := range slugs {
+			sub.slugs[slug] = struct{}{}
+		}
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		close(sub.events)
+		return &sub
+	}
+
+	b.subs[&sub] = struct{}{}
+
+	return &sub
+}
+
+// Subscribers returns the amount of active subscriptions.
+func (b *Broker) Subscribers() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return len(b.subs)
+}
+
+// Close ends every subscription, so their consumers can finish, e.g. when
+// shutting down. Later subscriptions are closed right away.
+func (b *Broker) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.closed = true
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Subscription receives the hits published on a broker.
+type Subscription struct {
+	broker  *Broker
+	slugs   map[string]struct{}
+	events  chan Hit
+	dropped uint64
+}
+
+// Events returns the channel the hits are delivered on.
+// It's closed when the subscription or the broker are closed.
+func (s *Subscription) Events() <-chan Hit {
+	return s.events
+}
+
+// Dropped returns how many hits were missed because the buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Close stops receiving hits. It's safe to call it more than once.
+func (s *Subscription) Close() {
+	s.broker.mutex.Lock()
+	defer s.broker.mutex.Unlock()
+
+	if _, active := s.broker.subs[s]; !active {
+		return
+	}
+
+	delete(s.broker.subs, s)
+	close(s.events)
+}
+
+func (s *Subscription) wants(slug string) bool {
+	if s.slugs == nil {
+		return true
+	}
+
+	_, found := s.slugs[slug]
+	return found
+}
+
+type Option func(broker *Broker) error
+
+// WithBufferSize sets how many hits can be pending for each subscriber.
+func WithBufferSize(size int) Option {
+	return func(broker *Broker) error {
+		if size < 1 {
+			return errors.New("buffer size must be positive")
+		}
+		broker.buffer = size
+		return nil
+	}
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func hit(slug string) Hit {
+	return Hit{Hit: storage.Hit{Slug: slug}, Target: "https://example.com/" + slug}
+}
+
+func TestBrokerFiltering(t *testing.T) {
+	broker, err := NewBroker()
+	require.NoError(t, err)
+
+	all := broker.Subscribe()
+	defer all.Close()
+	filtered := broker.Subscribe("bbb")
+	defer filtered.Close()
+
+	for _, slug := range []string{"aaa", "bbb", "ccc"} {
+		broker.Publish(hit(slug))
+	}
+
+	require.Len(t, all.Events(), 3, "the subscription without filters should get every hit")
+	for _, slug := range []string{"aaa", "bbb", "ccc"} {
+		assert.Equal(t, hit(slug), <-all.Events())
+	}
+
+	require.Len(t, filtered.Events(), 1, "the filtered subscription should only get the hits of its links")
+	assert.Equal(t, hit("bbb"), <-filtered.Events())
+}
+
+func TestBrokerSlowSubscriber(t *testing.T) {
+	broker, err := NewBroker(WithBufferSize(2))
+	require.NoError(t, err)
+
+	slow := broker.Subscribe()
+	defer slow.Close()
+
+	for i := 0; i < 5; i++ {
+		broker.Publish(hit("aaa")) // would block forever if it waited for the subscriber
+	}
+
+	assert.Len(t, slow.Events(), 2, "only the hits that fit on the buffer should be delivered")
+	assert.EqualValues(t, 3, slow.Dropped(), "the rest of hits should be dropped")
+}
+
+func TestBrokerClose(t *testing.T) {
+	broker, err := NewBroker()
+	require.NoError(t, err)
+
+	sub := broker.Subscribe()
+	other := broker.Subscribe()
+	assert.Equal(t, 2, broker.Subscribers())
+
+	sub.Close()
+	sub.Close()
+	assert.Equal(t, 1, broker.Subscribers(), "closed subscriptions should be removed")
+
+	_, open := <-sub.Events()
+	assert.False(t, open, "the events of closed subscriptions should be closed")
+
+	broker.Close()
+	_, open = <-other.Events()
+	assert.False(t, open, "closing the broker should close every subscription")
+	other.Close()
+
+	late := broker.Subscribe()
+	_, open = <-late.Events()
+	assert.False(t, open, "subscriptions to a closed broker should be closed right away")
+
+	broker.Publish(hit("aaa")) // shouldn't panic after closing
+}
+
+func TestBrokerOptions(t *testing.T) {
+	_, err := NewBroker(WithBufferSize(0))
+	assert.Error(t, err, "the buffer size should be validated")
+}
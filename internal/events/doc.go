@@ -0,0 +1,6 @@
+// Package events broadcasts link hits to in-process subscribers as they happen,
+// e.g. for displaying link activity live.
+//
+// Every subscriber has its own bounded buffer; events that don't fit on it are
+// dropped instead of waiting, so slow subscribers never stall the publishers.
+package events
@@ -0,0 +1,6 @@
+// Package export renders link stats in formats meant for spreadsheets and data
+// pipelines, CSV and newline delimited JSON.
+//
+// Exports are produced line by line, so they can be streamed without holding
+// every link in memory.
+package export
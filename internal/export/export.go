@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// Format of the exported stats.
+type Format string
+
+const (
+	// CSV exports one row per link and histogram bucket, preceded by a header row.
+	CSV Format = "csv"
+	// NDJSON exports one json object per link, including its whole histogram.
+	NDJSON Format = "ndjson"
+)
+
+// ParseFormat parses the export format, defaulting to CSV if empty.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case "", CSV:
+		return CSV, nil
+	case NDJSON:
+		return NDJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q, expected csv or ndjson", value)
+	}
+}
+
+// ContentType returns the mime type of the format.
+func (f Format) ContentType() string {
+	if f == NDJSON {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv"
+}
+
+// csvheader are the columns of the csv export.
+var csvheader = []string{"slug", "target", "date", "hits", "unique_visitors"}
+
+// Encoder renders links as lines of an export.
+// Lines are handed over without the trailing line terminator.
+type Encoder struct {
+	format Format
+	query  storage.StatsQuery
+}
+
+// NewEncoder returns an encoder for the format, that aggregates the link histograms
+// as specified by the query.
+func NewEncoder(format Format, query storage.StatsQuery) *Encoder {
+	return &Encoder{format: format, query: query}
+}
+
+// Header emits the lines that go before any link, if the format has any.
+func (e *Encoder) Header(emit func(line []byte) error) error {
+	if e.format != CSV {
+		return nil
+	}
+
+	line, err := csvline(csvheader)
+	if err != nil {
+		return err
+	}
+
+	return emit(line)
+}
+
+// Encode emits the lines that represent the link.
+func (e *Encoder) Encode(link *storage.Link, emit func(line []byte) error) error {
+	series := e.query.Series(link.Histogram)
+	visitors := e.query.UniqueVisitors(link.DailyVisitors)
+
+	if e.format == NDJSON {
+		rec := record{
+			Slug:      link.Slug,
+			Target:    link.Target,
+			Hits:      link.Hits,
+			BotHits:   link.BotHits,
+			Histogram: make([]bucket, 0, len(series)),
+		}
+		if link.Visitors != nil {
+			rec.UniqueVisitors = link.Visitors.Estimate()
+		}
+
+		for _, b := range series {
+			entry := bucket{Date: e.query.Granularity.Format(b.Start), Hits: b.Hits}
+			if visitors != nil {
+				unique := visitors[b.Start]
+				entry.UniqueVisitors = &unique
+			}
+			rec.Histogram = append(rec.Histogram, entry)
+		}
+
+		line, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("error encoding link %s: %w", link.Slug, err)
+		}
+
+		return emit(line)
+	}
+
+	for _, b := range series {
+		// unique visitors are left empty for hourly buckets, as they're not tracked
+		unique := ""
+		if visitors != nil {
+			unique = strconv.FormatUint(visitors[b.Start], 10)
+		}
+
+		line, err := csvline(
+			[]string{
+				link.Slug,
+				link.Target,
+				e.query.Granularity.Format(b.Start),
+				strconv.FormatUint(b.Hits, 10),
+				unique,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error encoding link %s: %w", link.Slug, err)
+		}
+
+		if err := emit(line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// record is the json representation of a link on the ndjson export.
+type record struct {
+	Slug           string   `json:"slug"`
+	Target         string   `json:"target"`
+	Hits           uint64   `json:"hits"`
+	BotHits        uint64   `json:"bot_hits"`
+	UniqueVisitors uint64   `json:"unique_visitors"`
+	Histogram      []bucket `json:"histogram"`
+}
+
+type bucket struct {
+	Date           string  `json:"date"`
+	Hits           uint64  `json:"hits"`
+	UniqueVisitors *uint64 `json:"unique_visitors,omitempty"`
+}
+
+// csvline encodes a single csv record, quoting the fields when needed.
+func csvline(fields []string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(fields); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,106 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/hll"
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  Format
+		fails bool
+	}{
+		"default":     {value: "", want: CSV},
+		"csv":         {value: "csv", want: CSV},
+		"ndjson":      {value: "NDJSON", want: NDJSON},
+		"unsupported": {value: "xlsx", fails: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			format, err := ParseFormat(test.value)
+			if test.fails {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, format)
+		})
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	visitors := hll.New()
+	visitors.Add(0x9e3779b97f4a7c15)
+
+	link := &storage.Link{
+		Slug:   "b8f8ea",
+		Target: "https://example.com/?a=1,2",
+		Hits:   5,
+		Histogram: map[string]uint64{
+			"2022-06-11T12": 2,
+			"2022-06-13T08": 3,
+		},
+		Visitors:      visitors,
+		DailyVisitors: map[string]*hll.Sketch{"2022-06-11": visitors},
+		BotHits:       1,
+	}
+
+	tests := map[string]struct {
+		format Format
+		query  storage.StatsQuery
+		want   []string
+	}{
+		"csv": {
+			format: CSV,
+			query:  storage.DefaultStatsQuery(),
+			want: []string{
+				"slug,target,date,hits,unique_visitors",
+				`b8f8ea,"https://example.com/?a=1,2",2022-06-11,2,1`,
+				`b8f8ea,"https://example.com/?a=1,2",2022-06-13,3,0`,
+			},
+		},
+		"csv hourly": {
+			format: CSV,
+			query:  storage.StatsQuery{Granularity: storage.GranularityHour},
+			want: []string{
+				"slug,target,date,hits,unique_visitors",
+				`b8f8ea,"https://example.com/?a=1,2",2022-06-11T12:00,2,`,
+				`b8f8ea,"https://example.com/?a=1,2",2022-06-13T08:00,3,`,
+			},
+		},
+		"ndjson": {
+			format: NDJSON,
+			query:  storage.DefaultStatsQuery(),
+			want: []string{
+				`{"slug":"b8f8ea","target":"https://example.com/?a=1,2","hits":5,"bot_hits":1,"unique_visitors":1,` +
+					`"histogram":[{"date":"2022-06-11","hits":2,"unique_visitors":1},{"date":"2022-06-13","hits":3,"unique_visitors":0}]}`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var lines []string
+			emit := func(line []byte) error {
+				assert.False(t, strings.HasSuffix(string(line), "\n"), "lines shouldn't include the terminator")
+				lines = append(lines, string(line))
+				return nil
+			}
+
+			encoder := NewEncoder(test.format, test.query)
+			require.NoError(t, encoder.Header(emit))
+			require.NoError(t, encoder.Encode(link, emit))
+
+			assert.Equal(t, test.want, lines)
+		})
+	}
+}
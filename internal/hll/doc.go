@@ -0,0 +1,6 @@
+// Package hll implements the HyperLogLog cardinality estimator.
+//
+// Sketches count distinct elements using a fixed amount of memory, and can be
+// merged, so counts from different processes can be combined without double
+// counting the elements they have in common.
+package hll
@@ -0,0 +1,166 @@
+package hll
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	// Precision used by New; 2^11 registers, for a standard error of about 2.3%.
+	Precision = 11
+
+	minprecision = 4
+	maxprecision = 16
+	version      = 1
+)
+
+// Sketch estimates the amount of distinct 64-bit hashes added to it.
+// The zero value is not usable, sketches have to be created via New or
+// NewWithPrecision, or unmarshalled.
+type Sketch struct {
+	precision uint8
+	registers []uint8
+}
+
+// New creates an empty sketch with the default precision.
+func New() *Sketch {
+	sketch, _ := NewWithPrecision(Precision)
+	return sketch
+}
+
+// NewWithPrecision creates an empty sketch with 2^precision registers.
+// Higher precisions are more accurate, but use more memory.
+func NewWithPrecision(precision uint8) (*Sketch, error) {
+	if precision < minprecision || precision > maxprecision {
+		return nil, fmt.Errorf("precision must be between %d and %d", minprecision, maxprecision)
+	}
+
+	return &Sketch{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}, nil
+}
+
+// Add a hash to the sketch.
+// Hashes have to be uniformly distributed, like the ones from cryptographic hash
+// functions, for the estimations to be accurate.
+func (s *Sketch) Add(hash uint64) {
+	idx := hash >> (64 - s.precision)
+	// the sentinel bit caps the rank when the remaining bits are all zero
+	rest := hash<<s.precision | 1<<(s.precision-1)
+	rank := uint8(bits.LeadingZeros64(rest)) + 1
+
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// Merge the other sketch into this one, so this one estimates the union of both.
+func (s *Sketch) Merge(other *Sketch) error {
+	if s.precision != other.precision {
+		return fmt.Errorf("can't merge sketches with precisions %d and %d", s.precision, other.precision)
+	}
+
+	for idx, rank := range other.registers {
+		if rank > s.registers[idx] {
+			s.registers[idx] = rank
+		}
+	}
+
+	return nil
+}
+
+// Clone returns an independent copy of the sketch.
+func (s *Sketch) Clone() *Sketch {
+	registers := make([]uint8, len(s.registers))
+	copy(registers, s.registers)
+
+	return &Sketch{precision: s.precision, registers: registers}
+}
+
+// Estimate the amount of distinct hashes added to the sketch.
+func (s *Sketch) Estimate() uint64 {
+	m := float64(len(s.registers))
+
+	sum := 0.0
+	zeros := 0
+	for _, rank := range s.registers {
+		sum += 1 / float64(uint64(1)<<rank)
+		if rank == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(m) * m * m / sum
+
+	// small cardinalities are estimated more accurately with linear counting
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(estimate + 0.5)
+}
+
+func alpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
+
+// MarshalBinary encodes the sketch as a version byte, the precision and the registers.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+len(s.registers))
+	data = append(data, version, s.precision)
+	return append(data, s.registers...), nil
+}
+
+// UnmarshalBinary decodes a sketch encoded by MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != version {
+		return errors.New("unsupported sketch encoding")
+	}
+
+	precision := data[1]
+	if precision < minprecision || precision > maxprecision || len(data)-2 != 1<<precision {
+		return errors.New("malformed sketch")
+	}
+
+	s.precision = precision
+	s.registers = make([]uint8, 1<<precision)
+	copy(s.registers, data[2:])
+
+	return nil
+}
+
+// MarshalText encodes the binary representation of the sketch as base64, so
+// sketches are serialized as strings on json documents.
+func (s *Sketch) MarshalText() ([]byte, error) {
+	data, _ := s.MarshalBinary()
+
+	text := make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+	base64.StdEncoding.Encode(text, data)
+
+	return text, nil
+}
+
+// UnmarshalText decodes a sketch encoded by MarshalText.
+func (s *Sketch) UnmarshalText(text []byte) error {
+	data := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+
+	n, err := base64.StdEncoding.Decode(data, text)
+	if err != nil {
+		return fmt.Errorf("malformed sketch: %w", err)
+	}
+
+	return s.UnmarshalBinary(data[:n])
+}
@@ -0,0 +1,71 @@
+package hll
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hash(value string) uint64 {
+	sum := sha256.Sum256([]byte(value))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+func TestSketchEstimate(t *testing.T) {
+	for _, cardinality := range []int{0, 1, 10, 1000, 100000} {
+		t.Run(fmt.Sprint(cardinality), func(t *testing.T) {
+			sketch := New()
+
+			// every element is added twice, duplicates shouldn't count
+			for i := 0; i < cardinality; i++ {
+				sketch.Add(hash(fmt.Sprint(i)))
+				sketch.Add(hash(fmt.Sprint(i)))
+			}
+
+			assert.InEpsilon(t, float64(cardinality)+1, float64(sketch.Estimate())+1, 0.05, "the estimate is too far off")
+		})
+	}
+}
+
+func TestSketchMerge(t *testing.T) {
+	first, second := New(), New()
+
+	// both sketches share half of the elements
+	for i := 0; i < 20000; i++ {
+		first.Add(hash(fmt.Sprint(i)))
+	}
+	for i := 10000; i < 30000; i++ {
+		second.Add(hash(fmt.Sprint(i)))
+	}
+
+	union := first.Clone()
+	require.NoError(t, union.Merge(second), "sketches with the same precision should merge")
+
+	assert.InEpsilon(t, 30000, float64(union.Estimate()), 0.05, "the union estimate is too far off")
+	assert.InEpsilon(t, 20000, float64(first.Estimate()), 0.05, "merging into a clone shouldn't modify the original")
+
+	other, err := NewWithPrecision(Precision + 1)
+	require.NoError(t, err)
+	assert.Error(t, union.Merge(other), "sketches with different precisions can't be merged")
+}
+
+func TestSketchSerialization(t *testing.T) {
+	sketch := New()
+	for i := 0; i < 500; i++ {
+		sketch.Add(hash(fmt.Sprint(i)))
+	}
+
+	payload, err := json.Marshal(map[string]*Sketch{"visitors": sketch})
+	require.NoError(t, err, "sketches should be serializable as json")
+
+	var decoded map[string]*Sketch
+	require.NoError(t, json.Unmarshal(payload, &decoded), "sketches should be deserializable from json")
+	assert.Equal(t, sketch, decoded["visitors"], "the sketch should survive the roundtrip")
+
+	assert.Error(t, new(Sketch).UnmarshalBinary([]byte{version, Precision, 1, 2}), "truncated sketches should be rejected")
+}
@@ -0,0 +1,193 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrKeyReused is returned when a key is sent again along a different request.
+var ErrKeyReused = errors.New("idempotency key was already used for a different request")
+
+// Cache remembers the results of the requests made with an idempotency key, so
+// retries of a request get the result of the first attempt instead of running it
+// again.
+// Keys are remembered for a time window, and only the latest ones are kept when
+// there are too many of them.
+type Cache[T any] struct {
+	cfg config
+
+	entries map[string]*entry[T]
+	queue   []queued[T] // entries in the order they expire
+	mutex   sync.Mutex
+	now     func() time.Time
+}
+
+type entry[T any] struct {
+	fingerprint [sha256.Size]byte
+	expires     time.Time
+
+	done  chan struct{} // closed once the result is ready
+	value T
+	err   error
+}
+
+type queued[T any] struct {
+	key   string
+	entry *entry[T]
+}
+
+// NewCache instantiates an empty cache.
+// By default keys are remembered for 24 hours, up to 100000 of them; this can be
+// customized via Options.
+func NewCache[T any](opts ...Option) (*Cache[T], error) {
+	cfg := config{
+		window:  24 * time.Hour,
+		maxkeys: 100000,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Cache[T]{
+		cfg:     cfg,
+		entries: make(map[string]*entry[T]),
+		now:     time.Now,
+	}, nil
+}
+
+// Do runs fn for the first request made with the key, and returns its result to the
+// later ones, waiting for it if the first one is still running.
+// The fingerprint identifies the contents of the request; reusing the key with a
+// different one fails with ErrKeyReused.
+// Failed requests are forgotten, so they can be retried with the same key.
+func (c *Cache[T]) Do(ctx context.Context, key string, fingerprint []byte, fn func() (T, error)) (T, error) {
+	var zero T
+	hash := sha256.Sum256(fingerprint)
+
+	c.mutex.Lock()
+	c.expire(false)
+
+	if existing, ok := c.entries[key]; ok {
+		c.mutex.Unlock()
+
+		if existing.fingerprint != hash {
+			return zero, ErrKeyReused
+		}
+
+		select {
+		case <-existing.done:
+			return existing.value, existing.err
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+
+	c.expire(true)
+
+	current := &entry[T]{
+		fingerprint: hash,
+		expires:     c.now().Add(c.cfg.window),
+		done:        make(chan struct{}),
+	}
+	c.entries[key] = current
+	c.queue = append(c.queue, queued[T]{key: key, entry: current})
+	c.mutex.Unlock()
+
+	current.value, current.err = fn()
+	close(current.done)
+
+	if current.err != nil {
+		c.mutex.Lock()
+		c.discard(key, current)
+		c.mutex.Unlock()
+	}
+
+	return current.value, current.err
+}
+
+// Len returns the amount of keys remembered.
+func (c *Cache[T]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.expire(false)
+	return len(c.entries)
+}
+
+// expire forgets the keys whose window elapsed, and if room has to be made for
+// another key, the oldest ones while there are too many.
+// It must be called with the lock held.
+func (c *Cache[T]) expire(room bool) {
+	now := c.now()
+
+	for len(c.queue) > 0 {
+		oldest := c.queue[0]
+		full := room && len(c.entries) >= c.cfg.maxkeys
+		if !full && now.Before(oldest.entry.expires) {
+			return
+		}
+
+		c.forget(oldest.key, oldest.entry)
+		c.queue[0] = queued[T]{}
+		c.queue = c.queue[1:]
+	}
+}
+
+// discard forgets a failed entry right away instead of once its window elapses, so
+// failures don't pile up on the queue.
+// Failed entries are usually the latest ones, so the queue is searched from its end.
+func (c *Cache[T]) discard(key string, target *entry[T]) {
+	c.forget(key, target)
+
+	for i := len(c.queue) - 1; i >= 0; i-- {
+		if c.queue[i].entry == target {
+			last := len(c.queue) - 1
+			copy(c.queue[i:], c.queue[i+1:])
+			c.queue[last] = queued[T]{}
+			c.queue = c.queue[:last]
+			return
+		}
+	}
+}
+
+// forget the entry of the key, unless it was replaced already.
+func (c *Cache[T]) forget(key string, target *entry[T]) {
+	if c.entries[key] == target {
+		delete(c.entries, key)
+	}
+}
+
+type config struct {
+	window  time.Duration
+	maxkeys int
+}
+
+type Option func(cfg *config) error
+
+// WithWindow sets how long the keys are remembered for.
+func WithWindow(window time.Duration) Option {
+	return func(cfg *config) error {
+		if window <= 0 {
+			return errors.New("window must be positive")
+		}
+		cfg.window = window
+		return nil
+	}
+}
+
+// WithMaxKeys sets how many keys are remembered at most.
+func WithMaxKeys(keys int) Option {
+	return func(cfg *config) error {
+		if keys < 1 {
+			return errors.New("max keys must be positive")
+		}
+		cfg.maxkeys = keys
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheDo(t *testing.T) {
+	cache, err := NewCache[string]()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	var calls int32
+	create := func(value string) func() (string, error) {
+		return func() (string, error) {
+			atomic.AddInt32(&calls, 1)
+			return value, nil
+		}
+	}
+
+	got, err := cache.Do(ctx, "key", []byte("request"), create("first"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", got)
+
+	got, err = cache.Do(ctx, "key", []byte("request"), create("second"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", got, "retries should get the result of the first request")
+
+	_, err = cache.Do(ctx, "key", []byte("another request"), create("third"))
+	assert.ErrorIs(t, err, ErrKeyReused)
+
+	got, err = cache.Do(ctx, "other", []byte("request"), create("fourth"))
+	require.NoError(t, err)
+	assert.Equal(t, "fourth", got, "keys should be independent")
+
+	assert.EqualValues(t, 2, calls)
+}
+
+func TestCacheForgetsFailures(t *testing.T) {
+	cache, err := NewCache[string]()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = cache.Do(ctx, "key", nil, func() (string, error) { return "", errors.New("boom") })
+	require.Error(t, err)
+
+	got, err := cache.Do(ctx, "key", nil, func() (string, error) { return "retried", nil })
+	require.NoError(t, err, "failed requests should be retried")
+	assert.Equal(t, "retried", got)
+}
+
+func TestCacheDiscardsFailures(t *testing.T) {
+	cache, err := NewCache[string](WithMaxKeys(2))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = cache.Do(ctx, "kept", nil, func() (string, error) { return "kept", nil })
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		_, err = cache.Do(ctx, fmt.Sprintf("failed-%d", i), nil, func() (string, error) { return "", errors.New("boom") })
+		require.Error(t, err)
+	}
+
+	assert.Equal(t, 1, cache.Len())
+	assert.Len(t, cache.queue, 1, "failed requests shouldn't be kept until their window elapses")
+
+	_, err = cache.Do(ctx, "another", nil, func() (string, error) { return "another", nil })
+	require.NoError(t, err)
+
+	got, err := cache.Do(ctx, "kept", nil, func() (string, error) { return "again", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "kept", got, "failed requests shouldn't make room by evicting other keys")
+}
+
+func TestCacheConcurrentRequests(t *testing.T) {
+	cache, err := NewCache[int]()
+	require.NoError(t, err)
+
+	var calls int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = cache.Do(
+				context.Background(), "key", nil,
+				func() (int, error) {
+					<-release
+					return int(atomic.AddInt32(&calls, 1)), nil
+				},
+			)
+		}(i)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls, "concurrent requests should wait for the first one")
+	for _, result := range results {
+		assert.Equal(t, 1, result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	blocked := make(chan struct{})
+	go func() {
+		_, _ = cache.Do(context.Background(), "slow", nil, func() (int, error) { <-blocked; return 0, nil })
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = cache.Do(ctx, "slow", nil, func() (int, error) { return 0, nil })
+	assert.ErrorIs(t, err, context.Canceled, "waiting should stop when the context is done")
+	close(blocked)
+}
+
+func TestCacheExpiration(t *testing.T) {
+	cache, err := NewCache[string](WithWindow(time.Hour), WithMaxKeys(2))
+	require.NoError(t, err)
+
+	now := time.Date(2022, 6, 11, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	remember := func(key, value string) string {
+		got, err := cache.Do(ctx, key, nil, func() (string, error) { return value, nil })
+		require.NoError(t, err)
+		return got
+	}
+
+	remember("a", "a")
+	now = now.Add(30 * time.Minute)
+	remember("b", "b")
+	assert.Equal(t, 2, cache.Len())
+
+	remember("c", "c")
+	assert.Equal(t, 2, cache.Len(), "the oldest key should have been forgotten")
+	assert.Equal(t, "a2", remember("a", "a2"), "forgotten keys should be run again")
+
+	now = now.Add(2 * time.Hour)
+	assert.Equal(t, 0, cache.Len(), "keys should expire after the window")
+	assert.Equal(t, "b2", remember("b", "b2"))
+}
+
+func TestCacheOptions(t *testing.T) {
+	_, err := NewCache[string](WithWindow(0))
+	assert.Error(t, err)
+
+	_, err = NewCache[string](WithMaxKeys(0))
+	assert.Error(t, err)
+}
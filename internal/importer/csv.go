@@ -0,0 +1,211 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// Fields of the links the csv columns can be mapped to.
+const (
+	FieldSlug      = "slug"
+	FieldTarget    = "target"
+	FieldHits      = "hits"
+	FieldBotHits   = "bot_hits"
+	FieldCreatedAt = "created_at"
+	// FieldDate is the date of the histogram bucket the hits of the row belong to.
+	FieldDate = "date"
+)
+
+// aliases are the usual names of the columns holding each field, on the lnk export
+// and on the exports of other shorteners, normalized.
+var aliases = map[string][]string{
+	FieldSlug:      {"slug", "short_code", "shortcode", "code", "alias", "keyword", "back_half", "short"},
+	FieldTarget:    {"target", "url", "long_url", "longurl", "original_url", "destination", "destination_url"},
+	FieldHits:      {"hits", "clicks", "visits", "total_clicks"},
+	FieldBotHits:   {"bot_hits"},
+	FieldCreatedAt: {"created_at", "created", "date_created", "creation_date", "timestamp"},
+	FieldDate:      {"date"},
+}
+
+// Mapping of the fields of the links to the csv columns holding them; an empty
+// column ignores the field.
+type Mapping map[string]string
+
+// ParseMapping parses a mapping from field=column pairs.
+func ParseMapping(pairs []string) (Mapping, error) {
+	mapping := make(Mapping, len(pairs))
+
+	for _, pair := range pairs {
+		field, column, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid column mapping %q, expected field=column", pair)
+		}
+
+		field = normalize(field)
+		if _, known := aliases[field]; !known {
+			return nil, fmt.Errorf("unknown field %q on column mapping", field)
+		}
+
+		mapping[field] = strings.TrimSpace(column)
+	}
+
+	return mapping, nil
+}
+
+// bom is the byte order mark spreadsheets start utf-8 files with.
+var bom = []byte{0xef, 0xbb, 0xbf}
+
+func readcsv(data []byte, mapping Mapping, now time.Time) ([]Record, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, bom)))
+	reader.FieldsPerRecord = -1 // missing trailing columns are taken as empty
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading csv header: %w", err)
+	}
+
+	columns, err := resolve(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	groups := make(map[string]int) // record of each slug, for files with a date column
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		get := func(field string) string {
+			if i := columns[field]; i >= 0 && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		// with a date column, the rows of the same slug are buckets of the same link
+		slug := get(FieldSlug)
+		pos, found := groups[slug]
+		if !found || columns[FieldDate] < 0 {
+			pos = len(records)
+			records = append(records, Record{Line: line, Target: get(FieldTarget), Link: newlink()})
+
+			if columns[FieldDate] >= 0 {
+				if slug == "" {
+					records[pos].Err = errors.New("rows with a date need a slug")
+					continue
+				}
+				groups[slug] = pos
+			}
+		}
+
+		if rec := &records[pos]; rec.Err == nil {
+			rec.Err = readrow(rec.Link, get)
+		}
+	}
+
+	for i := range records {
+		complete(&records[i], now)
+	}
+
+	return records, nil
+}
+
+// readrow reads the fields of the row into the link.
+func readrow(link *storage.Link, get func(field string) string) error {
+	if link.Slug == "" {
+		link.Slug = get(FieldSlug)
+	}
+	if link.Target == "" {
+		link.Target = get(FieldTarget)
+	}
+
+	hits, err := parsecount(FieldHits, get(FieldHits))
+	if err != nil {
+		return err
+	}
+
+	if date := get(FieldDate); date != "" {
+		if err := addbucket(link, date, hits); err != nil {
+			return err
+		}
+	} else {
+		link.Hits += hits
+	}
+
+	bots, err := parsecount(FieldBotHits, get(FieldBotHits))
+	if err != nil {
+		return err
+	}
+	link.BotHits += bots
+
+	if created := get(FieldCreatedAt); created != "" && link.CreatedAt.IsZero() {
+		link.CreatedAt, err = parsetime(created)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve finds the position of the column of each field on the header, -1 for the
+// fields without column.
+func resolve(header []string, mapping Mapping) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, dupe := positions[normalize(name)]; !dupe {
+			positions[normalize(name)] = i
+		}
+	}
+
+	columns := make(map[string]int, len(aliases))
+	for field, names := range aliases {
+		columns[field] = -1
+
+		if column, mapped := mapping[field]; mapped {
+			if column == "" {
+				continue
+			}
+
+			i, found := positions[normalize(column)]
+			if !found {
+				return nil, fmt.Errorf("column %q mapped to %s not found on the csv header", column, field)
+			}
+			columns[field] = i
+			continue
+		}
+
+		for _, name := range names {
+			if i, found := positions[name]; found {
+				columns[field] = i
+				break
+			}
+		}
+	}
+
+	if columns[FieldTarget] < 0 {
+		return nil, errors.New("no target column found on the csv header, map it via target=column")
+	}
+
+	return columns, nil
+}
+
+// normalize column names, so they match regardless of their case and separators.
+func normalize(name string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
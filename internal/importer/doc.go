@@ -0,0 +1,7 @@
+// Package importer reads links exported from lnk or from other link shorteners, as
+// CSV, newline delimited JSON, or JSON dumps of the link list, so they can be loaded
+// into a store along with their hits over time.
+//
+// Every link is validated on its own; invalid links are reported instead of failing
+// the whole import.
+package importer
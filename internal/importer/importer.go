@@ -0,0 +1,197 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// Format of the imported file.
+type Format string
+
+const (
+	// CSV files have a header row naming the columns, and a link per row; or, if they
+	// have a date column, the hits of a link on a date per row, like the csv export.
+	CSV Format = "csv"
+	// NDJSON files have a json object per line with the fields of a link, like the
+	// ndjson export or the links returned by the api.
+	NDJSON Format = "ndjson"
+	// JSON files are dumps of the link list, as returned by the api.
+	JSON Format = "json"
+)
+
+// ParseFormat parses the import format; if empty, it's detected from the data.
+func ParseFormat(value string, data []byte) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case "":
+		return detect(data), nil
+	case CSV:
+		return CSV, nil
+	case NDJSON, "jsonl":
+		return NDJSON, nil
+	case JSON:
+		return JSON, nil
+	default:
+		return "", fmt.Errorf("unsupported import format %q, expected csv, ndjson or json", value)
+	}
+}
+
+// detect guesses the format of the data; json documents with a list of links are
+// dumps, other json objects are taken as the first line of an ndjson file, and
+// anything else as csv.
+func detect(data []byte) Format {
+	trimmed := bytes.TrimSpace(data)
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		return CSV
+	}
+
+	var dump struct {
+		Links json.RawMessage `json:"links"`
+	}
+	if json.Unmarshal(trimmed, &dump) == nil && dump.Links != nil {
+		return JSON
+	}
+
+	return NDJSON
+}
+
+// Record is a link read from the file, or the reason it couldn't be read.
+type Record struct {
+	// Line of the file the link starts at, or its position on the list of a json dump.
+	Line int
+	// Target of the link, as found on the file, even if the link is invalid.
+	Target string
+	Link   *storage.Link
+	Err    error
+}
+
+// Read the links of the data in the format. CSV files can map the columns to the
+// fields of the links via the mapping, which takes precedence over the default one.
+// It only fails if the file can't be read as a whole; invalid links are returned
+// as records with an error.
+func Read(data []byte, format Format, mapping Mapping) ([]Record, error) {
+	now := time.Now().UTC()
+
+	switch format {
+	case CSV:
+		return readcsv(data, mapping, now)
+	case NDJSON:
+		return readndjson(data, now)
+	case JSON:
+		return readdump(data, now)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+// validate checks that the link can be imported as it is.
+func validate(link *storage.Link) error {
+	target, err := url.ParseRequestURI(link.Target)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("invalid target url %q", link.Target)
+	}
+
+	if link.Slug != "" && !storage.ValidSlug(link.Slug) {
+		return fmt.Errorf("invalid slug %q", link.Slug)
+	}
+
+	return nil
+}
+
+// finish validates the link and makes its histogram account for all its hits.
+// Hits that can't be attributed to a bucket, e.g. because the file only has the
+// total, are attributed to the hour the link was created at.
+func finish(link *storage.Link, now time.Time) error {
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = now
+	}
+
+	var bucketed uint64
+	for _, hits := range link.Histogram {
+		bucketed += hits
+	}
+
+	if link.Hits < bucketed {
+		link.Hits = bucketed
+	}
+	if link.Hits > bucketed {
+		link.Histogram[link.CreatedAt.UTC().Format(storage.HourFormat)] += link.Hits - bucketed
+	}
+
+	return validate(link)
+}
+
+func newlink() *storage.Link {
+	return &storage.Link{Histogram: make(map[string]uint64)}
+}
+
+// bucketlayouts are the date layouts of the histogram buckets of the exports, for
+// hourly, daily and weekly, and monthly buckets.
+var bucketlayouts = []string{"2006-01-02T15:04", "2006-01-02", "2006-01"}
+
+// addbucket adds the hits of the bucket starting at the date to the histogram.
+func addbucket(link *storage.Link, date string, hits uint64) error {
+	for _, layout := range bucketlayouts {
+		start, err := time.ParseInLocation(layout, strings.TrimSpace(date), time.UTC)
+		if err == nil {
+			link.Histogram[start.Format(storage.HourFormat)] += hits
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid histogram date %q", date)
+}
+
+// timelayouts are the layouts creation times are parsed with, besides unix times.
+var timelayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+func parsetime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	for _, layout := range timelayouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
+
+func parsecount(field, value string) (uint64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	count, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", field, value)
+	}
+
+	return count, nil
+}
+
+var errprotected = errors.New("protected links can't be imported, as their passwords are not exported")
+
+// complete finishes the link of the record, unless it already failed; records that
+// fail don't keep their link.
+func complete(rec *Record, now time.Time) {
+	if rec.Err == nil {
+		rec.Err = finish(rec.Link, now)
+	}
+
+	if rec.Err != nil {
+		rec.Link = nil
+	}
+}
@@ -0,0 +1,197 @@
+package importer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		data  string
+
+		want    Format
+		wantErr bool
+	}{
+		"explicit":    {value: "NDJSON", data: "slug,target", want: NDJSON},
+		"csv":         {data: "slug,target\nsearch,http://google.com", want: CSV},
+		"ndjson":      {data: "{\"target\": \"http://google.com\"}\n{\"target\": \"http://go.dev\"}", want: NDJSON},
+		"single line": {data: "{\"target\": \"http://google.com\"}", want: NDJSON},
+		"dump":        {data: "\n{\"links\": [{\"target\": \"http://google.com\"}]}", want: JSON},
+		"unknown":     {value: "xml", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseFormat(test.value, []byte(test.data))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+// link is the expected outcome of a record; an empty error means it should be valid.
+type link struct {
+	line      int
+	slug      string
+	target    string
+	hits      uint64
+	histogram map[string]uint64
+	created   time.Time
+	err       string
+}
+
+func TestRead(t *testing.T) {
+	created := time.Date(2022, 6, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		format  Format
+		data    string
+		mapping []string
+
+		want    []link
+		wantErr string
+	}{
+		"csv with aliases": {
+			format: CSV,
+			data: "\xef\xbb\xbfShort Code,Long URL,Clicks,Created\n" +
+				"search,http://google.com,42,2022-06-01 10:30:00\n" +
+				",http://go.dev\n" +
+				"bad slug,http://go.dev,1\n" +
+				"api,http://go.dev,1\n" +
+				"nan,http://go.dev,many\n" +
+				"relative,/path\n",
+			want: []link{
+				{line: 2, slug: "search", target: "http://google.com", hits: 42, histogram: map[string]uint64{"2022-06-01T10": 42}, created: created},
+				{line: 3, target: "http://go.dev", histogram: map[string]uint64{}},
+				{line: 4, err: "invalid slug"},
+				{line: 5, err: "invalid slug"},
+				{line: 6, err: "invalid hits"},
+				{line: 7, err: "invalid target url"},
+			},
+		},
+		"csv with mapping": {
+			format:  CSV,
+			data:    "url,destination,clicks\nsearch,http://google.com,42\n",
+			mapping: []string{"slug=url", "target=destination", "hits="},
+			want: []link{
+				{line: 2, slug: "search", target: "http://google.com", histogram: map[string]uint64{}},
+			},
+		},
+		"csv export": {
+			format: CSV,
+			data: "slug,target,date,hits,unique_visitors\n" +
+				"search,http://google.com,2022-06-01,40,30\n" +
+				"go,http://go.dev,2022-06,1,1\n" +
+				"search,http://google.com,2022-06-02,2,2\n" +
+				",http://nowhere.com,2022-06-02,2,2\n",
+			want: []link{
+				{line: 2, slug: "search", target: "http://google.com", hits: 42, histogram: map[string]uint64{"2022-06-01T00": 40, "2022-06-02T00": 2}},
+				{line: 3, slug: "go", target: "http://go.dev", hits: 1, histogram: map[string]uint64{"2022-06-01T00": 1}},
+				{line: 5, err: "need a slug"},
+			},
+		},
+		"csv without target": {
+			format:  CSV,
+			data:    "slug,link\nsearch,http://google.com\n",
+			wantErr: "no target column",
+		},
+		"csv with missing mapped column": {
+			format:  CSV,
+			data:    "slug,target\nsearch,http://google.com\n",
+			mapping: []string{"hits=visits"},
+			wantErr: "column \"visits\" mapped to hits not found",
+		},
+		"ndjson": {
+			format: NDJSON,
+			data: `{"slug":"search","target":"http://google.com","hits":42,"bot_hits":1,"histogram":[{"date":"2022-06-01T10:00","hits":40}]}` + "\n" +
+				"\n" +
+				`{"slug":"go","target":"http://go.dev","hits":"3","createdAt":"2022-06-01T10:30:00Z","stats":[{"date":"2022-06-01","hits":"1"}]}` + "\n" +
+				`{"target":"http://go.dev","password":"secret"}` + "\n" +
+				`{"target":` + "\n",
+			want: []link{
+				{line: 1, slug: "search", target: "http://google.com", hits: 42, histogram: nil},
+				{line: 3, slug: "go", target: "http://go.dev", hits: 3, histogram: map[string]uint64{"2022-06-01T00": 1, "2022-06-01T10": 2}, created: created},
+				{line: 4, err: "protected links"},
+				{line: 5, err: "invalid json"},
+			},
+		},
+		"dump": {
+			format: JSON,
+			data: `{"links":[` +
+				`{"slug":"search","target":"http://google.com","hits":"42","createdAt":"2022-06-01T10:30:00Z","stats":[{"date":"2022-06-01","hits":"42"}],` +
+				`"split":[{"target":"http://a.com","weight":1,"hits":"40"},{"target":"http://b.com","weight":1,"hits":"2"}]},` +
+				`{"slug":"secret","target":"http://google.com","protected":true}` +
+				`]}`,
+			want: []link{
+				{line: 1, slug: "search", target: "http://google.com", hits: 42, histogram: map[string]uint64{"2022-06-01T00": 42}},
+				{line: 2, err: "protected links"},
+			},
+		},
+		"malformed dump": {
+			format:  JSON,
+			data:    `{"links":`,
+			wantErr: "invalid json dump",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mapping, err := ParseMapping(test.mapping)
+			require.NoError(t, err)
+
+			records, err := Read([]byte(test.data), test.format, mapping)
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, records, len(test.want))
+
+			for i, want := range test.want {
+				rec := records[i]
+				assert.Equal(t, want.line, rec.Line, "record %d", i)
+
+				if want.err != "" {
+					require.Error(t, rec.Err, "record %d should be invalid", i)
+					assert.Contains(t, rec.Err.Error(), want.err)
+					assert.Nil(t, rec.Link, "invalid records shouldn't have a link")
+					continue
+				}
+
+				require.NoError(t, rec.Err, "record %d should be valid", i)
+				assert.Equal(t, want.slug, rec.Link.Slug)
+				assert.Equal(t, want.target, rec.Link.Target)
+				assert.Equal(t, want.target, rec.Target)
+				assert.Equal(t, want.hits, rec.Link.Hits)
+				if want.histogram != nil {
+					assert.Equal(t, want.histogram, rec.Link.Histogram)
+				}
+				if !want.created.IsZero() {
+					assert.Equal(t, want.created, rec.Link.CreatedAt, "the creation time should be kept")
+				}
+			}
+		})
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	mapping, err := ParseMapping([]string{"Target = Long URL", "hits="})
+	require.NoError(t, err)
+	assert.Equal(t, Mapping{"target": "Long URL", "hits": ""}, mapping)
+
+	_, err = ParseMapping([]string{"target"})
+	assert.Error(t, err, "pairs without column should be rejected")
+
+	_, err = ParseMapping([]string{"visitors=unique"})
+	assert.Error(t, err, "unknown fields should be rejected")
+}
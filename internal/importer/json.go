@@ -0,0 +1,133 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/internal/translation"
+	"github.com/aexvir/lnk/proto"
+)
+
+var unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+// extrafields are the fields of the ndjson lines that are not part of the link
+// details; the histogram of the ndjson export, and the password of creation requests.
+type extrafields struct {
+	Histogram []struct {
+		Date string `json:"date"`
+		Hits uint64 `json:"hits"`
+	} `json:"histogram"`
+	Password *string `json:"password"`
+}
+
+func readndjson(data []byte, now time.Time) ([]Record, error) {
+	var records []Record
+
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		rec := Record{Line: i + 1}
+		rec.Link, rec.Target, rec.Err = readline(line)
+		complete(&rec, now)
+
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+// readline reads the link of a line of an ndjson file, returning its target even if
+// the link is invalid.
+func readline(line []byte) (*storage.Link, string, error) {
+	var details proto.LinkDetails
+	if err := unmarshaler.Unmarshal(line, &details); err != nil {
+		return nil, "", fmt.Errorf("invalid json: %w", err)
+	}
+
+	var extra extrafields
+	if err := json.Unmarshal(line, &extra); err != nil {
+		return nil, details.Target, fmt.Errorf("invalid json: %w", err)
+	}
+
+	if extra.Password != nil {
+		return nil, details.Target, errprotected
+	}
+
+	link, err := fromproto(&details)
+	if err != nil {
+		return nil, details.Target, err
+	}
+
+	for _, bucket := range extra.Histogram {
+		if err := addbucket(link, bucket.Date, bucket.Hits); err != nil {
+			return nil, details.Target, err
+		}
+	}
+
+	return link, details.Target, nil
+}
+
+func readdump(data []byte, now time.Time) ([]Record, error) {
+	var list proto.LinkList
+	if err := unmarshaler.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("invalid json dump: %w", err)
+	}
+
+	records := make([]Record, 0, len(list.Links))
+	for i, details := range list.Links {
+		rec := Record{Line: i + 1, Target: details.Target}
+		rec.Link, rec.Err = fromproto(details)
+		complete(&rec, now)
+
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
+
+// fromproto builds the link from its details as returned by the api, keeping the
+// hits of its histogram, rules and split targets.
+func fromproto(details *proto.LinkDetails) (*storage.Link, error) {
+	if details.Protected {
+		return nil, errprotected
+	}
+
+	link := newlink()
+	link.Slug = strings.TrimSpace(details.Slug)
+	link.Target = strings.TrimSpace(details.Target)
+	link.Hits = details.Hits
+	link.BotHits = details.BotHits
+	link.Interstitial = details.Interstitial
+	link.InterstitialDelay = time.Duration(details.InterstitialDelay) * time.Second
+
+	if details.CreatedAt != nil {
+		link.CreatedAt = details.CreatedAt.AsTime().UTC()
+	}
+
+	link.Rules = translation.ProtoRulesToDb(details.Rules)
+	for i, rule := range details.Rules {
+		link.Rules[i].Hits = rule.Hits
+	}
+
+	link.Split = translation.ProtoSplitToDb(details.Split)
+	for i, arm := range details.Split {
+		link.Split[i].Hits = arm.Hits
+	}
+
+	for _, bucket := range details.Stats {
+		if err := addbucket(link, bucket.Date, bucket.Hits); err != nil {
+			return nil, err
+		}
+	}
+
+	return link, nil
+}
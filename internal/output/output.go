@@ -0,0 +1,176 @@
+// Package output renders the results of the command line interface as aligned
+// tables for humans, or as json or yaml for scripts.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Format of the printed results.
+type Format string
+
+const (
+	// Table prints the results as tables with aligned columns.
+	Table Format = "table"
+	// JSON prints the results as indented json, as returned by the rest api.
+	JSON Format = "json"
+	// YAML prints the results as yaml, with the same fields as JSON.
+	YAML Format = "yaml"
+)
+
+// Formats are the names of the supported formats.
+var Formats = []string{string(Table), string(JSON), string(YAML)}
+
+// ParseFormat parses the output format, defaulting to Table if empty.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(value)) {
+	case "", Table:
+		return Table, nil
+	case JSON:
+		return JSON, nil
+	case YAML, "yml":
+		return YAML, nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q, expected one of %s", value, strings.Join(Formats, ", "))
+	}
+}
+
+// Rows of a table, printed aligned on columns below the headers.
+// Tables without headers are printed as they are, e.g. for key value pairs.
+type Rows struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Printer writes the results on the chosen format.
+type Printer struct {
+	w      io.Writer
+	format Format
+}
+
+func NewPrinter(w io.Writer, format Format) *Printer {
+	return &Printer{w: w, format: format}
+}
+
+// Format returns the format the printer writes.
+func (p *Printer) Format() Format {
+	return p.format
+}
+
+// Print writes the message; when printing tables, tables builds the ones that
+// represent the message, which are separated by a blank line.
+func (p *Printer) Print(msg proto.Message, tables func() []Rows) error {
+	switch p.format {
+	case JSON:
+		encoded, err := protojson.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("error encoding json: %w", err)
+		}
+
+		// protojson randomizes its whitespace on purpose; indenting normalizes it
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, encoded, "", "  "); err != nil {
+			return fmt.Errorf("error encoding json: %w", err)
+		}
+		indented.WriteByte('\n')
+
+		return p.write(indented.Bytes())
+
+	case YAML:
+		encoded, err := toyaml(msg)
+		if err != nil {
+			return err
+		}
+		return p.write(encoded)
+
+	default:
+		for i, table := range tables() {
+			if i > 0 {
+				if err := p.write([]byte("\n")); err != nil {
+					return err
+				}
+			}
+			if err := p.table(table); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (p *Printer) table(table Rows) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+
+	if len(table.Headers) > 0 {
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(table.Headers, "\t")))
+	}
+	for _, row := range table.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Printer) write(data []byte) error {
+	if _, err := p.w.Write(data); err != nil {
+		return fmt.Errorf("error writing output: %w", err)
+	}
+
+	return nil
+}
+
+// toyaml encodes the message as yaml, by converting its json encoding so the field
+// names and value formats are the same ones.
+func toyaml(msg proto.Message) ([]byte, error) {
+	encoded, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding json: %w", err)
+	}
+
+	// json is valid yaml; decoding it into nodes keeps the order of the fields
+	var node yaml.Node
+	if err := yaml.Unmarshal(encoded, &node); err != nil {
+		return nil, fmt.Errorf("error converting to yaml: %w", err)
+	}
+	blockstyle(&node)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("error encoding yaml: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// blockstyle clears the flow style the nodes get from the json syntax, so they're
+// printed as regular yaml.
+func blockstyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle
+
+	// strings that look like other types, e.g. "42", have to keep their quotes
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		var resolved yaml.Node
+		if err := yaml.Unmarshal([]byte(node.Value), &resolved); err != nil || len(resolved.Content) != 1 || resolved.Content[0].Tag != "!!str" {
+			node.Style |= yaml.DoubleQuotedStyle
+		}
+	}
+
+	for _, child := range node.Content {
+		blockstyle(child)
+	}
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/aexvir/lnk/proto"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := map[string]struct {
+		value   string
+		want    Format
+		wantErr bool
+	}{
+		"default": {value: "", want: Table},
+		"json":    {value: "JSON", want: JSON},
+		"yml":     {value: "yml", want: YAML},
+		"unknown": {value: "xml", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseFormat(test.value)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	link := &proto.LinkDetails{
+		Slug:      "search",
+		Target:    "http://google.com",
+		Hits:      42,
+		CreatedAt: &timestamppb.Timestamp{Seconds: 1654948800},
+		Stats:     []*proto.DailyHits{{Date: "2022-06-11", Hits: 42}},
+	}
+
+	tables := func() []Rows {
+		return []Rows{
+			{Rows: [][]string{{"slug", "search"}, {"target", "http://google.com"}}},
+			{Headers: []string{"date", "hits"}, Rows: [][]string{{"2022-06-11", "42"}}},
+		}
+	}
+
+	tests := map[Format]string{
+		Table: "slug    search\n" +
+			"target  http://google.com\n" +
+			"\n" +
+			"DATE        HITS\n" +
+			"2022-06-11  42\n",
+		JSON: "{\n" +
+			"  \"slug\": \"search\",\n" +
+			"  \"target\": \"http://google.com\",\n" +
+			"  \"hits\": \"42\",\n" +
+			"  \"stats\": [\n" +
+			"    {\n" +
+			"      \"date\": \"2022-06-11\",\n" +
+			"      \"hits\": \"42\"\n" +
+			"    }\n" +
+			"  ],\n" +
+			"  \"createdAt\": \"2022-06-11T12:00:00Z\"\n" +
+			"}\n",
+		YAML: "slug: search\n" +
+			"target: http://google.com\n" +
+			"hits: \"42\"\n" +
+			"stats:\n" +
+			"  - date: \"2022-06-11\"\n" +
+			"    hits: \"42\"\n" +
+			"createdAt: \"2022-06-11T12:00:00Z\"\n",
+	}
+
+	for format, want := range tests {
+		t.Run(string(format), func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, NewPrinter(&out, format).Print(link, tables))
+			assert.Equal(t, want, out.String())
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package qrcode renders QR codes as PNG and SVG images.
+//
+// The encoding itself is done fully offline, so no external services are involved.
+package qrcode
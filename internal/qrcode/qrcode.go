@@ -0,0 +1,165 @@
+package qrcode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"rsc.io/qr"
+)
+
+// Options customize how QR codes are rendered.
+type Options struct {
+	// Size of the image side, in pixels for png images and in user units for svg ones.
+	Size int
+	// Level of error correction of the code.
+	Level qr.Level
+	// Margin is the width of the quiet zone around the code, in modules.
+	Margin int
+
+	Foreground color.RGBA
+	Background color.RGBA
+}
+
+// DefaultOptions returns the options used when nothing is customized; a 256px
+// black on white image, with medium error correction and the standard quiet zone.
+func DefaultOptions() Options {
+	return Options{
+		Size:       256,
+		Level:      qr.M,
+		Margin:     4,
+		Foreground: color.RGBA{A: 0xff},
+		Background: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	}
+}
+
+// PNG renders the QR code for the text as a png image.
+// Modules are always drawn with the same integer size, so if the requested size
+// isn't a multiple of the code width, the code is centered with extra background.
+func PNG(w io.Writer, text string, opts Options) error {
+	code, err := encode(text, opts)
+	if err != nil {
+		return err
+	}
+
+	modules := code.Size + 2*opts.Margin
+	scale := opts.Size / modules
+	offset := (opts.Size-modules*scale)/2 + opts.Margin*scale
+
+	img := image.NewPaletted(
+		image.Rect(0, 0, opts.Size, opts.Size),
+		color.Palette{opts.Background, opts.Foreground},
+	)
+
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if !code.Black(x, y) {
+				continue
+			}
+
+			for py := 0; py < scale; py++ {
+				row := img.Pix[(offset+y*scale+py)*img.Stride:]
+				for px := 0; px < scale; px++ {
+					row[offset+x*scale+px] = 1
+				}
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// SVG renders the QR code for the text as a svg image.
+func SVG(w io.Writer, text string, opts Options) error {
+	code, err := encode(text, opts)
+	if err != nil {
+		return err
+	}
+
+	modules := code.Size + 2*opts.Margin
+
+	var path strings.Builder
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if code.Black(x, y) {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x+opts.Margin, y+opts.Margin)
+			}
+		}
+	}
+
+	_, err = fmt.Fprintf(
+		w,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+			`<rect width="100%%" height="100%%" fill="%s"/><path fill="%s" d="%s"/></svg>`,
+		opts.Size, opts.Size, modules, modules,
+		hex(opts.Background), hex(opts.Foreground), path.String(),
+	)
+	return err
+}
+
+// MinSize returns the minimum image size needed to render the text with the options,
+// which is the size where each module is drawn as a single pixel.
+func MinSize(text string, opts Options) (int, error) {
+	code, err := encode(text, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	return code.Size + 2*opts.Margin, nil
+}
+
+func encode(text string, opts Options) (*qr.Code, error) {
+	code, err := qr.Encode(text, opts.Level)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding qr code: %w", err)
+	}
+
+	if minsize := code.Size + 2*opts.Margin; opts.Size < minsize {
+		return nil, fmt.Errorf("size %d is too small, it has to be at least %d", opts.Size, minsize)
+	}
+
+	return code, nil
+}
+
+// ParseLevel parses the error correction level from its letter: L, M, Q or H.
+func ParseLevel(level string) (qr.Level, error) {
+	switch strings.ToUpper(level) {
+	case "L":
+		return qr.L, nil
+	case "M":
+		return qr.M, nil
+	case "Q":
+		return qr.Q, nil
+	case "H":
+		return qr.H, nil
+	default:
+		return 0, fmt.Errorf("unknown error correction level %q", level)
+	}
+}
+
+// ParseColor parses hex colors in the rgb or rrggbb forms, with or without leading #.
+func ParseColor(value string) (color.RGBA, error) {
+	value = strings.TrimPrefix(value, "#")
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+
+	if len(value) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q", value)
+	}
+
+	rgb, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q", value)
+	}
+
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, nil
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,94 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const text = "http://localhost:8000/b8f8ea"
+
+func TestPNG(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Size = 300
+	opts.Foreground = color.RGBA{R: 0xff, A: 0xff}
+
+	var buf bytes.Buffer
+	err := PNG(&buf, text, opts)
+	require.NoError(t, err, "rendering with valid options shouldn't fail")
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err, "the output should be a valid png")
+
+	assert.Equal(t, 300, img.Bounds().Dx(), "the image should have the requested size")
+	assert.Equal(t, 300, img.Bounds().Dy(), "the image should be square")
+
+	r, g, b, _ := img.At(0, 0).RGBA()
+	assert.Equal(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b}, "the quiet zone should use the background color")
+
+	// with a 4 module margin the finder pattern at the top left corner starts right after it
+	minsize, err := MinSize(text, opts)
+	require.NoError(t, err)
+	scale := opts.Size / minsize
+	offset := (opts.Size-minsize*scale)/2 + opts.Margin*scale
+
+	r, g, b, _ = img.At(offset, offset).RGBA()
+	assert.Equal(t, [3]uint32{0xffff, 0, 0}, [3]uint32{r, g, b}, "the finder pattern should use the foreground color")
+}
+
+func TestSVG(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Background, _ = ParseColor("#ffe")
+
+	var buf bytes.Buffer
+	err := SVG(&buf, text, opts)
+	require.NoError(t, err, "rendering with valid options shouldn't fail")
+
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg"), "the output should be a svg document")
+	assert.Contains(t, svg, `width="256"`, "the default size should be used")
+	assert.Contains(t, svg, `fill="#ffffee"`, "the background color should be used")
+	assert.Contains(t, svg, `fill="#000000"`, "the foreground color should be used")
+}
+
+func TestSizeTooSmall(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Size = 10
+
+	err := PNG(&bytes.Buffer{}, text, opts)
+	require.Error(t, err, "a code can't fit on 10 pixels")
+	assert.Contains(t, err.Error(), "too small")
+}
+
+func TestParseColor(t *testing.T) {
+	tests := map[string]struct {
+		value string
+
+		want    color.RGBA
+		wantErr bool
+	}{
+		"long form":      {value: "#1a2b3c", want: color.RGBA{R: 0x1a, G: 0x2b, B: 0x3c, A: 0xff}},
+		"without hash":   {value: "1a2b3c", want: color.RGBA{R: 0x1a, G: 0x2b, B: 0x3c, A: 0xff}},
+		"short form":     {value: "#abc", want: color.RGBA{R: 0xaa, G: 0xbb, B: 0xcc, A: 0xff}},
+		"invalid digits": {value: "#zzzzzz", wantErr: true},
+		"invalid length": {value: "#abcd", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseColor(test.value)
+			if test.wantErr {
+				require.Error(t, err, "the color should be rejected")
+				return
+			}
+
+			require.NoError(t, err, "the color should be valid")
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+// Package recorder provides an asynchronous pipeline for registering link hits.
+//
+// Redirects hand their hits over to the recorder without waiting for the store,
+// and a pool of workers registers them on the store in batches.
+package recorder
@@ -0,0 +1,250 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aexvir/lnk/internal/logging"
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// HitStore is the store where the recorded hits are flushed to.
+type HitStore interface {
+	BatchRegisterHits(hits []storage.Hit)
+}
+
+// Recorder buffers hits on a bounded queue and registers them on the store in batches.
+// A batch is flushed when it's full or when the flush interval elapses, whichever
+// happens first.
+// When the queue is full, recording a hit waits for a bit for the workers to catch up
+// and if they don't, the hit is dropped so redirects never stall.
+type Recorder struct {
+	store HitStore
+	queue chan storage.Hit
+
+	queuesize int
+	batchsize int
+	interval  time.Duration
+	workers   int
+	maxwait   time.Duration
+
+	recorded uint64
+	dropped  uint64
+	batches  uint64
+
+	closed bool
+	mutex  sync.RWMutex
+	wg     sync.WaitGroup
+	log    *logging.Logger
+}
+
+// Stats of the hits that went through the recorder.
+type Stats struct {
+	// Recorded is the amount of hits flushed to the store.
+	Recorded uint64
+	// Dropped is the amount of hits discarded because the queue was full.
+	Dropped uint64
+	// Batches is the amount of batches flushed to the store.
+	Batches uint64
+	// Pending is the amount of hits waiting on the queue.
+	Pending int
+}
+
+// NewRecorder instantiates a recorder for the store and starts its workers.
+// By default it uses 2 workers that flush batches of up to 100 hits every second,
+// from a queue that holds 10000 hits. This can be customized via Options.
+// The recorder has to be closed to make sure all the hits are flushed.
+func NewRecorder(store HitStore, opts ...Option) (*Recorder, error) {
+	rec := Recorder{
+		store:     store,
+		queuesize: 10000,
+		batchsize: 100,
+		interval:  time.Second,
+		workers:   2,
+		maxwait:   5 * time.Millisecond,
+		log:       logging.NewLogger("lnk.recorder"),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&rec); err != nil {
+			return nil, err
+		}
+	}
+
+	rec.queue = make(chan storage.Hit, rec.queuesize)
+
+	rec.wg.Add(rec.workers)
+	for i := 0; i < rec.workers; i++ {
+		go rec.work()
+	}
+
+	return &rec, nil
+}
+
+// Record queues the hit to be registered on the store.
+// If the queue is full, it waits up to the max wait time for space to free up,
+// and drops the hit otherwise. Hits recorded after closing the recorder are dropped.
+func (r *Recorder) Record(hit storage.Hit) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.closed {
+		atomic.AddUint64(&r.dropped, 1)
+		return
+	}
+
+	select {
+	case r.queue <- hit:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(r.maxwait)
+	defer timer.Stop()
+
+	select {
+	case r.queue <- hit:
+	case <-timer.C:
+		atomic.AddUint64(&r.dropped, 1)
+	}
+}
+
+// Stats returns the counters of the recorder.
+func (r *Recorder) Stats() Stats {
+	return Stats{
+		Recorded: atomic.LoadUint64(&r.recorded),
+		Dropped:  atomic.LoadUint64(&r.dropped),
+		Batches:  atomic.LoadUint64(&r.batches),
+		Pending:  len(r.queue),
+	}
+}
+
+// Close stops accepting hits and waits until all the queued ones are flushed to the
+// store, or the context is done.
+func (r *Recorder) Close(ctx context.Context) error {
+	r.mutex.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		stats := r.Stats()
+		r.log.Write("close", "recorded: %d, dropped: %d, batches: %d", stats.Recorded, stats.Dropped, stats.Batches)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work accumulates hits from the queue and flushes them in batches until the
+// queue is closed, flushing whatever is left before returning.
+func (r *Recorder) work() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	batch := make([]storage.Hit, 0, r.batchsize)
+
+	for {
+		select {
+		case hit, ok := <-r.queue:
+			if !ok {
+				r.flush(batch)
+				return
+			}
+
+			batch = append(batch, hit)
+			if len(batch) >= r.batchsize {
+				r.flush(batch)
+				batch = make([]storage.Hit, 0, r.batchsize)
+			}
+
+		case <-ticker.C:
+			if len(batch) > 0 {
+				r.flush(batch)
+				batch = make([]storage.Hit, 0, r.batchsize)
+			}
+		}
+	}
+}
+
+func (r *Recorder) flush(batch []storage.Hit) {
+	if len(batch) == 0 {
+		return
+	}
+
+	r.store.BatchRegisterHits(batch)
+	atomic.AddUint64(&r.recorded, uint64(len(batch)))
+	atomic.AddUint64(&r.batches, 1)
+}
+
+type Option func(rec *Recorder) error
+
+// WithQueueSize sets how many hits can be waiting to be flushed.
+func WithQueueSize(size int) Option {
+	return func(rec *Recorder) error {
+		if size < 1 {
+			return errors.New("queue size must be positive")
+		}
+		rec.queuesize = size
+		return nil
+	}
+}
+
+// WithBatchSize sets the maximum amount of hits flushed together.
+func WithBatchSize(size int) Option {
+	return func(rec *Recorder) error {
+		if size < 1 {
+			return errors.New("batch size must be positive")
+		}
+		rec.batchsize = size
+		return nil
+	}
+}
+
+// WithFlushInterval sets how often incomplete batches are flushed.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(rec *Recorder) error {
+		if interval <= 0 {
+			return errors.New("flush interval must be positive")
+		}
+		rec.interval = interval
+		return nil
+	}
+}
+
+// WithWorkers sets how many workers flush batches concurrently.
+func WithWorkers(workers int) Option {
+	return func(rec *Recorder) error {
+		if workers < 1 {
+			return errors.New("there must be at least one worker")
+		}
+		rec.workers = workers
+		return nil
+	}
+}
+
+// WithMaxWait sets how long recording a hit waits for space on a full queue before
+// dropping it. Zero means hits are dropped right away.
+func WithMaxWait(wait time.Duration) Option {
+	return func(rec *Recorder) error {
+		if wait < 0 {
+			return errors.New("max wait can't be negative")
+		}
+		rec.maxwait = wait
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package recorder
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// fakestore keeps the batches it receives, optionally blocking until released.
+type fakestore struct {
+	batches [][]storage.Hit
+	mutex   sync.Mutex
+
+	block chan struct{}
+}
+
+func (fs *fakestore) BatchRegisterHits(hits []storage.Hit) {
+	if fs.block != nil {
+		<-fs.block
+	}
+
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	fs.batches = append(fs.batches, hits)
+}
+
+func (fs *fakestore) hits() int {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	total := 0
+	for _, batch := range fs.batches {
+		total += len(batch)
+	}
+	return total
+}
+
+func TestRecorderFlushesFullBatches(t *testing.T) {
+	store := fakestore{}
+	rec, err := NewRecorder(&store, WithWorkers(1), WithBatchSize(10), WithFlushInterval(time.Hour))
+	require.NoError(t, err, "valid options shouldn't fail")
+
+	for i := 0; i < 25; i++ {
+		rec.Record(storage.Hit{Slug: "test"})
+	}
+
+	require.Eventually(t, func() bool { return store.hits() == 20 }, time.Second, time.Millisecond, "two full batches should be flushed")
+
+	err = rec.Close(context.Background())
+	require.NoError(t, err, "closing should drain the queue")
+
+	assert.Equal(t, 25, store.hits(), "the incomplete batch should be flushed on close")
+	assert.Equal(t, Stats{Recorded: 25, Batches: 3}, rec.Stats())
+}
+
+func TestRecorderFlushesOnInterval(t *testing.T) {
+	store := fakestore{}
+	rec, err := NewRecorder(&store, WithBatchSize(100), WithFlushInterval(10*time.Millisecond))
+	require.NoError(t, err, "valid options shouldn't fail")
+	defer rec.Close(context.Background()) //nolint:errcheck
+
+	rec.Record(storage.Hit{Slug: "test"})
+
+	require.Eventually(t, func() bool { return store.hits() == 1 }, time.Second, time.Millisecond, "the hit should be flushed after the interval")
+}
+
+func TestRecorderDropsWhenFull(t *testing.T) {
+	store := fakestore{block: make(chan struct{})}
+	rec, err := NewRecorder(&store, WithWorkers(1), WithBatchSize(1), WithQueueSize(2), WithMaxWait(0))
+	require.NoError(t, err, "valid options shouldn't fail")
+
+	// the first hit is taken by the worker, which blocks on the store,
+	// the next two fill the queue and the rest are dropped
+	rec.Record(storage.Hit{Slug: "test"})
+	require.Eventually(t, func() bool { return rec.Stats().Pending == 0 }, time.Second, time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		rec.Record(storage.Hit{Slug: "test"})
+	}
+
+	assert.EqualValues(t, 3, rec.Stats().Dropped, "hits that don't fit on the queue should be dropped")
+	assert.Equal(t, 2, rec.Stats().Pending, "the queue should be full")
+
+	close(store.block)
+	err = rec.Close(context.Background())
+	require.NoError(t, err, "closing should drain the queue")
+
+	assert.Equal(t, 3, store.hits(), "all the hits that fit should be flushed")
+
+	rec.Record(storage.Hit{Slug: "test"})
+	assert.EqualValues(t, 4, rec.Stats().Dropped, "hits recorded after closing should be dropped")
+}
+
+func TestRecorderInvalidOptions(t *testing.T) {
+	_, err := NewRecorder(&fakestore{}, WithWorkers(0))
+	require.Error(t, err, "a recorder without workers would never flush")
+}
@@ -0,0 +1,131 @@
+package storage
+
+import "fmt"
+
+// NewLink is a link to be created as part of a batch, with the same parameters as
+// CreateLink.
+type NewLink struct {
+	Target string
+	Slug   *string
+	Opts   []LinkOption
+}
+
+// BatchResult is the outcome of an item of a batch; the slug of the link it
+// refers to, or the reason it failed.
+type BatchResult struct {
+	Slug string
+	Err  error
+}
+
+// BatchCreateLinks creates all the links at once, taking the lock only once for
+// the whole batch, and returns the result of each link at the same position.
+// Like with CreateLink, links with an existing slug replace the existing link, but
+// a slug can't appear more than once on a batch.
+// If atomic, either every link is created or none is; when any link fails, the rest
+// fail with ErrBatchAborted.
+func (m *Memory) BatchCreateLinks(links []NewLink, atomic bool) []BatchResult {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	results := make([]BatchResult, len(links))
+	reserved := make(map[string]bool, len(links))
+	failed := false
+
+	// custom slugs are resolved first, so generated slugs don't take them
+	for i, link := range links {
+		if link.Slug == nil || *link.Slug == "" {
+			continue
+		}
+
+		slug := *link.Slug
+		if reserved[slug] {
+			results[i].Err = fmt.Errorf("slug %s appears more than once on the batch", slug)
+			failed = true
+			continue
+		}
+
+		reserved[slug] = true
+		results[i].Slug = slug
+	}
+
+	for i, link := range links {
+		if link.Slug != nil && *link.Slug != "" {
+			continue
+		}
+
+		slug, err := m.genslug(reserved)
+		if err != nil {
+			results[i].Err = err
+			failed = true
+			continue
+		}
+
+		reserved[slug] = true
+		results[i].Slug = slug
+	}
+
+	if atomic && failed {
+		return abort(results)
+	}
+
+	for i, link := range links {
+		if results[i].Err == nil {
+			m.insert(link.Target, results[i].Slug, link.Opts)
+		}
+	}
+
+	return results
+}
+
+// BatchDeleteLinks removes all the links at once, taking the lock only once for the
+// whole batch, and returns the result of each slug at the same position.
+// Unlike DeleteLink, deleting a link that doesn't exist fails with ErrNotFound.
+// If atomic, either every link is deleted or none is; when any link fails, the rest
+// fail with ErrBatchAborted.
+func (m *Memory) BatchDeleteLinks(slugs []string, atomic bool) []BatchResult {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	results := make([]BatchResult, len(slugs))
+	seen := make(map[string]bool, len(slugs))
+	failed := false
+
+	for i, slug := range slugs {
+		results[i].Slug = slug
+
+		switch _, found := m.links[slug]; {
+		case seen[slug]:
+			results[i].Err = fmt.Errorf("slug %s appears more than once on the batch", slug)
+		case !found:
+			results[i].Err = notfound("no link with slug %s found", slug)
+		}
+
+		if results[i].Err != nil {
+			failed = true
+		}
+		seen[slug] = true
+	}
+
+	if atomic && failed {
+		return abort(results)
+	}
+
+	for _, result := range results {
+		if result.Err == nil {
+			m.remove(m.links[result.Slug])
+		}
+	}
+
+	return results
+}
+
+// abort fails the items of a batch that didn't fail on their own.
+func abort(results []BatchResult) []BatchResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = ErrBatchAborted
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errfailed stands for any error other than ErrBatchAborted on the expectations.
+var errfailed = errors.New("failed")
+
+func TestMemoryBatchCreateLinks(t *testing.T) {
+	slug := func(s string) *string { return &s }
+
+	tests := map[string]struct {
+		links  []NewLink
+		atomic bool
+
+		wantErrs  []error // nil for the links that should be created
+		wantLinks int
+	}{
+		"all created": {
+			links:     []NewLink{{Target: "https://google.com"}, {Target: "https://go.dev", Slug: slug("go")}},
+			wantErrs:  []error{nil, nil},
+			wantLinks: 3,
+		},
+		"generated slugs avoid custom ones": {
+			links:     []NewLink{{Target: "https://google.com"}, {Target: "https://go.dev", Slug: slug("test")}},
+			wantErrs:  []error{errfailed, nil},
+			wantLinks: 2,
+		},
+		"partial failure": {
+			links:     []NewLink{{Target: "https://go.dev", Slug: slug("go")}, {Target: "https://golang.org", Slug: slug("go")}},
+			wantErrs:  []error{nil, errfailed},
+			wantLinks: 2,
+		},
+		"atomic failure": {
+			links:     []NewLink{{Target: "https://go.dev", Slug: slug("go")}, {Target: "https://golang.org", Slug: slug("go")}},
+			atomic:    true,
+			wantErrs:  []error{ErrBatchAborted, errfailed},
+			wantLinks: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store, err := NewMemoryStorage(WithSlugGenerator(&staticslugger{}))
+			require.NoError(t, err, "shouldn't fail initing the store")
+
+			_, err = store.CreateLink("https://example.com", slug("existing"))
+			require.NoError(t, err)
+
+			results := store.BatchCreateLinks(test.links, test.atomic)
+			require.Len(t, results, len(test.links), "there should be a result per link")
+
+			for i, result := range results {
+				assertbatcherr(t, test.wantErrs[i], result.Err, i)
+				if result.Err == nil {
+					link, err := store.GetLink(result.Slug)
+					require.NoError(t, err, "the created link should be on the db")
+					assert.Equal(t, test.links[i].Target, link.Target)
+				}
+			}
+
+			assert.Len(t, store.AllLinks(), test.wantLinks)
+		})
+	}
+}
+
+func TestMemoryBatchDeleteLinks(t *testing.T) {
+	tests := map[string]struct {
+		slugs  []string
+		atomic bool
+
+		wantErrs  []error
+		wantLinks int
+	}{
+		"all deleted": {
+			slugs:     []string{"aaa", "bbb"},
+			wantErrs:  []error{nil, nil},
+			wantLinks: 1,
+		},
+		"missing link": {
+			slugs:     []string{"aaa", "missing", "aaa"},
+			wantErrs:  []error{nil, ErrNotFound, errfailed},
+			wantLinks: 2,
+		},
+		"atomic failure": {
+			slugs:     []string{"aaa", "missing"},
+			atomic:    true,
+			wantErrs:  []error{ErrBatchAborted, ErrNotFound},
+			wantLinks: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store, err := NewMemoryStorage()
+			require.NoError(t, err, "shouldn't fail initing the store")
+
+			for _, slug := range []string{"aaa", "bbb", "ccc"} {
+				slug := slug
+				_, err := store.CreateLink("https://google.com", &slug)
+				require.NoError(t, err)
+			}
+			store.RegisterHit(Hit{Slug: "aaa"})
+
+			results := store.BatchDeleteLinks(test.slugs, test.atomic)
+			require.Len(t, results, len(test.slugs), "there should be a result per slug")
+
+			for i, result := range results {
+				assert.Equal(t, test.slugs[i], result.Slug)
+				assertbatcherr(t, test.wantErrs[i], result.Err, i)
+			}
+
+			assert.Len(t, store.AllLinks(), test.wantLinks)
+
+			var hits uint64
+			for _, link := range store.AllLinks() {
+				hits += link.Hits
+			}
+			assert.Equal(t, hits, store.Overview(DefaultStatsQuery(), 10).Hits, "the totals should match the remaining links")
+		})
+	}
+}
+
+func assertbatcherr(t *testing.T, want, got error, item int) {
+	t.Helper()
+
+	switch want {
+	case nil:
+		assert.NoError(t, got, "item %d should succeed", item)
+	case errfailed:
+		assert.Error(t, got, "item %d should fail", item)
+		assert.NotErrorIs(t, got, ErrBatchAborted, "item %d should fail on its own", item)
+	default:
+		assert.ErrorIs(t, got, want, "item %d should fail with %s", item, want)
+	}
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrChangesExpired is returned when asking for changes that were already discarded
+// from the changelog, or for changes after a sequence the changelog hasn't reached,
+// as happens when it starts over after a restart; either way, consumers have to
+// reload all links before resuming.
+var ErrChangesExpired = errors.New("changes no longer available")
+
+// ChangeKind is the kind of change done to a link.
+type ChangeKind string
+
+const (
+	ChangeCreated ChangeKind = "created"
+	ChangeUpdated ChangeKind = "updated"
+	ChangeDeleted ChangeKind = "deleted"
+	// ChangeMilestone is recorded when the hits of a link reach a power of ten,
+	// starting from 10.
+	ChangeMilestone ChangeKind = "milestone"
+	// ChangeRestored is recorded once when the whole store is restored from a snapshot,
+	// instead of a change per link; it has no slug, and every link has to be reloaded.
+	ChangeRestored ChangeKind = "restored"
+)
+
+// Change done to a link, as recorded on the changelog.
+// Hits registered on links are not considered changes, unless the link reaches
+// a milestone with them.
+type Change struct {
+	// Sequence increases with every change, starting from 1.
+	Sequence uint64
+	Kind     ChangeKind
+	Slug     string
+	Time     time.Time
+	// Link is the state of the link after the change, without its histogram,
+	// breakdown and visitor sketches; nil when it was deleted.
+	Link *Link
+}
+
+// changelog keeps the latest changes, discarding the oldest ones once full.
+type changelog struct {
+	size    int
+	last    uint64
+	changes []Change
+	// notify is closed and replaced whenever a change is recorded, to wake up
+	// whoever is waiting for new changes.
+	notify chan struct{}
+}
+
+func newchangelog(size int) *changelog {
+	return &changelog{size: size, notify: make(chan struct{})}
+}
+
+func (cl *changelog) record(kind ChangeKind, slug string, link *Link) {
+	cl.last++
+
+	change := Change{Sequence: cl.last, Kind: kind, Slug: slug, Time: time.Now().UTC()}
+	if link != nil {
+		change.Link = link.slim()
+	}
+
+	// the discarded changes are left behind when append reallocates the slice
+	if len(cl.changes) >= cl.size {
+		cl.changes[0] = Change{}
+		cl.changes = cl.changes[1:]
+	}
+	cl.changes = append(cl.changes, change)
+
+	close(cl.notify)
+	cl.notify = make(chan struct{})
+}
+
+// resume continues the sequence from the specified one if it's ahead, e.g. when
+// restoring a snapshot. The recorded changes are discarded, as the sequence skips
+// the numbers in between.
+func (cl *changelog) resume(sequence uint64) {
+	if sequence <= cl.last {
+		return
+	}
+
+	cl.last = sequence
+	cl.changes = nil
+}
+
+func (cl *changelog) since(sequence uint64) ([]Change, error) {
+	if sequence > cl.last {
+		return nil, fmt.Errorf("%w: asked for changes after %d, latest is %d", ErrChangesExpired, sequence, cl.last)
+	}
+	if sequence == cl.last {
+		return nil, nil
+	}
+
+	oldest := cl.last - uint64(len(cl.changes)) + 1
+	if sequence+1 < oldest {
+		return nil, fmt.Errorf("%w: asked for changes after %d, oldest available is %d", ErrChangesExpired, sequence, oldest)
+	}
+
+	start := int(sequence + 1 - oldest)
+	return append([]Change(nil), cl.changes[start:]...), nil
+}
+
+// milestone checks whether the amount of hits is a power of ten, from 10 on.
+func milestone(hits uint64) bool {
+	if hits < 10 {
+		return false
+	}
+
+	for hits%10 == 0 {
+		hits /= 10
+	}
+
+	return hits == 1
+}
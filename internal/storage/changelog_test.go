@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryChangelog(t *testing.T) {
+	store, err := NewMemoryStorage(WithChangelogSize(3))
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	assert.EqualValues(t, 0, store.LastSequence(), "there shouldn't be changes yet")
+
+	changes, notify, err := store.ChangesSince(0)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	slug := "aaa"
+	_, err = store.CreateLink("https://google.com", &slug)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	select {
+	case <-notify:
+	default:
+		t.Fatal("the notify channel should be closed after a change")
+	}
+
+	_, err = store.CreateLink("https://duckduckgo.com", &slug)
+	require.NoError(t, err, "replacing a link shouldn't error on this test")
+	store.RegisterHit(Hit{Slug: slug})
+	require.NoError(t, store.DeleteLink(slug))
+	require.NoError(t, store.DeleteLink(slug), "deleting a missing link is a noop")
+
+	assert.EqualValues(t, 3, store.LastSequence(), "hits and noop deletes shouldn't be changes")
+
+	changes, _, err = store.ChangesSince(0)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	assert.Equal(t, ChangeCreated, changes[0].Kind)
+	assert.Equal(t, "https://google.com", changes[0].Link.Target)
+	assert.Equal(t, ChangeUpdated, changes[1].Kind)
+	assert.Equal(t, "https://duckduckgo.com", changes[1].Link.Target)
+	assert.Equal(t, ChangeDeleted, changes[2].Kind)
+	assert.Equal(t, slug, changes[2].Slug)
+	assert.Nil(t, changes[2].Link, "deleted links have no state")
+
+	for idx, change := range changes {
+		assert.EqualValues(t, idx+1, change.Sequence, "sequence numbers should be consecutive")
+	}
+
+	changes, _, err = store.ChangesSince(2)
+	require.NoError(t, err)
+	require.Len(t, changes, 1, "only the changes after the sequence should be returned")
+	assert.EqualValues(t, 3, changes[0].Sequence)
+
+	// overflow the changelog, discarding the first change
+	other := "bbb"
+	_, err = store.CreateLink("https://google.com", &other)
+	require.NoError(t, err)
+
+	_, _, err = store.ChangesSince(0)
+	assert.ErrorIs(t, err, ErrChangesExpired, "the first change should have been discarded")
+
+	changes, _, err = store.ChangesSince(1)
+	require.NoError(t, err, "resuming right after a discarded change loses nothing")
+	assert.Len(t, changes, 3)
+	assert.Nil(t, changes[2].Link.Histogram, "the changes shouldn't keep the stats of the links")
+
+	_, _, err = store.ChangesSince(5)
+	assert.ErrorIs(t, err, ErrChangesExpired, "changes after the latest one come from a changelog that started over")
+}
+
+func TestMemoryMilestones(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	slug, err := store.CreateLink("https://google.com", nil)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	for i := 0; i < 100; i++ {
+		store.RegisterHit(Hit{Slug: slug})
+		store.RegisterHit(Hit{Slug: slug, Bot: true})
+	}
+
+	changes, _, err := store.ChangesSince(1)
+	require.NoError(t, err)
+	require.Len(t, changes, 2, "the 10th and 100th hits should be milestones, bots don't count")
+
+	assert.Equal(t, ChangeMilestone, changes[0].Kind)
+	assert.EqualValues(t, 10, changes[0].Link.Hits)
+	assert.Equal(t, ChangeMilestone, changes[1].Kind)
+	assert.EqualValues(t, 100, changes[1].Link.Hits)
+}
+
+func TestMilestone(t *testing.T) {
+	for hits, want := range map[uint64]bool{0: false, 1: false, 9: false, 10: true, 11: false, 20: false, 100: true, 1000: true, 1010: false} {
+		assert.Equal(t, want, milestone(hits), "unexpected result for %d hits", hits)
+	}
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is matched by the errors returned when the requested link, webhook or
+// delivery doesn't exist, so callers can tell them apart via errors.Is.
+var ErrNotFound = errors.New("not found")
+
+// ErrBatchAborted is the error of the items of an all-or-nothing batch that were not
+// applied because other items of the batch failed.
+var ErrBatchAborted = errors.New("not applied, as other items of the batch failed")
+
+type notfounderror struct {
+	message string
+}
+
+func (e notfounderror) Error() string {
+	return e.message
+}
+
+func (e notfounderror) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// notfound builds an error matching ErrNotFound with the formatted message.
+func notfound(format string, args ...any) error {
+	return notfounderror{message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,306 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aexvir/lnk/internal/hll"
+)
+
+// HourFormat is the layout of the histogram keys.
+// Hits are bucketed by the UTC hour they happened in, so they can be aggregated
+// to coarser granularities on any time zone when they're queried.
+const HourFormat = "2006-01-02T15"
+
+// DayFormat is the layout of the keys of the daily unique visitor sketches.
+const DayFormat = "2006-01-02"
+
+type Granularity string
+
+const (
+	GranularityHour  Granularity = "hour"
+	GranularityDay   Granularity = "day"
+	GranularityWeek  Granularity = "week"
+	GranularityMonth Granularity = "month"
+)
+
+// ParseGranularity parses a granularity from its name; empty defaults to day.
+func ParseGranularity(name string) (Granularity, error) {
+	switch g := Granularity(name); g {
+	case "":
+		return GranularityDay, nil
+	case GranularityHour, GranularityDay, GranularityWeek, GranularityMonth:
+		return g, nil
+	default:
+		return "", fmt.Errorf("unknown granularity %q", name)
+	}
+}
+
+// Format the start of a bucket of this granularity.
+// Hours are formatted as `2006-01-02T15:00`, days and weeks as the date they
+// start on, and months as `2006-01`.
+func (g Granularity) Format(start time.Time) string {
+	switch g {
+	case GranularityHour:
+		return start.Format("2006-01-02T15:04")
+	case GranularityMonth:
+		return start.Format("2006-01")
+	default:
+		return start.Format("2006-01-02")
+	}
+}
+
+// StatsQuery selects and groups the hits of a histogram.
+type StatsQuery struct {
+	// From is the inclusive start of the time range; zero means unbounded.
+	From time.Time
+	// To is the exclusive end of the time range; zero means unbounded.
+	To time.Time
+	// Granularity of the buckets the hits are grouped in.
+	Granularity Granularity
+	// Location is the time zone the buckets are aligned to; nil means UTC.
+	Location *time.Location
+	// FillGaps makes Series include the buckets without hits, with zero hits.
+	FillGaps bool
+}
+
+// Bucket of hits of an aggregated histogram.
+type Bucket struct {
+	Start time.Time
+	Hits  uint64
+}
+
+// DefaultStatsQuery returns daily buckets in UTC, over all time.
+func DefaultStatsQuery() StatsQuery {
+	return StatsQuery{Granularity: GranularityDay, Location: time.UTC}
+}
+
+// Aggregate groups the hourly buckets of the histogram that fall into the query
+// time range into buckets of the query granularity, on the query time zone.
+// The result is indexed by the start of each bucket.
+// For time zones with offsets that are not whole hours, each utc hour is counted
+// on the local bucket its start falls into.
+func (q StatsQuery) Aggregate(histogram map[string]uint64) map[time.Time]uint64 {
+	result := make(map[time.Time]uint64)
+
+	for key, hits := range histogram {
+		hour, err := time.ParseInLocation(HourFormat, key, time.UTC)
+		if err != nil {
+			continue // ignore malformed keys instead of failing the whole query
+		}
+
+		if !q.contains(hour) {
+			continue
+		}
+
+		result[q.Bucket(hour)] += hits
+	}
+
+	return result
+}
+
+// Total sums the hits of the histogram that fall into the query time range.
+func (q StatsQuery) Total(histogram map[string]uint64) uint64 {
+	var total uint64
+
+	for key, hits := range histogram {
+		if q.From.IsZero() && q.To.IsZero() {
+			total += hits
+			continue
+		}
+
+		hour, err := time.ParseInLocation(HourFormat, key, time.UTC)
+		if err != nil || !q.contains(hour) {
+			continue
+		}
+
+		total += hits
+	}
+
+	return total
+}
+
+// UniqueVisitors estimates the unique visitors of each bucket by merging the daily
+// sketches of the days that overlap the query time range.
+// Visitors are only tracked daily, so nothing is returned for hourly queries. Days
+// are assigned to buckets by their utc noon, which matches the local calendar day
+// on most time zones.
+func (q StatsQuery) UniqueVisitors(daily map[string]*hll.Sketch) map[time.Time]uint64 {
+	if q.Granularity == GranularityHour {
+		return nil
+	}
+
+	merged := make(map[time.Time]*hll.Sketch)
+	for key, sketch := range daily {
+		day, err := time.ParseInLocation(DayFormat, key, time.UTC)
+		if err != nil {
+			continue
+		}
+
+		if !q.From.IsZero() && !day.Add(24*time.Hour).After(q.From) {
+			continue
+		}
+		if !q.To.IsZero() && !day.Before(q.To) {
+			continue
+		}
+
+		bucket := q.Bucket(day.Add(12 * time.Hour))
+		if merged[bucket] == nil {
+			merged[bucket] = sketch.Clone()
+			continue
+		}
+		_ = merged[bucket].Merge(sketch)
+	}
+
+	result := make(map[time.Time]uint64, len(merged))
+	for bucket, sketch := range merged {
+		result[bucket] = sketch.Estimate()
+	}
+
+	return result
+}
+
+// Series aggregates the histogram like Aggregate does, returning the buckets in
+// chronological order.
+// If the query fills gaps, buckets without hits are included with zero hits; from
+// the bucket of the query start, or the first bucket with hits if it's unbounded,
+// until the bucket of the query end, or the current time if it's unbounded.
+// Gaps are only filled if that takes at most MaxSeriesBuckets buckets, as the first
+// hits can be arbitrarily old, e.g. on imported links; otherwise only the buckets
+// with hits are returned.
+func (q StatsQuery) Series(histogram map[string]uint64) []Bucket {
+	buckets := q.Aggregate(histogram)
+
+	if !q.FillGaps {
+		return sortbuckets(buckets)
+	}
+
+	first := q.Bucket(q.From)
+	if q.From.IsZero() {
+		if len(buckets) == 0 {
+			return []Bucket{}
+		}
+
+		for start := range buckets {
+			if first.IsZero() || start.Before(first) {
+				first = start
+			}
+		}
+	}
+
+	end := q.end()
+	if q.countbuckets(first, end) > MaxSeriesBuckets {
+		return sortbuckets(buckets)
+	}
+
+	var series []Bucket
+	for start := first; start.Before(end); start = q.next(start) {
+		series = append(series, Bucket{Start: start, Hits: buckets[start]})
+	}
+
+	return series
+}
+
+func sortbuckets(buckets map[time.Time]uint64) []Bucket {
+	series := make([]Bucket, 0, len(buckets))
+	for start, hits := range buckets {
+		series = append(series, Bucket{Start: start, Hits: hits})
+	}
+
+	sort.Slice(series, func(i, j int) bool { return series[i].Start.Before(series[j].Start) })
+	return series
+}
+
+// end returns the end of the query time range, the current time if it's unbounded.
+func (q StatsQuery) end() time.Time {
+	if q.To.IsZero() {
+		return time.Now()
+	}
+
+	return q.To
+}
+
+// countbuckets counts the buckets from the one starting at first until end,
+// stopping once there are more than MaxSeriesBuckets.
+func (q StatsQuery) countbuckets(first, end time.Time) int {
+	count := 0
+	for start := first; start.Before(end) && count <= MaxSeriesBuckets; start = q.next(start) {
+		count++
+	}
+
+	return count
+}
+
+// next returns the start of the bucket that follows the one starting at start.
+func (q StatsQuery) next(start time.Time) time.Time {
+	year, month, day := start.Date()
+
+	switch q.Granularity {
+	case GranularityHour:
+		// adding absolute time instead of using the wall clock handles dst changes,
+		// but when the clock goes back the repeated hour maps to the same bucket
+		next := q.Bucket(start.Add(time.Hour))
+		if !next.After(start) {
+			next = q.Bucket(start.Add(2 * time.Hour))
+		}
+		return next
+	case GranularityWeek:
+		return time.Date(year, month, day+7, 0, 0, 0, 0, start.Location())
+	case GranularityMonth:
+		return time.Date(year, month+1, 1, 0, 0, 0, 0, start.Location())
+	default:
+		return time.Date(year, month, day+1, 0, 0, 0, 0, start.Location())
+	}
+}
+
+// MaxSeriesBuckets caps how many buckets a gap filled series can have.
+const MaxSeriesBuckets = 10000
+
+// TooManyBuckets checks if the query time range spans more than MaxSeriesBuckets,
+// until the current time if its end is unbounded.
+// Ranges with an unbounded start are limited by the data, which Series takes care of.
+func (q StatsQuery) TooManyBuckets() bool {
+	if q.From.IsZero() {
+		return false
+	}
+
+	return q.countbuckets(q.Bucket(q.From), q.end()) > MaxSeriesBuckets
+}
+
+// contains checks if the time is within the query time range.
+func (q StatsQuery) contains(t time.Time) bool {
+	if !q.From.IsZero() && t.Before(q.From) {
+		return false
+	}
+
+	if !q.To.IsZero() && !t.Before(q.To) {
+		return false
+	}
+
+	return true
+}
+
+// Bucket returns the start of the bucket the time falls into.
+func (q StatsQuery) Bucket(t time.Time) time.Time {
+	loc := q.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t = t.In(loc)
+	year, month, day := t.Date()
+
+	switch q.Granularity {
+	case GranularityHour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, loc)
+	case GranularityWeek:
+		// weeks start on monday
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, loc)
+	case GranularityMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsQueryAggregate(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err, "the time zone database should be available")
+
+	histogram := map[string]uint64{
+		"2022-06-10T21": 1, // friday 23:00 in madrid
+		"2022-06-10T22": 2, // saturday 00:00 in madrid
+		"2022-06-11T12": 3,
+		"2022-06-13T08": 4, // monday
+		"2022-07-01T00": 5,
+		"malformed":     6,
+	}
+
+	utc := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := map[string]struct {
+		query StatsQuery
+		want  map[time.Time]uint64
+	}{
+		"daily in utc": {
+			query: DefaultStatsQuery(),
+			want: map[time.Time]uint64{
+				utc(2022, 6, 10, 0): 3,
+				utc(2022, 6, 11, 0): 3,
+				utc(2022, 6, 13, 0): 4,
+				utc(2022, 7, 1, 0):  5,
+			},
+		},
+		"daily in madrid": {
+			query: StatsQuery{Granularity: GranularityDay, Location: madrid},
+			want: map[time.Time]uint64{
+				time.Date(2022, 6, 10, 0, 0, 0, 0, madrid): 1,
+				time.Date(2022, 6, 11, 0, 0, 0, 0, madrid): 5,
+				time.Date(2022, 6, 13, 0, 0, 0, 0, madrid): 4,
+				time.Date(2022, 7, 1, 0, 0, 0, 0, madrid):  5,
+			},
+		},
+		"hourly within range": {
+			query: StatsQuery{Granularity: GranularityHour, From: utc(2022, 6, 10, 22), To: utc(2022, 6, 13, 8)},
+			want: map[time.Time]uint64{
+				utc(2022, 6, 10, 22): 2,
+				utc(2022, 6, 11, 12): 3,
+			},
+		},
+		"weekly": {
+			query: StatsQuery{Granularity: GranularityWeek},
+			want: map[time.Time]uint64{
+				utc(2022, 6, 6, 0):  6,
+				utc(2022, 6, 13, 0): 4,
+				utc(2022, 6, 27, 0): 5,
+			},
+		},
+		"monthly": {
+			query: StatsQuery{Granularity: GranularityMonth},
+			want: map[time.Time]uint64{
+				utc(2022, 6, 1, 0): 10,
+				utc(2022, 7, 1, 0): 5,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := test.query.Aggregate(histogram)
+
+			require.Len(t, got, len(test.want), "unexpected amount of buckets: %v", got)
+			for start, hits := range test.want {
+				assert.Equal(t, hits, got[start], "unexpected hits on the bucket starting at %s", start)
+			}
+		})
+	}
+}
+
+func TestStatsQuerySeries(t *testing.T) {
+	histogram := map[string]uint64{
+		"2022-06-13T08": 4,
+		"2022-06-10T21": 1,
+		"2022-06-11T12": 3,
+	}
+
+	day := func(day int) time.Time {
+		return time.Date(2022, 6, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := map[string]struct {
+		query StatsQuery
+		want  []Bucket
+	}{
+		"sorted": {
+			query: DefaultStatsQuery(),
+			want:  []Bucket{{day(10), 1}, {day(11), 3}, {day(13), 4}},
+		},
+		"gaps filled from the first bucket": {
+			query: StatsQuery{Granularity: GranularityDay, FillGaps: true, To: day(15)},
+			want:  []Bucket{{day(10), 1}, {day(11), 3}, {day(12), 0}, {day(13), 4}, {day(14), 0}},
+		},
+		"gaps filled within the range": {
+			query: StatsQuery{Granularity: GranularityDay, FillGaps: true, From: day(9), To: day(12)},
+			want:  []Bucket{{day(9), 0}, {day(10), 1}, {day(11), 3}},
+		},
+		"too many gaps to fill": {
+			query: StatsQuery{Granularity: GranularityHour, FillGaps: true, From: time.Date(2, 1, 1, 0, 0, 0, 0, time.UTC)},
+			want:  []Bucket{{day(10).Add(21 * time.Hour), 1}, {day(11).Add(12 * time.Hour), 3}, {day(13).Add(8 * time.Hour), 4}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.query.Series(histogram))
+		})
+	}
+}
+
+func TestStatsQuerySeriesDST(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err, "the time zone database should be available")
+
+	// clocks went back from 03:00 to 02:00 on the 30th of october of 2022
+	query := StatsQuery{
+		Granularity: GranularityHour,
+		Location:    madrid,
+		FillGaps:    true,
+		From:        time.Date(2022, 10, 30, 0, 0, 0, 0, madrid),
+		To:          time.Date(2022, 10, 30, 5, 0, 0, 0, madrid),
+	}
+
+	series := query.Series(map[string]uint64{"2022-10-30T00": 1, "2022-10-30T01": 2})
+
+	hours := make([]int, 0, len(series))
+	for _, bucket := range series {
+		hours = append(hours, bucket.Start.Hour())
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, hours, "the repeated hour should be a single bucket")
+	assert.EqualValues(t, 3, series[2].Hits, "both utc hours of the repeated hour should be merged")
+	assert.False(t, query.TooManyBuckets(), "five buckets are not too many")
+}
+
+func TestStatsQuerySeriesAncientHits(t *testing.T) {
+	// e.g. an imported link, whose first hits are centuries old
+	histogram := map[string]uint64{"0002-01-01T00": 1, "2022-06-10T21": 2}
+	query := StatsQuery{Granularity: GranularityHour, FillGaps: true, To: time.Date(2022, 6, 11, 0, 0, 0, 0, time.UTC)}
+
+	series := query.Series(histogram)
+	assert.Len(t, series, 2, "the gaps shouldn't be filled if there are too many")
+
+	query.From = time.Date(2, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, query.TooManyBuckets())
+
+	query.To = time.Time{}
+	assert.True(t, query.TooManyBuckets(), "ranges until now should be checked too")
+}
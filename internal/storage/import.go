@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConflictPolicy decides what happens to the imported links whose slug already
+// exists, either on the database or earlier on the same import.
+type ConflictPolicy int
+
+const (
+	// ConflictSkip keeps the existing link, skipping the imported one.
+	ConflictSkip ConflictPolicy = iota
+	// ConflictOverwrite replaces the existing link with the imported one.
+	ConflictOverwrite
+	// ConflictRename imports the link with the first free slug made by adding a
+	// numeric suffix to its slug, e.g. search-2.
+	ConflictRename
+)
+
+// maxrenames is how many suffixes are tried when renaming a link.
+const maxrenames = 100
+
+// ImportOutcome is what happened to an imported link.
+type ImportOutcome string
+
+const (
+	ImportCreated     ImportOutcome = "created"
+	ImportOverwritten ImportOutcome = "overwritten"
+	ImportRenamed     ImportOutcome = "renamed"
+	ImportSkipped     ImportOutcome = "skipped"
+)
+
+// ImportResult is the outcome of an imported link and the slug it was stored with,
+// or the reason it failed.
+type ImportResult struct {
+	Slug    string
+	Outcome ImportOutcome
+	Err     error
+}
+
+// ImportLinks stores the links as they are, hits included, taking the lock only once
+// for the whole import, and returns the result of each link at the same position.
+// Links without slug get a generated one, and links whose slug already exists are
+// handled as the policy says. On a dry run nothing is stored, but the results are the
+// same the import would have.
+func (m *Memory) ImportLinks(links []*Link, policy ConflictPolicy, dryrun bool) []ImportResult {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	results := make([]ImportResult, len(links))
+	imported := make(map[string]bool, len(links)) // slugs taken by the import
+	taken := func(slug string) bool {
+		_, found := m.links[slug]
+		return found || imported[slug]
+	}
+
+	now := time.Now().UTC()
+
+	for i, link := range links {
+		slug := link.Slug
+		outcome := ImportCreated
+
+		switch {
+		case slug == "":
+			generated, err := m.genslug(imported)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			slug = generated
+
+		case taken(slug) && policy == ConflictSkip:
+			results[i] = ImportResult{Slug: slug, Outcome: ImportSkipped}
+			continue
+
+		case taken(slug) && policy == ConflictOverwrite:
+			outcome = ImportOverwritten
+
+		case taken(slug) && policy == ConflictRename:
+			renamed, err := rename(slug, taken)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			slug, outcome = renamed, ImportRenamed
+		}
+
+		imported[slug] = true
+		results[i] = ImportResult{Slug: slug, Outcome: outcome}
+
+		if dryrun {
+			continue
+		}
+
+		stored := link.clone()
+		stored.Slug = slug
+		if stored.CreatedAt.IsZero() {
+			stored.CreatedAt = now
+		}
+		if stored.Histogram == nil {
+			stored.Histogram = make(map[string]uint64)
+		}
+		m.put(stored)
+	}
+
+	return results
+}
+
+// rename returns the first slug made by adding a numeric suffix to the slug that's
+// not taken.
+func rename(slug string, taken func(slug string) bool) (string, error) {
+	for suffix := 2; suffix < maxrenames+2; suffix++ {
+		candidate := fmt.Sprintf("%s-%d", slug, suffix)
+		if !taken(candidate) {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not find a free slug for %s in %d attempts", slug, maxrenames)
+}
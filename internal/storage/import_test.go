@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryImportLinks(t *testing.T) {
+	created := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	imported := func(slug string, hits uint64) *Link {
+		return &Link{
+			Slug:      slug,
+			Target:    "https://imported.com",
+			Hits:      hits,
+			Histogram: map[string]uint64{created.Format(HourFormat): hits},
+			CreatedAt: created,
+		}
+	}
+
+	tests := map[string]struct {
+		policy ConflictPolicy
+		dryrun bool
+
+		wantResults []ImportResult
+		wantTarget  string // of the existing link after the import
+		wantHits    uint64 // of all links after the import
+	}{
+		"skip": {
+			policy: ConflictSkip,
+			wantResults: []ImportResult{
+				{Slug: "existing", Outcome: ImportSkipped},
+				{Slug: "new", Outcome: ImportCreated},
+				{Slug: "new", Outcome: ImportSkipped},
+				{Slug: "test", Outcome: ImportCreated},
+			},
+			wantTarget: "https://google.com",
+			wantHits:   1 + 20,
+		},
+		"overwrite": {
+			policy: ConflictOverwrite,
+			wantResults: []ImportResult{
+				{Slug: "existing", Outcome: ImportOverwritten},
+				{Slug: "new", Outcome: ImportCreated},
+				{Slug: "new", Outcome: ImportOverwritten},
+				{Slug: "test", Outcome: ImportCreated},
+			},
+			wantTarget: "https://imported.com",
+			wantHits:   10 + 30,
+		},
+		"rename": {
+			policy: ConflictRename,
+			wantResults: []ImportResult{
+				{Slug: "existing-3", Outcome: ImportRenamed},
+				{Slug: "new", Outcome: ImportCreated},
+				{Slug: "new-2", Outcome: ImportRenamed},
+				{Slug: "test", Outcome: ImportCreated},
+			},
+			wantTarget: "https://google.com",
+			wantHits:   1 + 10 + 20 + 30,
+		},
+		"dry run": {
+			policy: ConflictRename,
+			dryrun: true,
+			wantResults: []ImportResult{
+				{Slug: "existing-3", Outcome: ImportRenamed},
+				{Slug: "new", Outcome: ImportCreated},
+				{Slug: "new-2", Outcome: ImportRenamed},
+				{Slug: "test", Outcome: ImportCreated},
+			},
+			wantTarget: "https://google.com",
+			wantHits:   1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store, err := NewMemoryStorage(WithSlugGenerator(&staticslugger{}))
+			require.NoError(t, err, "shouldn't fail initing the store")
+
+			for _, slug := range []string{"existing", "existing-2"} {
+				slug := slug
+				_, err = store.CreateLink("https://google.com", &slug)
+				require.NoError(t, err)
+			}
+			store.RegisterHit(Hit{Slug: "existing"})
+
+			links := []*Link{imported("existing", 10), imported("new", 20), imported("new", 30), {Target: "https://generated.com"}}
+			results := store.ImportLinks(links, test.policy, test.dryrun)
+			assert.Equal(t, test.wantResults, results)
+
+			link, err := store.GetLink("existing")
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTarget, link.Target)
+
+			overview := store.Overview(DefaultStatsQuery(), 10)
+			assert.Equal(t, test.wantHits, overview.Hits, "the totals should include the imported hits")
+
+			if test.dryrun {
+				assert.Len(t, store.AllLinks(), 2, "dry runs shouldn't store anything")
+				return
+			}
+
+			// the second new link is only stored if it's not skipped
+			want := links[2]
+			if test.policy == ConflictSkip {
+				want = links[1]
+			}
+
+			link, err = store.GetLink(results[2].Slug)
+			require.NoError(t, err)
+			assert.Equal(t, created, link.CreatedAt, "the creation time should be kept")
+			assert.Equal(t, want.Histogram, link.Histogram, "the histogram should be kept")
+
+			store.RegisterHit(Hit{Slug: "test"})
+			link, err = store.GetLink("test")
+			require.NoError(t, err, "links without slug should get a generated one")
+			assert.EqualValues(t, 1, link.Hits, "imported links without histogram should register hits")
+		})
+	}
+}
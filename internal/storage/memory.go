@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 )
@@ -12,17 +13,34 @@ type Memory struct {
 	links   map[string]*Link
 	slugger SlugGenerator
 
+	// totals of all links, kept up to date for the overview
+	hits      uint64
+	bothits   uint64
+	histogram map[string]uint64
+
+	changes *changelog
+
+	webhooks    map[string]Webhook
+	deliveries  map[string]*Delivery
+	deadletters map[string]*Delivery
+
 	mutex sync.RWMutex
 }
 
 const maxrecursion = 5
 
 // NewMemoryStorage instantiates a new in-memory storage.
-// By default, it uses an UUIDSlugGenerator for generating slugs, but this can be
-// clustomized via MemoryOptions.
+// By default, it uses an UUIDSlugGenerator for generating slugs, and keeps the
+// latest 10000 changes on its changelog, but this can be clustomized via MemoryOptions.
 func NewMemoryStorage(opts ...MemoryOption) (*Memory, error) {
 	ms := Memory{
-		links: make(map[string]*Link, 0),
+		links:     make(map[string]*Link, 0),
+		histogram: make(map[string]uint64),
+		changes:   newchangelog(10000),
+
+		webhooks:    make(map[string]Webhook),
+		deliveries:  make(map[string]*Delivery),
+		deadletters: make(map[string]*Delivery),
 	}
 
 	for _, opt := range opts {
@@ -41,39 +59,96 @@ func NewMemoryStorage(opts ...MemoryOption) (*Memory, error) {
 // CreateLink to the target url received as parameter.
 // If the slug param is not nil, that slug will be used instead of generating
 // a new random one, which allows for custom shortened links.
-func (m *Memory) CreateLink(target string, slug *string) (string, error) {
+// The rest of the link properties can be customized via LinkOptions.
+func (m *Memory) CreateLink(target string, slug *string, opts ...LinkOption) (string, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	if slug == nil || *slug == "" {
-		s, err := m.genslug()
+		s, err := m.genslug(nil)
 		if err != nil {
 			return "", err
 		}
 		slug = &s
 	}
 
-	m.links[*slug] = &Link{
-		Slug:      *slug,
+	m.insert(target, *slug, opts)
+
+	return *slug, nil
+}
+
+// insert stores a new link, replacing the existing one with the same slug if any.
+// The caller must hold the write lock.
+func (m *Memory) insert(target, slug string, opts []LinkOption) {
+	link := Link{
+		Slug:      slug,
 		Target:    target,
 		Histogram: make(map[string]uint64),
+		Breakdown: newbreakdown(),
+		CreatedAt: time.Now().UTC(),
 	}
 
-	return *slug, nil
+	for _, opt := range opts {
+		opt(&link)
+	}
+
+	m.put(&link)
+}
+
+// put stores the link as it is, hits included, replacing the existing one with the
+// same slug if any.
+// The caller must hold the write lock.
+func (m *Memory) put(link *Link) {
+	kind := ChangeCreated
+	if m.replace(link) {
+		kind = ChangeUpdated
+	}
+
+	m.changes.record(kind, link.Slug, link)
+}
+
+// replace stores the link like put, without recording the change, and reports
+// whether there was a link with the same slug.
+// The caller must hold the write lock.
+func (m *Memory) replace(link *Link) bool {
+	previous, found := m.links[link.Slug]
+	if found {
+		m.untrack(previous)
+	}
+
+	m.links[link.Slug] = link
+	m.track(link)
+
+	return found
 }
 
 // GetLink returns the Link object associated with the specified slug.
+// The link is a copy, so it's not affected by later changes on the database.
 func (m *Memory) GetLink(slug string) (link *Link, err error) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
 	link, found := m.links[slug]
 	if !found {
-		err = fmt.Errorf("no link with slug %s found", slug)
+		err = notfound("no link with slug %s found", slug)
 		return
 	}
 
-	return link, nil
+	return link.clone(), nil
+}
+
+// GetRoute returns a copy of the link with the specified slug without its stats,
+// which are costly to copy, but with everything needed to redirect to it.
+func (m *Memory) GetRoute(slug string) (*Link, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	link, found := m.links[slug]
+	if !found {
+		return nil, notfound("no link with slug %s found", slug)
+	}
+
+	return link.slim(), nil
 }
 
 // DeleteLink removes a link from the database.
@@ -81,24 +156,65 @@ func (m *Memory) DeleteLink(slug string) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	delete(m.links, slug)
+	link, found := m.links[slug]
+	if !found {
+		return nil
+	}
+
+	m.remove(link)
 
 	return nil
 }
 
-// AllLinks returns all links stored in the database.
+// remove deletes a link that's stored on the database.
+// The caller must hold the write lock.
+func (m *Memory) remove(link *Link) {
+	m.untrack(link)
+	delete(m.links, link.Slug)
+	m.changes.record(ChangeDeleted, link.Slug, nil)
+}
+
+// AllLinks returns copies of all links stored in the database.
 func (m *Memory) AllLinks() []*Link {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
 	result := make([]*Link, 0, len(m.links))
 	for _, link := range m.links {
-		result = append(result, link)
+		result = append(result, link.clone())
 	}
 
 	return result
 }
 
+// WalkLinks calls fn with a copy of every link stored in the database, one at a
+// time and sorted by slug, stopping at the first error fn returns.
+// Unlike AllLinks, it doesn't hold every link in memory at once, nor does it block
+// the database while fn runs; links deleted during the walk are skipped.
+func (m *Memory) WalkLinks(fn func(link *Link) error) error {
+	m.mutex.RLock()
+	slugs := make([]string, 0, len(m.links))
+	for slug := range m.links {
+		slugs = append(slugs, slug)
+	}
+	m.mutex.RUnlock()
+
+	sort.Strings(slugs)
+
+	for _, slug := range slugs {
+		link, err := m.GetLink(slug)
+		if err != nil {
+			continue
+		}
+
+		if err := fn(link); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetTarget returns the full url associated with a specific slug.
 // It returns an error if the slug isn't found on the database.
 func (m *Memory) GetTarget(slug string) (string, error) {
@@ -107,31 +223,126 @@ func (m *Memory) GetTarget(slug string) (string, error) {
 
 	link, found := m.links[slug]
 	if !found {
-		return "", fmt.Errorf("no url found for slug: %s", slug)
+		return "", notfound("no url found for slug: %s", slug)
 	}
 
 	return link.Target, nil
 }
 
-// RegisterHit increments the hit counter for the specific slug and the current day.
+// RegisterHit increments the hit counter for the link the hit belongs to and the
+// utc hour of the visit, the counters of the rule that matched and the split arm the
+// visit was assigned to, if any, and the visitor breakdowns.
 // If the slug doesn't exist on the database this is noop.
-func (m *Memory) RegisterHit(slug string) {
+func (m *Memory) RegisterHit(hit Hit) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.registerhit(hit, time.Now())
+}
+
+// BatchRegisterHits registers all the hits at once, taking the lock only once
+// for the whole batch. Hits for slugs that don't exist are ignored.
+func (m *Memory) BatchRegisterHits(hits []Hit) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	now := time.Now()
-	bucket := now.Format("2006-01-02")
+	for _, hit := range hits {
+		m.registerhit(hit, now)
+	}
+}
+
+// registerhit updates the counters of the link the hit belongs to.
+// If the hit doesn't have a time, it's considered to happen at the specified time.
+// The caller must hold the write lock.
+func (m *Memory) registerhit(hit Hit, now time.Time) {
+	when := hit.Time
+	if when.IsZero() {
+		when = now
+	}
+	bucket := when.UTC().Format(HourFormat)
+
+	link := m.links[hit.Slug]
+	if link == nil {
+		return
+	}
+
+	if hit.Bot {
+		link.BotHits++
+		m.bothits++
+		return
+	}
+
+	link.Hits++
+	link.Histogram[bucket]++
+	m.hits++
+	m.histogram[bucket]++
+
+	// recorded once the hit is fully registered, so the snapshot includes it
+	if milestone(link.Hits) {
+		defer m.changes.record(ChangeMilestone, link.Slug, link)
+	}
+
+	if hit.Rule != nil && *hit.Rule >= 0 && *hit.Rule < len(link.Rules) {
+		link.Rules[*hit.Rule].Hits++
+	}
+
+	if hit.Arm != nil && *hit.Arm >= 0 && *hit.Arm < len(link.Split) {
+		link.Split[*hit.Arm].Hits++
+	}
 
-	if link := m.links[slug]; link != nil {
-		link.Hits++
-		link.Histogram[bucket]++
+	link.Breakdown.count(hit)
+	link.countvisitor(hit.Visitor, when)
+}
+
+// LastSequence returns the sequence number of the latest change on the changelog,
+// zero if there were no changes yet.
+func (m *Memory) LastSequence() uint64 {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.changes.last
+}
+
+// ChangesSince returns the changes recorded after the specified sequence number,
+// oldest first, along with a channel that's closed as soon as a newer change is
+// recorded, for waiting on it.
+// It fails with ErrChangesExpired if some of the changes were already discarded.
+func (m *Memory) ChangesSince(sequence uint64) ([]Change, <-chan struct{}, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	changes, err := m.changes.since(sequence)
+	return changes, m.changes.notify, err
+}
+
+// track adds the hits of a link that's being stored to the totals.
+func (m *Memory) track(link *Link) {
+	m.hits += link.Hits
+	m.bothits += link.BotHits
+
+	for bucket, hits := range link.Histogram {
+		m.histogram[bucket] += hits
+	}
+}
+
+// untrack removes the hits of a link that's being replaced or deleted from the totals.
+func (m *Memory) untrack(link *Link) {
+	m.hits -= link.Hits
+	m.bothits -= link.BotHits
+
+	for bucket, hits := range link.Histogram {
+		m.histogram[bucket] -= hits
+		if m.histogram[bucket] == 0 {
+			delete(m.histogram, bucket)
+		}
 	}
 }
 
 // genslug generates a slug using the slugger function.
-// If the generated slug already exists, it will keep generating slugs
-// until it finds a unique one, or the maxrecursion limit is hit.
-func (m *Memory) genslug() (string, error) {
+// If the generated slug already exists, or is reserved, it will keep generating
+// slugs until it finds a unique one, or the maxrecursion limit is hit.
+func (m *Memory) genslug(reserved map[string]bool) (string, error) {
 	var slug string
 	var err error
 	iteration := 0
@@ -147,7 +358,7 @@ func (m *Memory) genslug() (string, error) {
 		}
 
 		_, dupe := m.links[slug]
-		if !dupe {
+		if !dupe && !reserved[slug] {
 			break
 		}
 
@@ -165,3 +376,15 @@ func WithSlugGenerator(slugger SlugGenerator) MemoryOption {
 		return nil
 	}
 }
+
+// WithChangelogSize sets how many of the latest changes are kept on the changelog,
+// which determines how far behind consumers of the changes can fall.
+func WithChangelogSize(size int) MemoryOption {
+	return func(ms *Memory) error {
+		if size < 1 {
+			return fmt.Errorf("changelog size must be positive")
+		}
+		ms.changes = newchangelog(size)
+		return nil
+	}
+}
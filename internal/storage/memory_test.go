@@ -1,7 +1,10 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,6 +28,7 @@ func TestMemoryRoundtrip(t *testing.T) {
 	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
 
 	assert.Equal(t, target, link.Target, "the slug had a link on the db, but not for the correct url?")
+	assert.False(t, link.CreatedAt.IsZero(), "the creation time should be set by the store")
 
 	err = store.DeleteLink(slug)
 	require.NoError(t, err, "the in-memory db doesn't error on delete; and the slug should exist")
@@ -34,6 +38,10 @@ func TestMemoryRoundtrip(t *testing.T) {
 
 	_, err = store.GetTarget(slug)
 	require.Errorf(t, err, "we deleted this slug, it should fail when trying to fetch it")
+	assert.ErrorIs(t, err, ErrNotFound, "missing links should be reported as not found")
+
+	_, err = store.GetLink(slug)
+	assert.ErrorIs(t, err, ErrNotFound, "missing links should be reported as not found")
 }
 
 func TestMemoryHitRegistering(t *testing.T) {
@@ -46,8 +54,8 @@ func TestMemoryHitRegistering(t *testing.T) {
 	require.NoError(t, err, "creating a new link shouldn't error on this test")
 	assert.NotEqual(t, "", slug, "the slug should never be empty")
 
-	store.RegisterHit(slug)
-	store.RegisterHit(slug)
+	store.RegisterHit(Hit{Slug: slug})
+	store.RegisterHit(Hit{Slug: slug})
 
 	link, err := store.GetLink(slug)
 	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
@@ -56,6 +64,281 @@ func TestMemoryHitRegistering(t *testing.T) {
 	assert.EqualValues(t, 2, link.Hits, "this link should have been visited twice")
 }
 
+func TestMemoryRuleHitRegistering(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	rules := []Rule{
+		{Condition: Condition{Devices: []string{"ios"}}, Target: "https://apps.apple.com"},
+		{Condition: Condition{Devices: []string{"android"}}, Target: "https://play.google.com"},
+	}
+
+	slug, err := store.CreateLink("https://google.com", nil, WithRules(rules))
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	second := 1
+	outofrange := 5
+	store.RegisterHit(Hit{Slug: slug})
+	store.RegisterHit(Hit{Slug: slug, Rule: &second})
+	store.RegisterHit(Hit{Slug: slug, Rule: &second})
+	store.RegisterHit(Hit{Slug: slug, Rule: &outofrange})
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
+
+	require.Len(t, link.Rules, 2, "the link should keep the rules it was created with")
+	assert.EqualValues(t, 4, link.Hits, "every hit should count towards the total")
+	assert.EqualValues(t, 0, link.Rules[0].Hits, "the first rule never matched")
+	assert.EqualValues(t, 2, link.Rules[1].Hits, "the second rule matched twice")
+}
+
+func TestMemorySplitHitRegistering(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	arms := []Arm{
+		{Target: "https://example.com/a", Weight: 70},
+		{Target: "https://example.com/b", Weight: 30},
+	}
+
+	slug, err := store.CreateLink("https://example.com", nil, WithSplit(arms))
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	first, second := 0, 1
+	store.RegisterHit(Hit{Slug: slug, Arm: &first})
+	store.RegisterHit(Hit{Slug: slug, Arm: &second})
+	store.RegisterHit(Hit{Slug: slug, Arm: &second})
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
+
+	require.Len(t, link.Split, 2, "the link should keep the arms it was created with")
+	assert.EqualValues(t, 3, link.Hits, "every hit should count towards the total")
+	assert.EqualValues(t, 1, link.Split[0].Hits, "the first arm was visited once")
+	assert.EqualValues(t, 2, link.Split[1].Hits, "the second arm was visited twice")
+}
+
+func TestMemoryBreakdownRegistering(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	slug, err := store.CreateLink("https://google.com", nil)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	store.RegisterHit(Hit{Slug: slug, Referrer: "news.ycombinator.com", Browser: "Firefox", OS: "Linux", Device: "desktop", Country: "ES"})
+	store.RegisterHit(Hit{Slug: slug, Referrer: "news.ycombinator.com", Browser: "Safari", OS: "iOS", Device: "mobile"})
+	store.RegisterHit(Hit{Slug: slug, Browser: "Firefox", OS: "Android", Device: "mobile", Country: "ES"})
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
+
+	assert.EqualValues(t, 3, link.Hits, "every hit should count towards the total")
+	assert.Equal(t, map[string]uint64{"news.ycombinator.com": 2}, link.Breakdown.Referrers)
+	assert.Equal(t, map[string]uint64{"Firefox": 2, "Safari": 1}, link.Breakdown.Browsers)
+	assert.Equal(t, map[string]uint64{"Linux": 1, "iOS": 1, "Android": 1}, link.Breakdown.Systems)
+	assert.Equal(t, map[string]uint64{"desktop": 1, "mobile": 2}, link.Breakdown.Devices)
+	assert.Equal(t, map[string]uint64{"ES": 2}, link.Breakdown.Countries, "hits without country shouldn't be counted")
+
+	// the returned link is a copy, changes on the store shouldn't affect it
+	store.RegisterHit(Hit{Slug: slug, Referrer: "news.ycombinator.com", Country: "ES"})
+	assert.EqualValues(t, 3, link.Hits, "the link returned before shouldn't change")
+	assert.EqualValues(t, 2, link.Breakdown.Referrers["news.ycombinator.com"], "the breakdown returned before shouldn't change")
+}
+
+func TestMemoryBreakdownCap(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	slug, err := store.CreateLink("https://google.com", nil)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	for i := 0; i < maxbreakdownkeys+10; i++ {
+		store.RegisterHit(Hit{Slug: slug, Referrer: fmt.Sprintf("%d.example.com", i), Country: fmt.Sprintf("C%d", i), Browser: fmt.Sprintf("B%d", i)})
+	}
+	store.RegisterHit(Hit{Slug: slug, Referrer: "0.example.com", Country: "C0"})
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
+
+	assert.Len(t, link.Breakdown.Referrers, maxbreakdownkeys+1, "referrers past the max should be folded together")
+	assert.EqualValues(t, 10, link.Breakdown.Referrers[otherkey], "referrers past the max should count as other")
+	assert.EqualValues(t, 2, link.Breakdown.Referrers["0.example.com"], "referrers already seen should keep counting")
+	assert.Len(t, link.Breakdown.Countries, maxbreakdownkeys+1, "countries past the max should be folded together")
+	assert.EqualValues(t, 10, link.Breakdown.Countries[otherkey], "countries past the max should count as other")
+	assert.EqualValues(t, 2, link.Breakdown.Countries["C0"], "countries already seen should keep counting")
+	assert.Len(t, link.Breakdown.Browsers, maxbreakdownkeys+10, "browsers are bounded by the user agent parser")
+}
+
+func TestMemoryBatchHitRegistering(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	first, err := store.CreateLink("https://google.com", nil)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+	second, err := store.CreateLink("https://duckduckgo.com", nil)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	store.BatchRegisterHits(
+		[]Hit{
+			{Slug: first},
+			{Slug: second},
+			{Slug: "missing"},
+			{Slug: first, Time: time.Date(2022, 6, 11, 12, 30, 0, 0, time.UTC)},
+		},
+	)
+
+	link, err := store.GetLink(first)
+	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
+	assert.EqualValues(t, 2, link.Hits, "the first link was in the batch twice")
+	assert.EqualValues(t, 1, link.Histogram["2022-06-11T12"], "the hit should be registered on the hour it happened")
+
+	link, err = store.GetLink(second)
+	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
+	assert.EqualValues(t, 1, link.Hits, "the second link was in the batch once")
+}
+
+func TestMemoryUniqueVisitors(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	slug, err := store.CreateLink("https://google.com", nil)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	monday := time.Date(2022, 6, 13, 10, 0, 0, 0, time.UTC)
+	tuesday := monday.Add(24 * time.Hour)
+
+	store.BatchRegisterHits(
+		[]Hit{
+			{Slug: slug, Time: monday, Visitor: 0x9e3779b97f4a7c15},
+			{Slug: slug, Time: monday, Visitor: 0x9e3779b97f4a7c15},
+			{Slug: slug, Time: monday, Visitor: 0xbf58476d1ce4e5b9},
+			{Slug: slug, Time: tuesday, Visitor: 0x9e3779b97f4a7c15},
+			{Slug: slug, Time: tuesday}, // unknown visitor
+		},
+	)
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
+
+	assert.EqualValues(t, 5, link.Hits, "every hit should count towards the total")
+	require.NotNil(t, link.Visitors, "the link should have a visitors sketch")
+	assert.EqualValues(t, 2, link.Visitors.Estimate(), "there were only two different visitors")
+	assert.EqualValues(t, 2, link.DailyVisitors["2022-06-13"].Estimate(), "both visitors came on monday")
+	assert.EqualValues(t, 1, link.DailyVisitors["2022-06-14"].Estimate(), "only one known visitor came on tuesday")
+
+	// the returned link is a copy, changes on the store shouldn't affect it
+	store.RegisterHit(Hit{Slug: slug, Time: tuesday, Visitor: 0x94d049bb133111eb})
+	assert.EqualValues(t, 5, link.Hits, "the link returned before shouldn't change")
+	assert.EqualValues(t, 2, link.Visitors.Estimate(), "the sketch returned before shouldn't change")
+}
+
+func TestMergeVisitors(t *testing.T) {
+	monday := time.Date(2022, 6, 13, 10, 0, 0, 0, time.UTC)
+	tuesday := monday.Add(24 * time.Hour)
+
+	// the same link on two replicas, with one visitor that came to both
+	replicas := make([]*Link, 2)
+	for i, visitors := range [][]Hit{
+		{{Time: monday, Visitor: 0x9e3779b97f4a7c15}, {Time: monday, Visitor: 0xbf58476d1ce4e5b9}},
+		{{Time: monday, Visitor: 0x9e3779b97f4a7c15}, {Time: tuesday, Visitor: 0x94d049bb133111eb}},
+	} {
+		store, err := NewMemoryStorage()
+		require.NoError(t, err, "shouldn't fail initing the store")
+
+		slug, err := store.CreateLink("https://google.com", nil)
+		require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+		for _, hit := range visitors {
+			hit.Slug = slug
+			store.RegisterHit(hit)
+		}
+
+		replicas[i], err = store.GetLink(slug)
+		require.NoError(t, err)
+	}
+
+	merged := &Link{}
+	for _, replica := range replicas {
+		require.NoError(t, merged.MergeVisitors(replica), "sketches of the same precision should be mergeable")
+	}
+
+	require.NotNil(t, merged.Visitors, "the sketches should be created when missing")
+	assert.EqualValues(t, 3, merged.Visitors.Estimate(), "visitors of both replicas should only be counted once")
+	assert.EqualValues(t, 2, merged.DailyVisitors["2022-06-13"].Estimate(), "visitors of the same day should be merged")
+	assert.EqualValues(t, 1, merged.DailyVisitors["2022-06-14"].Estimate(), "days only one replica had should be kept")
+	assert.EqualValues(t, 2, replicas[0].Visitors.Estimate(), "the merged links shouldn't change")
+
+	require.NoError(t, merged.MergeVisitors(&Link{}), "links without visitors should be mergeable")
+	assert.EqualValues(t, 3, merged.Visitors.Estimate())
+}
+
+func TestMemoryBotHits(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	rule := 0
+	slug, err := store.CreateLink(
+		"https://google.com", nil,
+		WithRules([]Rule{{Condition: Condition{Devices: []string{"ios"}}, Target: "https://apple.com"}}),
+	)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	store.BatchRegisterHits(
+		[]Hit{
+			{Slug: slug, Browser: "Chrome", Device: "desktop", Visitor: 1},
+			{Slug: slug, Device: "bot", Bot: true, Visitor: 2},
+			{Slug: slug, Device: "bot", Bot: true, Rule: &rule, Visitor: 3},
+		},
+	)
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err, "this slug was returned by the previous create call; it should be on db")
+
+	assert.EqualValues(t, 1, link.Hits, "bot hits shouldn't count as regular hits")
+	assert.EqualValues(t, 2, link.BotHits, "bot hits should be counted separately")
+	assert.EqualValues(t, 0, link.Rules[0].Hits, "bot hits shouldn't count towards rules")
+	assert.Equal(t, map[string]uint64{"desktop": 1}, link.Breakdown.Devices, "bots shouldn't show on the breakdown")
+	assert.EqualValues(t, 1, link.Visitors.Estimate(), "bots shouldn't count as visitors")
+
+	var total uint64
+	for _, hits := range link.Histogram {
+		total += hits
+	}
+	assert.EqualValues(t, 1, total, "bot hits shouldn't show on the histogram")
+}
+
+func TestMemoryWalkLinks(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	for _, slug := range []string{"ccc", "aaa", "bbb"} {
+		slug := slug
+		_, err := store.CreateLink("https://google.com", &slug)
+		require.NoError(t, err, "creating a new link shouldn't error on this test")
+	}
+
+	var walked []string
+	err = store.WalkLinks(
+		func(link *Link) error {
+			walked = append(walked, link.Slug)
+			return nil
+		},
+	)
+	require.NoError(t, err, "walking shouldn't fail if the callback doesn't")
+	assert.Equal(t, []string{"aaa", "bbb", "ccc"}, walked, "links should be walked sorted by slug")
+
+	walked = nil
+	stop := errors.New("stop")
+	err = store.WalkLinks(
+		func(link *Link) error {
+			walked = append(walked, link.Slug)
+			return stop
+		},
+	)
+	assert.ErrorIs(t, err, stop, "the callback error should be returned")
+	assert.Equal(t, []string{"aaa"}, walked, "walking should stop on the first error")
+}
+
 type staticslugger struct{}
 
 func (s *staticslugger) Random() (string, error) {
@@ -77,3 +360,42 @@ func TestMemorySlugGenRecursion(t *testing.T) {
 	require.Error(t, err, "this time it should error, as the slug generator always returned the same value")
 	assert.Contains(t, err.Error(), "generate a unique slug")
 }
+
+func TestMemoryGetRoute(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	slug, err := store.CreateLink(
+		"https://google.com", nil,
+		WithRules([]Rule{{Condition: Condition{Devices: []string{"ios"}}, Target: "https://apple.com"}}),
+		WithSplit([]Arm{{Target: "https://a.com", Weight: 1}, {Target: "https://b.com", Weight: 1}}),
+		WithPasswordHash([]byte("hash")),
+		WithInterstitial(time.Second),
+	)
+	require.NoError(t, err, "creating a new link shouldn't error on this test")
+
+	store.RegisterHit(Hit{Slug: slug, Referrer: "news.ycombinator.com", Visitor: 0x9e3779b97f4a7c15})
+
+	route, err := store.GetRoute(slug)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://google.com", route.Target)
+	assert.Equal(t, "https://apple.com", route.Rules[0].Target)
+	assert.Len(t, route.Split, 2)
+	assert.Equal(t, []byte("hash"), route.PasswordHash)
+	assert.True(t, route.Interstitial)
+	assert.Equal(t, time.Second, route.InterstitialDelay)
+	assert.EqualValues(t, 1, route.Hits)
+	assert.Nil(t, route.Histogram, "stats aren't needed to redirect")
+	assert.Nil(t, route.Breakdown.Referrers, "stats aren't needed to redirect")
+	assert.Nil(t, route.Visitors, "stats aren't needed to redirect")
+	assert.Nil(t, route.DailyVisitors, "stats aren't needed to redirect")
+
+	route.Rules[0].Target = "https://changed.com"
+	link, err := store.GetLink(slug)
+	require.NoError(t, err)
+	assert.Equal(t, "https://apple.com", link.Rules[0].Target, "the route should be a copy")
+
+	_, err = store.GetRoute("missing")
+	assert.ErrorIs(t, err, ErrNotFound, "missing links should be reported as not found")
+}
@@ -1,8 +1,287 @@
 package storage
 
+import (
+	"time"
+
+	"github.com/aexvir/lnk/internal/hll"
+)
+
 type Link struct {
 	Slug      string            `json:"slug"`
 	Target    string            `json:"target"`
+	Rules     []Rule            `json:"rules"`
+	Split     []Arm             `json:"split"`
 	Hits      uint64            `json:"hits"`
-	Histogram map[string]uint64 `json:"histogram"`
+	Histogram map[string]uint64 `json:"histogram"` // hits per utc hour, keyed using HourFormat
+	Breakdown Breakdown         `json:"breakdown"`
+	CreatedAt time.Time         `json:"created_at"`
+
+	// BotHits counts the visits of crawlers and link preview fetchers, which are
+	// kept out of every other counter.
+	BotHits uint64 `json:"bot_hits"`
+
+	// Visitors estimates the unique visitors of the link, and DailyVisitors the ones
+	// of each utc day, keyed by date.
+	Visitors      *hll.Sketch            `json:"visitors"`
+	DailyVisitors map[string]*hll.Sketch `json:"daily_visitors"`
+
+	// Interstitial makes visitors go through a warning page that shows where the
+	// link is going, which redirects them after InterstitialDelay if it's not zero.
+	Interstitial      bool          `json:"interstitial"`
+	InterstitialDelay time.Duration `json:"interstitial_delay"`
+
+	// PasswordHash of the passphrase needed to follow the link, empty if the
+	// link is not protected.
+	PasswordHash []byte `json:"password_hash,omitempty"`
+}
+
+// Rule redirects visits to an alternative target when its condition matches.
+// Rules are evaluated in order and the first matching one wins.
+type Rule struct {
+	Condition Condition `json:"condition"`
+	Target    string    `json:"target"`
+	Hits      uint64    `json:"hits"`
+}
+
+// Condition that a visit has to meet for a rule to apply.
+// Empty criteria are ignored; all the non-empty ones have to match, and each of
+// them matches if any of its values match.
+type Condition struct {
+	Devices   []string   `json:"devices,omitempty"`
+	Languages []string   `json:"languages,omitempty"`
+	Countries []string   `json:"countries,omitempty"`
+	After     *time.Time `json:"after,omitempty"`
+	Before    *time.Time `json:"before,omitempty"`
+}
+
+// Arm is one of the targets of a link whose traffic is split between several
+// targets, e.g. for running experiments.
+// Each arm receives a share of the visits proportional to its weight.
+type Arm struct {
+	Target string `json:"target"`
+	Weight uint32 `json:"weight"`
+	Hits   uint64 `json:"hits"`
+}
+
+// clone returns a deep copy of the link, so it can be handed out of the store
+// without racing with later updates.
+func (l *Link) clone() *Link {
+	cp := *l
+
+	cp.Rules = append([]Rule(nil), l.Rules...)
+	cp.Split = append([]Arm(nil), l.Split...)
+	cp.Histogram = clonecounters(l.Histogram)
+	cp.Breakdown = Breakdown{
+		Referrers: clonecounters(l.Breakdown.Referrers),
+		Browsers:  clonecounters(l.Breakdown.Browsers),
+		Systems:   clonecounters(l.Breakdown.Systems),
+		Devices:   clonecounters(l.Breakdown.Devices),
+		Countries: clonecounters(l.Breakdown.Countries),
+	}
+
+	if l.Visitors != nil {
+		cp.Visitors = l.Visitors.Clone()
+	}
+
+	if l.DailyVisitors != nil {
+		cp.DailyVisitors = make(map[string]*hll.Sketch, len(l.DailyVisitors))
+		for day, sketch := range l.DailyVisitors {
+			cp.DailyVisitors[day] = sketch.Clone()
+		}
+	}
+
+	return &cp
+}
+
+// slim returns a copy of the link without its histogram, breakdown and visitor
+// sketches, which make up most of its size, for keeping it around cheaply.
+func (l *Link) slim() *Link {
+	cp := *l
+
+	cp.Rules = append([]Rule(nil), l.Rules...)
+	cp.Split = append([]Arm(nil), l.Split...)
+	cp.Histogram = nil
+	cp.Breakdown = Breakdown{}
+	cp.Visitors = nil
+	cp.DailyVisitors = nil
+
+	return &cp
+}
+
+func clonecounters(counters map[string]uint64) map[string]uint64 {
+	if counters == nil {
+		return nil
+	}
+
+	cp := make(map[string]uint64, len(counters))
+	for key, value := range counters {
+		cp[key] = value
+	}
+
+	return cp
+}
+
+// MergeVisitors merges the unique visitor sketches of the other link into this one,
+// e.g. for combining the counts of the same link on different replicas.
+func (l *Link) MergeVisitors(other *Link) error {
+	if other.Visitors != nil {
+		if l.Visitors == nil {
+			l.Visitors = hll.New()
+		}
+		if err := l.Visitors.Merge(other.Visitors); err != nil {
+			return err
+		}
+	}
+
+	for day, sketch := range other.DailyVisitors {
+		if l.DailyVisitors == nil {
+			l.DailyVisitors = make(map[string]*hll.Sketch)
+		}
+		if l.DailyVisitors[day] == nil {
+			l.DailyVisitors[day] = hll.New()
+		}
+		if err := l.DailyVisitors[day].Merge(sketch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// countvisitor adds the visitor to the sketches of the link.
+func (l *Link) countvisitor(visitor uint64, when time.Time) {
+	if visitor == 0 {
+		return
+	}
+
+	if l.Visitors == nil {
+		l.Visitors = hll.New()
+	}
+	l.Visitors.Add(visitor)
+
+	day := when.UTC().Format(DayFormat)
+	if l.DailyVisitors == nil {
+		l.DailyVisitors = make(map[string]*hll.Sketch)
+	}
+	if l.DailyVisitors[day] == nil {
+		l.DailyVisitors[day] = hll.New()
+	}
+	l.DailyVisitors[day].Add(visitor)
+}
+
+// maxbreakdownkeys is how many distinct referrers and countries are counted per link;
+// they come from the visitors, so they're not bounded otherwise.
+const maxbreakdownkeys = 500
+
+// otherkey is the value the referrers and countries are counted as once there are
+// too many distinct ones.
+const otherkey = "other"
+
+// Breakdown of the visits of a link by the properties of the visitors.
+// Each map holds the amount of visits for every value seen on that property; once
+// there are maxbreakdownkeys referrers or countries, new ones count as otherkey.
+type Breakdown struct {
+	Referrers map[string]uint64 `json:"referrers"`
+	Browsers  map[string]uint64 `json:"browsers"`
+	Systems   map[string]uint64 `json:"systems"`
+	Devices   map[string]uint64 `json:"devices"`
+	Countries map[string]uint64 `json:"countries"`
+}
+
+func newbreakdown() Breakdown {
+	return Breakdown{
+		Referrers: make(map[string]uint64),
+		Browsers:  make(map[string]uint64),
+		Systems:   make(map[string]uint64),
+		Devices:   make(map[string]uint64),
+		Countries: make(map[string]uint64),
+	}
+}
+
+// count the hit on every property it has a value for.
+func (b *Breakdown) count(hit Hit) {
+	increment(&b.Referrers, capped(b.Referrers, hit.Referrer))
+	increment(&b.Browsers, hit.Browser)
+	increment(&b.Systems, hit.OS)
+	increment(&b.Devices, hit.Device)
+	increment(&b.Countries, capped(b.Countries, hit.Country))
+}
+
+// capped returns the key, or otherkey if it's a new one and the counters are full.
+func capped(counters map[string]uint64, key string) string {
+	if _, ok := counters[key]; ok || len(counters) < maxbreakdownkeys {
+		return key
+	}
+
+	return otherkey
+}
+
+func increment(counters *map[string]uint64, key string) {
+	if key == "" {
+		return
+	}
+
+	if *counters == nil {
+		*counters = make(map[string]uint64)
+	}
+	(*counters)[key]++
+}
+
+// Hit represents a single visit to a link.
+type Hit struct {
+	Slug string
+	// Time of the visit; if zero, the time the hit is registered at is used.
+	Time time.Time
+
+	// Referrer is the host of the page the visitor came from.
+	Referrer string
+	Browser  string
+	OS       string
+	Device   string
+	Country  string
+	// Bot is set for automated visits, which only count towards the link BotHits.
+	Bot bool
+	// Visitor is an anonymous identifier of the visitor, used for counting unique
+	// visitors; zero if unknown.
+	Visitor uint64
+
+	// Rule is the index of the rule that matched the visit, nil if the visit
+	// was redirected to the base target.
+	Rule *int
+	// Arm is the index of the split arm the visit was assigned to, nil if the
+	// link has no split targets or a rule matched first.
+	Arm *int
+}
+
+// LinkOption customizes a link when it's being created.
+type LinkOption func(link *Link)
+
+// WithRules sets the conditional redirect rules of a link.
+func WithRules(rules []Rule) LinkOption {
+	return func(link *Link) {
+		link.Rules = rules
+	}
+}
+
+// WithSplit sets the weighted targets a link splits its visits between.
+func WithSplit(arms []Arm) LinkOption {
+	return func(link *Link) {
+		link.Split = arms
+	}
+}
+
+// WithPasswordHash protects a link with the passphrase the hash was derived from.
+func WithPasswordHash(hash []byte) LinkOption {
+	return func(link *Link) {
+		link.PasswordHash = hash
+	}
+}
+
+// WithInterstitial makes a link show a warning page before redirecting.
+// If the delay is zero, visitors have to click through the page to continue.
+func WithInterstitial(delay time.Duration) LinkOption {
+	return func(link *Link) {
+		link.Interstitial = true
+		link.InterstitialDelay = delay
+	}
 }
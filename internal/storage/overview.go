@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"sort"
+	"time"
+)
+
+// Overview summarizes the usage of all the links of the database.
+type Overview struct {
+	Links   uint64
+	Hits    uint64
+	BotHits uint64
+
+	// Histogram of the hits of all links, aggregated as specified by the query.
+	Histogram []Bucket
+
+	// Top are the links with the most hits within the query time range; their
+	// Hits only count the ones within the range.
+	Top []LinkSummary
+	// Newest are the most recently created links.
+	Newest []LinkSummary
+	// Unvisited are the links that were never visited, oldest first, as those are
+	// the most likely to be stale; UnvisitedLinks counts all of them.
+	Unvisited      []LinkSummary
+	UnvisitedLinks uint64
+}
+
+// LinkSummary is the minimal representation of a link on an overview.
+type LinkSummary struct {
+	Slug      string
+	Target    string
+	Hits      uint64
+	CreatedAt time.Time
+}
+
+func newsummary(link *Link, hits uint64) LinkSummary {
+	return LinkSummary{Slug: link.Slug, Target: link.Target, Hits: hits, CreatedAt: link.CreatedAt}
+}
+
+// Overview summarizes the usage of all links; the hits over time and the top links
+// are computed over the query time range, and every list has at most limit links.
+// Totals and the global histogram are maintained as hits are registered, so only
+// the link lists require going through the links.
+func (m *Memory) Overview(query StatsQuery, limit int) Overview {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	overview := Overview{
+		Links:     uint64(len(m.links)),
+		Hits:      m.hits,
+		BotHits:   m.bothits,
+		Histogram: query.Series(m.histogram),
+	}
+
+	top := newranking(
+		limit,
+		func(a, b LinkSummary) bool {
+			if a.Hits != b.Hits {
+				return a.Hits > b.Hits
+			}
+			return a.Slug < b.Slug
+		},
+	)
+	newest := newranking(limit, func(a, b LinkSummary) bool { return newer(a, b) })
+	unvisited := newranking(limit, func(a, b LinkSummary) bool { return newer(b, a) })
+
+	for _, link := range m.links {
+		if link.Hits == 0 {
+			overview.UnvisitedLinks++
+			unvisited.push(newsummary(link, 0))
+		}
+
+		newest.push(newsummary(link, link.Hits))
+
+		if hits := query.Total(link.Histogram); hits > 0 {
+			top.push(newsummary(link, hits))
+		}
+	}
+
+	overview.Top = top.items
+	overview.Newest = newest.items
+	overview.Unvisited = unvisited.items
+
+	return overview
+}
+
+// newer sorts links by creation time, most recent first, and by slug for links
+// created at the same time.
+func newer(a, b LinkSummary) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.Slug < b.Slug
+}
+
+// ranking keeps the first items according to less, without sorting all of them.
+type ranking struct {
+	limit int
+	less  func(a, b LinkSummary) bool
+	items []LinkSummary
+}
+
+func newranking(limit int, less func(a, b LinkSummary) bool) *ranking {
+	return &ranking{limit: limit, less: less, items: make([]LinkSummary, 0, limit)}
+}
+
+func (r *ranking) push(item LinkSummary) {
+	if r.limit <= 0 {
+		return
+	}
+
+	if len(r.items) == r.limit && !r.less(item, r.items[len(r.items)-1]) {
+		return
+	}
+
+	idx := sort.Search(len(r.items), func(i int) bool { return r.less(item, r.items[i]) })
+	if len(r.items) < r.limit {
+		r.items = append(r.items, LinkSummary{})
+	}
+	copy(r.items[idx+1:], r.items[idx:])
+	r.items[idx] = item
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryOverview(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	for _, slug := range []string{"aaa", "bbb", "ccc", "ddd", "eee"} {
+		slug := slug
+		_, err := store.CreateLink("https://google.com/"+slug, &slug)
+		require.NoError(t, err, "creating a new link shouldn't error on this test")
+	}
+
+	// make creation times deterministic
+	created := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	for idx, slug := range []string{"aaa", "bbb", "ccc", "ddd", "eee"} {
+		store.links[slug].CreatedAt = created.Add(time.Duration(idx) * time.Hour)
+	}
+
+	june := func(day int) time.Time { return time.Date(2022, 6, day, 12, 0, 0, 0, time.UTC) }
+
+	store.BatchRegisterHits(
+		[]Hit{
+			{Slug: "aaa", Time: june(10)},
+			{Slug: "aaa", Time: june(10)},
+			{Slug: "aaa", Time: june(10)},
+			{Slug: "bbb", Time: june(11)},
+			{Slug: "bbb", Time: june(11)},
+			{Slug: "bbb", Time: june(12)},
+			{Slug: "bbb", Time: june(12)},
+			{Slug: "ccc", Time: june(12)},
+			{Slug: "ccc", Time: june(12), Bot: true},
+			{Slug: "ddd", Time: june(12), Bot: true},
+		},
+	)
+
+	tests := map[string]struct {
+		query     StatsQuery
+		limit     int
+		top       []string
+		newest    []string
+		unvisited []string
+		histogram []Bucket
+	}{
+		"all time": {
+			query:     DefaultStatsQuery(),
+			limit:     2,
+			top:       []string{"bbb", "aaa"},
+			newest:    []string{"eee", "ddd"},
+			unvisited: []string{"ddd", "eee"},
+			histogram: []Bucket{
+				{Start: time.Date(2022, 6, 10, 0, 0, 0, 0, time.UTC), Hits: 3},
+				{Start: time.Date(2022, 6, 11, 0, 0, 0, 0, time.UTC), Hits: 2},
+				{Start: time.Date(2022, 6, 12, 0, 0, 0, 0, time.UTC), Hits: 3},
+			},
+		},
+		"within range": {
+			query:     StatsQuery{Granularity: GranularityDay, From: june(12).Truncate(24 * time.Hour)},
+			limit:     10,
+			top:       []string{"bbb", "ccc"},
+			newest:    []string{"eee", "ddd", "ccc", "bbb", "aaa"},
+			unvisited: []string{"ddd", "eee"},
+			histogram: []Bucket{
+				{Start: time.Date(2022, 6, 12, 0, 0, 0, 0, time.UTC), Hits: 3},
+			},
+		},
+	}
+
+	slugs := func(summaries []LinkSummary) []string {
+		result := make([]string, 0, len(summaries))
+		for _, summary := range summaries {
+			result = append(result, summary.Slug)
+		}
+		return result
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			overview := store.Overview(test.query, test.limit)
+
+			assert.EqualValues(t, 5, overview.Links)
+			assert.EqualValues(t, 8, overview.Hits)
+			assert.EqualValues(t, 2, overview.BotHits)
+			assert.EqualValues(t, 2, overview.UnvisitedLinks)
+			assert.Equal(t, test.top, slugs(overview.Top), "top links don't match")
+			assert.Equal(t, test.newest, slugs(overview.Newest), "newest links don't match")
+			assert.Equal(t, test.unvisited, slugs(overview.Unvisited), "unvisited links don't match")
+			assert.Equal(t, test.histogram, overview.Histogram, "histogram doesn't match")
+		})
+	}
+
+	require.NoError(t, store.DeleteLink("bbb"))
+
+	overview := store.Overview(DefaultStatsQuery(), 10)
+	assert.EqualValues(t, 4, overview.Links, "deleted links shouldn't count")
+	assert.EqualValues(t, 4, overview.Hits, "hits of deleted links shouldn't count")
+	assert.Equal(t, []string{"aaa", "ccc"}, slugs(overview.Top))
+	assert.Equal(
+		t,
+		[]Bucket{
+			{Start: time.Date(2022, 6, 10, 0, 0, 0, 0, time.UTC), Hits: 3},
+			{Start: time.Date(2022, 6, 12, 0, 0, 0, 0, time.UTC), Hits: 1},
+		},
+		overview.Histogram,
+		"hits of deleted links shouldn't show on the histogram",
+	)
+}
@@ -1,6 +1,22 @@
 package storage
 
-import "github.com/google/uuid"
+import (
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// slugpattern are the slugs that can be used on urls without escaping.
+var slugpattern = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+
+// reservedslugs are the paths that are not redirected.
+var reservedslugs = map[string]bool{"api": true, ".": true, "..": true}
+
+// ValidSlug checks that the slug can be used on urls without escaping and that it
+// isn't one of the paths that are not redirected.
+func ValidSlug(slug string) bool {
+	return slugpattern.MatchString(slug) && !reservedslugs[slug]
+}
 
 type SlugGenerator interface {
 	Random() (string, error)
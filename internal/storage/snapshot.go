@@ -0,0 +1,340 @@
+package storage
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// SnapshotVersion is the version of the snapshot format written by WriteSnapshot.
+// Snapshots of older versions can still be read.
+const SnapshotVersion = 1
+
+// snapshotmagic starts every snapshot, followed by a byte with the version of its
+// format and the compressed contents.
+const snapshotmagic = "LNKSNAP"
+
+// maxsnapshotline caps the size of each of the records of a snapshot.
+const maxsnapshotline = 64 << 20
+
+// ErrInvalidSnapshot is returned when restoring data that isn't a valid snapshot.
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// Snapshotter is implemented by the stores that can be backed up and restored.
+// Snapshots don't depend on the backend of the store, so they're meant to be restored
+// on any other one, but the memory store is the only one implementing them for now.
+type Snapshotter interface {
+	// Snapshot writes a consistent copy of the whole store.
+	Snapshot(w io.Writer) error
+	// Restore replaces the contents of the store with the ones of the snapshot.
+	// Nothing is changed unless the whole snapshot can be read.
+	Restore(r io.Reader) (SnapshotInfo, error)
+}
+
+// SnapshotData is everything a store holds: the links with their hits, histograms
+// and visitor sketches, and the webhooks with their pending deliveries.
+type SnapshotData struct {
+	CreatedAt time.Time
+	// Sequence is the one of the latest change of the store, so the changelog of the
+	// restored store continues from it instead of starting over.
+	Sequence    uint64
+	Links       []*Link
+	Webhooks    []Webhook
+	Deliveries  []Delivery
+	DeadLetters []Delivery
+}
+
+// SnapshotInfo describes a snapshot.
+type SnapshotInfo struct {
+	Version     int
+	CreatedAt   time.Time
+	Links       int
+	Webhooks    int
+	Deliveries  int
+	DeadLetters int
+}
+
+// snapshotheader is the first record of a snapshot; the counts are used for making
+// sure the whole snapshot was read.
+type snapshotheader struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Sequence    uint64    `json:"sequence,omitempty"`
+	Links       int       `json:"links"`
+	Webhooks    int       `json:"webhooks"`
+	Deliveries  int       `json:"deliveries"`
+	DeadLetters int       `json:"dead_letters"`
+}
+
+// snapshotrecord is each of the records after the header, which has only one of its
+// fields set.
+type snapshotrecord struct {
+	Link       *Link     `json:"link,omitempty"`
+	Webhook    *Webhook  `json:"webhook,omitempty"`
+	Delivery   *Delivery `json:"delivery,omitempty"`
+	DeadLetter *Delivery `json:"dead_letter,omitempty"`
+}
+
+// WriteSnapshot writes the data on the latest snapshot format; a gzip compressed
+// stream of json records, one per line, starting with a header that has the counts
+// of the rest.
+func WriteSnapshot(w io.Writer, data *SnapshotData) error {
+	if _, err := w.Write(append([]byte(snapshotmagic), SnapshotVersion)); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+
+	compressed := gzip.NewWriter(w)
+	encoder := json.NewEncoder(compressed)
+
+	records := []any{
+		snapshotheader{
+			CreatedAt:   data.CreatedAt,
+			Sequence:    data.Sequence,
+			Links:       len(data.Links),
+			Webhooks:    len(data.Webhooks),
+			Deliveries:  len(data.Deliveries),
+			DeadLetters: len(data.DeadLetters),
+		},
+	}
+	for _, link := range data.Links {
+		records = append(records, snapshotrecord{Link: link})
+	}
+	for idx := range data.Webhooks {
+		records = append(records, snapshotrecord{Webhook: &data.Webhooks[idx]})
+	}
+	for idx := range data.Deliveries {
+		records = append(records, snapshotrecord{Delivery: &data.Deliveries[idx]})
+	}
+	for idx := range data.DeadLetters {
+		records = append(records, snapshotrecord{DeadLetter: &data.DeadLetters[idx]})
+	}
+
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("error writing snapshot: %w", err)
+		}
+	}
+
+	if err := compressed.Close(); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot, of the current version or
+// an older one, checking that it's complete and consistent.
+// Links without histogram get an empty one, so they can be stored as they are.
+func ReadSnapshot(r io.Reader) (*SnapshotData, SnapshotInfo, error) {
+	prefix := make([]byte, len(snapshotmagic)+1)
+	if _, err := io.ReadFull(r, prefix); err != nil || string(prefix[:len(snapshotmagic)]) != snapshotmagic {
+		return nil, SnapshotInfo{}, fmt.Errorf("%w: not a lnk snapshot", ErrInvalidSnapshot)
+	}
+
+	version := int(prefix[len(snapshotmagic)])
+	switch {
+	case version == 0:
+		return nil, SnapshotInfo{}, fmt.Errorf("%w: unknown version 0", ErrInvalidSnapshot)
+	case version > SnapshotVersion:
+		return nil, SnapshotInfo{}, fmt.Errorf("%w: version %d is newer than the supported one, %d", ErrInvalidSnapshot, version, SnapshotVersion)
+	}
+
+	data, err := readsnapshotv1(r)
+	if err != nil {
+		return nil, SnapshotInfo{}, err
+	}
+
+	info := SnapshotInfo{
+		Version:     version,
+		CreatedAt:   data.CreatedAt,
+		Links:       len(data.Links),
+		Webhooks:    len(data.Webhooks),
+		Deliveries:  len(data.Deliveries),
+		DeadLetters: len(data.DeadLetters),
+	}
+
+	return data, info, nil
+}
+
+func readsnapshotv1(r io.Reader) (*SnapshotData, error) {
+	compressed, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSnapshot, err)
+	}
+	defer compressed.Close()
+
+	scanner := bufio.NewScanner(compressed)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxsnapshotline)
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("%w: missing header: %v", ErrInvalidSnapshot, scanner.Err())
+	}
+
+	var header snapshotheader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %s", ErrInvalidSnapshot, err)
+	}
+
+	data := SnapshotData{CreatedAt: header.CreatedAt, Sequence: header.Sequence}
+	slugs := make(map[string]bool, header.Links)
+	ids := make(map[string]bool)
+
+	for line := 2; scanner.Scan(); line++ {
+		var record snapshotrecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%w: malformed record on line %d: %s", ErrInvalidSnapshot, line, err)
+		}
+
+		switch {
+		case record.Link != nil:
+			link := record.Link
+			if link.Slug == "" || slugs[link.Slug] {
+				return nil, fmt.Errorf("%w: missing or duplicated slug %q on line %d", ErrInvalidSnapshot, link.Slug, line)
+			}
+			slugs[link.Slug] = true
+
+			if link.Histogram == nil {
+				link.Histogram = make(map[string]uint64)
+			}
+			data.Links = append(data.Links, link)
+
+		case record.Webhook != nil:
+			if record.Webhook.ID == "" || ids[record.Webhook.ID] {
+				return nil, fmt.Errorf("%w: missing or duplicated webhook id %q on line %d", ErrInvalidSnapshot, record.Webhook.ID, line)
+			}
+			ids[record.Webhook.ID] = true
+			data.Webhooks = append(data.Webhooks, *record.Webhook)
+
+		case record.Delivery != nil, record.DeadLetter != nil:
+			delivery := record.Delivery
+			if delivery == nil {
+				delivery = record.DeadLetter
+			}
+
+			if delivery.ID == "" || ids[delivery.ID] {
+				return nil, fmt.Errorf("%w: missing or duplicated delivery id %q on line %d", ErrInvalidSnapshot, delivery.ID, line)
+			}
+			ids[delivery.ID] = true
+
+			if record.Delivery != nil {
+				data.Deliveries = append(data.Deliveries, *delivery)
+			} else {
+				data.DeadLetters = append(data.DeadLetters, *delivery)
+			}
+
+		default:
+			return nil, fmt.Errorf("%w: empty record on line %d", ErrInvalidSnapshot, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSnapshot, err)
+	}
+
+	if len(data.Links) != header.Links || len(data.Webhooks) != header.Webhooks ||
+		len(data.Deliveries) != header.Deliveries || len(data.DeadLetters) != header.DeadLetters {
+		return nil, fmt.Errorf("%w: the contents don't match the header, the snapshot may be truncated", ErrInvalidSnapshot)
+	}
+
+	return &data, nil
+}
+
+// Snapshot writes a copy of every link, webhook and delivery of the database.
+// The database is only blocked while they're copied, not while they're written.
+func (m *Memory) Snapshot(w io.Writer) error {
+	m.mutex.RLock()
+
+	data := SnapshotData{
+		CreatedAt:   time.Now().UTC(),
+		Sequence:    m.changes.last,
+		Links:       make([]*Link, 0, len(m.links)),
+		Webhooks:    make([]Webhook, 0, len(m.webhooks)),
+		Deliveries:  make([]Delivery, 0, len(m.deliveries)),
+		DeadLetters: make([]Delivery, 0, len(m.deadletters)),
+	}
+
+	for _, link := range m.links {
+		data.Links = append(data.Links, link.clone())
+	}
+	for _, hook := range m.webhooks {
+		hook.Events = append([]string(nil), hook.Events...)
+		hook.Slugs = append([]string(nil), hook.Slugs...)
+		data.Webhooks = append(data.Webhooks, hook)
+	}
+	for _, delivery := range m.deliveries {
+		data.Deliveries = append(data.Deliveries, *delivery)
+	}
+	for _, delivery := range m.deadletters {
+		data.DeadLetters = append(data.DeadLetters, *delivery)
+	}
+
+	m.mutex.RUnlock()
+
+	sort.Slice(data.Links, func(i, j int) bool { return data.Links[i].Slug < data.Links[j].Slug })
+	sort.Slice(data.Webhooks, func(i, j int) bool { return data.Webhooks[i].CreatedAt.Before(data.Webhooks[j].CreatedAt) })
+	sort.Slice(data.Deliveries, func(i, j int) bool { return data.Deliveries[i].Created.Before(data.Deliveries[j].Created) })
+	sort.Slice(data.DeadLetters, func(i, j int) bool { return data.DeadLetters[i].Created.Before(data.DeadLetters[j].Created) })
+
+	return WriteSnapshot(w, &data)
+}
+
+// Restore replaces every link, webhook and delivery of the database with the ones of
+// the snapshot.
+// The totals of the overview end up covering only the restored links, and the changes
+// are recorded on the changelog like any other; links missing from the snapshot as
+// deleted, and the rest as created or updated, so watchers and caches catch up.
+// If the snapshot is ahead of the changelog, e.g. when restoring it after a restart,
+// the changelog continues from the sequence of the snapshot, and the watchers that
+// are behind it have to reload every link.
+func (m *Memory) Restore(r io.Reader) (SnapshotInfo, error) {
+	data, info, err := ReadSnapshot(r)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	restored := make(map[string]bool, len(data.Links))
+	for _, link := range data.Links {
+		restored[link.Slug] = true
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.changes.resume(data.Sequence)
+
+	for slug, link := range m.links {
+		if !restored[slug] {
+			m.untrack(link)
+			delete(m.links, slug)
+		}
+	}
+
+	for _, link := range data.Links {
+		m.replace(link)
+	}
+
+	// a single change for the whole store, as one per link would flood the watchers
+	// and the webhooks with every link it has
+	m.changes.record(ChangeRestored, "", nil)
+
+	m.webhooks = make(map[string]Webhook, len(data.Webhooks))
+	for _, hook := range data.Webhooks {
+		m.webhooks[hook.ID] = hook
+	}
+
+	m.deliveries = make(map[string]*Delivery, len(data.Deliveries))
+	for idx := range data.Deliveries {
+		m.deliveries[data.Deliveries[idx].ID] = &data.Deliveries[idx]
+	}
+
+	m.deadletters = make(map[string]*Delivery, len(data.DeadLetters))
+	for idx := range data.DeadLetters {
+		m.deadletters[data.DeadLetters[idx].ID] = &data.DeadLetters[idx]
+	}
+
+	return info, nil
+}
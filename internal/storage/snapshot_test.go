@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// there's only the memory backend for now, so the round trip goes between two
+// differently configured memory stores; any other backend should pass it too
+func TestMemorySnapshotRoundtrip(t *testing.T) {
+	source, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	rule := 0
+	_, err = source.CreateLink(
+		"https://example.com", ptr("example"),
+		WithRules([]Rule{{Condition: Condition{Devices: []string{"ios"}}, Target: "https://apps.apple.com"}}),
+		WithPasswordHash([]byte("hash")),
+		WithInterstitial(5*time.Second),
+	)
+	require.NoError(t, err)
+	_, err = source.CreateLink("https://split.com", ptr("split"), WithSplit([]Arm{{Target: "https://a.com", Weight: 1}, {Target: "https://b.com", Weight: 3}}))
+	require.NoError(t, err)
+	_, err = source.CreateLink("https://unvisited.com", ptr("unvisited"))
+	require.NoError(t, err)
+
+	when := time.Date(2022, 6, 11, 12, 0, 0, 0, time.UTC)
+	source.BatchRegisterHits(
+		[]Hit{
+			{Slug: "example", Time: when, Rule: &rule, Browser: "Safari", Country: "ES", Visitor: 1},
+			{Slug: "example", Time: when.Add(time.Hour), Visitor: 2},
+			{Slug: "split", Time: when, Arm: ptr(1), Referrer: "news.ycombinator.com"},
+			{Slug: "split", Time: when, Bot: true},
+		},
+	)
+
+	hook, err := source.CreateWebhook(Webhook{URL: "http://localhost/hook", Secret: "secret", Events: []string{"link.created"}})
+	require.NoError(t, err)
+	require.NoError(t, source.EnqueueDeliveries([]Delivery{{Webhook: hook.ID, Event: "link.created", Payload: []byte(`{}`)}, {Webhook: hook.ID, Event: "link.deleted"}}))
+	claimed := source.ClaimDeliveries(time.Now(), time.Minute, 1)
+	require.Len(t, claimed, 1)
+	source.DeadLetterDelivery(claimed[0].ID, "gone")
+
+	var snapshot bytes.Buffer
+	require.NoError(t, source.Snapshot(&snapshot), "shouldn't fail taking the snapshot")
+
+	target, err := NewMemoryStorage(WithChangelogSize(100), WithSlugGenerator(&staticslugger{}))
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	_, err = target.CreateLink("https://stale.com", ptr("stale"))
+	require.NoError(t, err)
+	_, err = target.CreateLink("https://old.com", ptr("example"))
+	require.NoError(t, err)
+	_, err = target.CreateLink("https://temporary.com", ptr("temporary"))
+	require.NoError(t, err)
+	require.NoError(t, target.DeleteLink("temporary"))
+	target.RegisterHit(Hit{Slug: "stale", Time: when})
+	before := target.LastSequence()
+	require.Greater(t, before, source.LastSequence(), "the target changelog should be ahead of the snapshot")
+
+	info, err := target.Restore(&snapshot)
+	require.NoError(t, err, "shouldn't fail restoring the snapshot")
+	assert.Equal(t, SnapshotVersion, info.Version)
+	assert.Equal(t, 3, info.Links)
+	assert.Equal(t, 1, info.Webhooks)
+	assert.Equal(t, 1, info.Deliveries)
+	assert.Equal(t, 1, info.DeadLetters)
+	assert.False(t, info.CreatedAt.IsZero(), "the snapshot should have its creation time")
+
+	assert.Equal(t, sortedlinks(source.AllLinks()), sortedlinks(target.AllLinks()), "the links should be restored as they were")
+	assert.Equal(t, source.GetWebhooks(), target.GetWebhooks(), "the webhooks should be restored")
+	assert.Equal(t, source.DeadLetters(""), target.DeadLetters(""), "the dead letters should be restored")
+	assert.Len(t, target.ClaimDeliveries(time.Now(), time.Minute, 10), 1, "the pending deliveries should be restored")
+
+	sourceview := source.Overview(DefaultStatsQuery(), 10)
+	targetview := target.Overview(DefaultStatsQuery(), 10)
+	assert.EqualValues(t, 3, targetview.Links)
+	assert.EqualValues(t, 3, targetview.Hits, "the totals should only include the restored links")
+	assert.EqualValues(t, 1, targetview.BotHits)
+	assert.Equal(t, sourceview.Histogram, targetview.Histogram)
+
+	changes, _, err := target.ChangesSince(before)
+	require.NoError(t, err)
+
+	require.Len(t, changes, 1, "the restore should be recorded once on the changelog")
+	assert.Equal(t, ChangeRestored, changes[0].Kind)
+	assert.Empty(t, changes[0].Slug)
+	assert.Nil(t, changes[0].Link)
+
+	target.RegisterHit(Hit{Slug: "split", Time: when, Arm: ptr(0)})
+	link, err := target.GetLink("split")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, link.Hits, "restored links should keep counting hits")
+	assert.EqualValues(t, 1, link.Split[0].Hits)
+}
+
+func TestMemoryRestoreSequence(t *testing.T) {
+	source, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	for _, slug := range []string{"a", "b", "c"} {
+		_, err = source.CreateLink("https://example.com", ptr(slug))
+		require.NoError(t, err)
+	}
+	require.NoError(t, source.DeleteLink("c"))
+	sequence := source.LastSequence()
+
+	var snapshot bytes.Buffer
+	require.NoError(t, source.Snapshot(&snapshot), "shouldn't fail taking the snapshot")
+
+	// as happens when the service restarts and restores its snapshot
+	target, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+	_, err = target.Restore(&snapshot)
+	require.NoError(t, err, "shouldn't fail restoring the snapshot")
+
+	assert.EqualValues(t, sequence+1, target.LastSequence(), "the changelog should continue from the snapshot")
+
+	changes, _, err := target.ChangesSince(sequence)
+	require.NoError(t, err, "watchers that were up to date should be told about the restore")
+	require.Len(t, changes, 1)
+	assert.Equal(t, ChangeRestored, changes[0].Kind)
+
+	_, _, err = target.ChangesSince(sequence - 1)
+	assert.ErrorIs(t, err, ErrChangesExpired, "watchers that were behind the snapshot have to reload")
+}
+
+func TestReadSnapshot(t *testing.T) {
+	valid := func() []byte {
+		var buf bytes.Buffer
+		require.NoError(t, WriteSnapshot(&buf, &SnapshotData{Links: []*Link{{Slug: "a", Target: "https://a.com"}}}))
+		return buf.Bytes()
+	}
+
+	compressed := func(lines ...string) []byte {
+		var buf bytes.Buffer
+		buf.WriteString(snapshotmagic)
+		buf.WriteByte(SnapshotVersion)
+
+		gz := gzip.NewWriter(&buf)
+		_, _ = gz.Write([]byte(strings.Join(lines, "\n")))
+		require.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+
+	tests := map[string]struct {
+		data []byte
+
+		wantErr string
+	}{
+		"valid snapshot": {
+			data: valid(),
+		},
+		"not a snapshot": {
+			data:    []byte("slug,target\na,https://a.com\n"),
+			wantErr: "not a lnk snapshot",
+		},
+		"empty": {
+			wantErr: "not a lnk snapshot",
+		},
+		"newer version": {
+			data:    append([]byte(snapshotmagic), SnapshotVersion+1),
+			wantErr: "version 2 is newer than the supported one",
+		},
+		"truncated": {
+			data:    valid()[:40],
+			wantErr: "invalid snapshot",
+		},
+		"missing records": {
+			data:    compressed(`{"links":2}`, `{"link":{"slug":"a","target":"https://a.com"}}`),
+			wantErr: "the contents don't match the header",
+		},
+		"duplicated slugs": {
+			data:    compressed(`{"links":2}`, `{"link":{"slug":"a"}}`, `{"link":{"slug":"a"}}`),
+			wantErr: `duplicated slug "a" on line 3`,
+		},
+		"empty record": {
+			data:    compressed(`{"links":1}`, `{}`),
+			wantErr: "empty record on line 2",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, info, err := ReadSnapshot(bytes.NewReader(test.data))
+
+			if test.wantErr != "" {
+				require.ErrorIs(t, err, ErrInvalidSnapshot)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, info.Links)
+			require.Len(t, data.Links, 1)
+			assert.NotNil(t, data.Links[0].Histogram, "links should get an empty histogram")
+		})
+	}
+}
+
+func sortedlinks(links []*Link) []*Link {
+	sort.Slice(links, func(i, j int) bool { return links[i].Slug < links[j].Slug })
+	return links
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,247 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is a subscription to the events of the links, which are delivered as
+// http requests to its url.
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Secret the payloads are signed with.
+	Secret string `json:"secret"`
+	// Events the webhook is subscribed to; all of them if empty.
+	Events []string `json:"events,omitempty"`
+	// Slugs of the links whose events are delivered; all links if empty.
+	Slugs     []string  `json:"slugs,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Delivery of an event to a webhook, which is retried until it succeeds or it
+// runs out of attempts, ending up on the dead letters.
+type Delivery struct {
+	ID      string    `json:"id"`
+	Webhook string    `json:"webhook"`
+	Event   string    `json:"event"`
+	Payload []byte    `json:"payload"`
+	Created time.Time `json:"created"`
+
+	// Attempts done so far, and the error of the last one.
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+	// NextAttempt is the time the delivery is due at.
+	NextAttempt time.Time `json:"next_attempt"`
+}
+
+// CreateWebhook stores the subscription, assigning it an identifier.
+func (m *Memory) CreateWebhook(hook Webhook) (Webhook, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return Webhook{}, fmt.Errorf("error generating webhook id: %w", err)
+	}
+
+	hook.ID = id.String()
+	hook.CreatedAt = time.Now().UTC()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.webhooks[hook.ID] = hook
+
+	return hook, nil
+}
+
+// GetWebhook returns the webhook with the specified id.
+func (m *Memory) GetWebhook(id string) (Webhook, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	hook, found := m.webhooks[id]
+	if !found {
+		return Webhook{}, notfound("no webhook with id %s found", id)
+	}
+
+	return hook, nil
+}
+
+// GetWebhooks returns all the webhooks, oldest first.
+func (m *Memory) GetWebhooks() []Webhook {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := make([]Webhook, 0, len(m.webhooks))
+	for _, hook := range m.webhooks {
+		result = append(result, hook)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+
+	return result
+}
+
+// DeleteWebhook removes the webhook along with its pending deliveries and dead letters.
+func (m *Memory) DeleteWebhook(id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, found := m.webhooks[id]; !found {
+		return notfound("no webhook with id %s found", id)
+	}
+
+	delete(m.webhooks, id)
+
+	for key, delivery := range m.deliveries {
+		if delivery.Webhook == id {
+			delete(m.deliveries, key)
+		}
+	}
+
+	for key, delivery := range m.deadletters {
+		if delivery.Webhook == id {
+			delete(m.deadletters, key)
+		}
+	}
+
+	return nil
+}
+
+// EnqueueDeliveries adds the deliveries to the queue, assigning them an identifier.
+// Deliveries without a due time are due right away.
+func (m *Memory) EnqueueDeliveries(deliveries []Delivery) error {
+	now := time.Now().UTC()
+
+	for idx := range deliveries {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return fmt.Errorf("error generating delivery id: %w", err)
+		}
+
+		deliveries[idx].ID = id.String()
+		deliveries[idx].Created = now
+		if deliveries[idx].NextAttempt.IsZero() {
+			deliveries[idx].NextAttempt = now
+		}
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, delivery := range deliveries {
+		delivery := delivery
+		m.deliveries[delivery.ID] = &delivery
+	}
+
+	return nil
+}
+
+// ClaimDeliveries returns up to limit deliveries that are due, oldest first.
+// Claimed deliveries are not due again until the lease expires, so a delivery is not
+// attempted twice at once; if the attempt doesn't complete, reschedule or dead letter
+// the delivery by then, it's retried.
+func (m *Memory) ClaimDeliveries(now time.Time, lease time.Duration, limit int) []Delivery {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var due []*Delivery
+	for _, delivery := range m.deliveries {
+		if !delivery.NextAttempt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttempt.Before(due[j].NextAttempt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	result := make([]Delivery, 0, len(due))
+	for _, delivery := range due {
+		delivery.NextAttempt = now.Add(lease)
+		result = append(result, *delivery)
+	}
+
+	return result
+}
+
+// CompleteDelivery removes a delivery that succeeded from the queue.
+func (m *Memory) CompleteDelivery(id string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.deliveries, id)
+}
+
+// RescheduleDelivery records a failed attempt of the delivery, and makes it due
+// again at the specified time.
+func (m *Memory) RescheduleDelivery(id string, reason string, at time.Time) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if delivery, found := m.deliveries[id]; found {
+		delivery.Attempts++
+		delivery.LastError = reason
+		delivery.NextAttempt = at
+	}
+}
+
+// DeadLetterDelivery records the last failed attempt of the delivery, and moves it
+// from the queue to the dead letters.
+func (m *Memory) DeadLetterDelivery(id string, reason string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delivery, found := m.deliveries[id]
+	if !found {
+		return
+	}
+
+	delivery.Attempts++
+	delivery.LastError = reason
+	delivery.NextAttempt = time.Time{}
+
+	delete(m.deliveries, id)
+	m.deadletters[id] = delivery
+}
+
+// DeadLetters returns the deliveries that ran out of attempts, oldest first,
+// optionally only the ones of the specified webhook.
+func (m *Memory) DeadLetters(webhook string) []Delivery {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	result := make([]Delivery, 0, len(m.deadletters))
+	for _, delivery := range m.deadletters {
+		if webhook == "" || delivery.Webhook == webhook {
+			result = append(result, *delivery)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Created.Before(result[j].Created) })
+
+	return result
+}
+
+// RequeueDeadLetter moves a dead letter back to the queue, due right away and with
+// its attempts reset.
+func (m *Memory) RequeueDeadLetter(id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delivery, found := m.deadletters[id]
+	if !found {
+		return notfound("no dead letter with id %s found", id)
+	}
+
+	delivery.Attempts = 0
+	delivery.NextAttempt = time.Now().UTC()
+
+	delete(m.deadletters, id)
+	m.deliveries[id] = delivery
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDeliveryQueue(t *testing.T) {
+	store, err := NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	hook, err := store.CreateWebhook(Webhook{URL: "http://localhost/hook", Secret: "secret"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, hook.ID, "the webhook should get an id")
+
+	err = store.EnqueueDeliveries([]Delivery{{Webhook: hook.ID, Event: "link.created"}, {Webhook: hook.ID, Event: "link.deleted"}})
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	claimed := store.ClaimDeliveries(now, time.Minute, 1)
+	require.Len(t, claimed, 1, "the limit should be honored")
+	claimed = append(claimed, store.ClaimDeliveries(now, time.Minute, 10)...)
+	require.Len(t, claimed, 2, "the rest of due deliveries should be claimed")
+	assert.Empty(t, store.ClaimDeliveries(now, time.Minute, 10), "claimed deliveries shouldn't be due until the lease expires")
+
+	store.CompleteDelivery(claimed[0].ID)
+	store.RescheduleDelivery(claimed[1].ID, "timeout", now.Add(time.Second))
+
+	assert.Empty(t, store.ClaimDeliveries(now, time.Minute, 10), "rescheduled deliveries shouldn't be due before their time")
+
+	retried := store.ClaimDeliveries(now.Add(time.Second), time.Minute, 10)
+	require.Len(t, retried, 1, "rescheduled deliveries should be due at their time")
+	assert.Equal(t, 1, retried[0].Attempts)
+	assert.Equal(t, "timeout", retried[0].LastError)
+
+	store.DeadLetterDelivery(retried[0].ID, "gone")
+	assert.Empty(t, store.ClaimDeliveries(now.Add(time.Hour), time.Minute, 10), "dead letters shouldn't be due")
+
+	dead := store.DeadLetters(hook.ID)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 2, dead[0].Attempts)
+	assert.Equal(t, "gone", dead[0].LastError)
+
+	require.NoError(t, store.RequeueDeadLetter(dead[0].ID))
+	assert.Empty(t, store.DeadLetters(""), "requeued dead letters should leave the dead letters")
+	requeued := store.ClaimDeliveries(time.Now(), time.Minute, 10)
+	require.Len(t, requeued, 1, "requeued dead letters should be due right away")
+	assert.Equal(t, 0, requeued[0].Attempts, "the attempts of requeued dead letters should be reset")
+
+	require.NoError(t, store.DeleteWebhook(hook.ID))
+	assert.Empty(t, store.GetWebhooks())
+	assert.Empty(t, store.ClaimDeliveries(now.Add(time.Hour), time.Minute, 10), "deliveries of deleted webhooks should be dropped")
+	assert.Error(t, store.DeleteWebhook(hook.ID), "deleting missing webhooks should fail")
+}
@@ -0,0 +1,120 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aexvir/lnk/internal/idempotency"
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+// maxbatchsize is the most items a batch can have, so a single call can't hold the
+// store for too long.
+const maxbatchsize = 10000
+
+func (lgs *LinksService) BatchCreateLinks(ctx context.Context, req *proto.BatchCreateLinksReq) (*proto.BatchCreateLinksResp, error) {
+	lgs.log.Write("BatchCreateLinks", "links: %d, transactional: %t", len(req.Links), req.Transactional)
+
+	if len(req.Links) > maxbatchsize {
+		return nil, invalid("batches can't have more than %d links", maxbatchsize)
+	}
+
+	resp, err := remember(ctx, lgs.idempotency, req, func() (*proto.BatchCreateLinksResp, error) { return lgs.batchcreate(req), nil })
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return nil, fmt.Errorf("error creating links: %w", err)
+	}
+
+	return resp, err
+}
+
+// batchcreate creates the links of the batch that are valid, or none of them if the
+// batch is transactional and any is invalid.
+func (lgs *LinksService) batchcreate(req *proto.BatchCreateLinksReq) *proto.BatchCreateLinksResp {
+	results := make([]storage.BatchResult, len(req.Links))
+	links := make([]storage.NewLink, 0, len(req.Links))
+	positions := make([]int, 0, len(req.Links)) // position of each valid link on the batch
+
+	for i, link := range req.Links {
+		opts, err := linkoptions(link)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		links = append(links, storage.NewLink{Target: link.Target, Slug: link.Slug, Opts: opts})
+		positions = append(positions, i)
+	}
+
+	if req.Transactional && len(links) < len(req.Links) {
+		for _, i := range positions {
+			results[i].Err = storage.ErrBatchAborted
+		}
+	} else {
+		for j, result := range lgs.store.BatchCreateLinks(links, req.Transactional) {
+			results[positions[j]] = result
+		}
+	}
+
+	var resp proto.BatchCreateLinksResp
+	resp.Results, resp.Succeeded, resp.Failed = batchresults(results)
+
+	// links that failed only have a slug if it was a custom one
+	for i, result := range resp.Results {
+		if result.Error != "" {
+			result.Slug = req.Links[i].GetSlug()
+		}
+	}
+
+	return &resp
+}
+
+func (lgs *LinksService) BatchDeleteLinks(ctx context.Context, req *proto.BatchDeleteLinksReq) (*proto.BatchDeleteLinksResp, error) {
+	lgs.log.Write("BatchDeleteLinks", "slugs: %d, transactional: %t", len(req.Slugs), req.Transactional)
+
+	if len(req.Slugs) > maxbatchsize {
+		return nil, invalid("batches can't have more than %d slugs", maxbatchsize)
+	}
+
+	resp, err := remember(
+		ctx, lgs.idempotency, req,
+		func() (*proto.BatchDeleteLinksResp, error) {
+			var resp proto.BatchDeleteLinksResp
+			resp.Results, resp.Succeeded, resp.Failed = batchresults(lgs.store.BatchDeleteLinks(req.Slugs, req.Transactional))
+			return &resp, nil
+		},
+	)
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return nil, fmt.Errorf("error deleting links: %w", err)
+	}
+
+	return resp, err
+}
+
+// batchresults translates the results of a batch, counting how many items succeeded
+// and failed.
+func batchresults(results []storage.BatchResult) ([]*proto.BatchResult, uint32, uint32) {
+	translated := make([]*proto.BatchResult, 0, len(results))
+	var succeeded, failed uint32
+
+	for _, result := range results {
+		if result.Err == nil {
+			succeeded++
+			translated = append(translated, &proto.BatchResult{Slug: result.Slug})
+			continue
+		}
+
+		failed++
+		translated = append(
+			translated,
+			&proto.BatchResult{
+				Slug:    result.Slug,
+				Error:   result.Err.Error(),
+				Aborted: errors.Is(result.Err, storage.ErrBatchAborted),
+			},
+		)
+	}
+
+	return translated, succeeded, failed
+}
@@ -0,0 +1,39 @@
+package svc
+
+import (
+	"net/http"
+	"strings"
+)
+
+// prefetchheaders are the headers browsers and proxies use to flag speculative
+// requests, along with the values that mark them as such.
+var prefetchheaders = map[string][]string{
+	"Purpose":     {"prefetch", "preview"},
+	"Sec-Purpose": {"prefetch", "prerender"},
+	"X-Purpose":   {"prefetch", "preview"},
+	"X-Moz":       {"prefetch"},
+}
+
+// automated reports whether the request wasn't made by a person following the link,
+// either because it comes from a known bot, it only asks for the headers, or it's
+// the browser prefetching the link in advance.
+func automated(r *http.Request, bot bool) bool {
+	return bot || r.Method == http.MethodHead || prefetch(r)
+}
+
+func prefetch(r *http.Request) bool {
+	for header, markers := range prefetchheaders {
+		value := strings.ToLower(r.Header.Get(header))
+		if value == "" {
+			continue
+		}
+
+		for _, marker := range markers {
+			if strings.Contains(value, marker) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
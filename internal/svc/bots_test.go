@@ -0,0 +1,91 @@
+package svc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func TestAutomated(t *testing.T) {
+	tests := map[string]struct {
+		method  string
+		headers map[string]string
+		bot     bool
+
+		want bool
+	}{
+		"person": {
+			method: http.MethodGet,
+		},
+		"known bot": {
+			method: http.MethodGet,
+			bot:    true,
+			want:   true,
+		},
+		"head request": {
+			method: http.MethodHead,
+			want:   true,
+		},
+		"chrome prefetch": {
+			method:  http.MethodGet,
+			headers: map[string]string{"Sec-Purpose": "prefetch;prerender"},
+			want:    true,
+		},
+		"safari preview": {
+			method:  http.MethodGet,
+			headers: map[string]string{"Purpose": "Preview"},
+			want:    true,
+		},
+		"firefox prefetch": {
+			method:  http.MethodGet,
+			headers: map[string]string{"X-Moz": "prefetch"},
+			want:    true,
+		},
+		"unrelated purpose": {
+			method:  http.MethodGet,
+			headers: map[string]string{"Purpose": "navigate", "X-Moz": "preview"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := request(test.method, "/abc")
+			for header, value := range test.headers {
+				r.Header.Set(header, value)
+			}
+
+			assert.Equal(t, test.want, automated(r, test.bot))
+		})
+	}
+}
+
+func TestBotRedirect(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	slug, err := store.CreateLink("https://example.com", ptr("abc"))
+	require.NoError(t, err)
+
+	handler, err := LinkRedirectHandler(store)
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	crawler := request(http.MethodGet, "/"+slug)
+	crawler.Header.Set("User-Agent", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)")
+
+	prefetch := request(http.MethodGet, "/"+slug)
+	prefetch.Header.Set("Sec-Purpose", "prefetch")
+
+	for _, r := range []*http.Request{crawler, prefetch, request(http.MethodHead, "/"+slug), request(http.MethodGet, "/"+slug)} {
+		resp := record(handler, r)
+		assert.Equal(t, http.StatusTemporaryRedirect, resp.Code, "bots should be redirected too")
+	}
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, link.Hits, "only the visit of the person should be a hit")
+	assert.EqualValues(t, 3, link.BotHits, "automated visits should be counted apart")
+}
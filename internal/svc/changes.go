@@ -0,0 +1,48 @@
+package svc
+
+import (
+	"fmt"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/internal/translation"
+	"github.com/aexvir/lnk/proto"
+)
+
+func (lgs *LinksService) WatchLinks(req *proto.WatchLinksReq, stream proto.Links_WatchLinksServer) error {
+	lgs.log.Write("WatchLinks", req.String())
+
+	sequence := lgs.store.LastSequence()
+	if req.AfterSequence != nil {
+		sequence = *req.AfterSequence
+	}
+
+	for {
+		changes, notify, err := lgs.store.ChangesSince(sequence)
+		if err != nil {
+			return fmt.Errorf("error getting link changes: %w", err)
+		}
+
+		for _, change := range changes {
+			sequence = change.Sequence
+			if change.Kind == storage.ChangeMilestone && !req.Milestones {
+				continue
+			}
+
+			if err := stream.Send(translation.ChangeToProto(change)); err != nil {
+				return err
+			}
+		}
+
+		if len(changes) > 0 {
+			continue
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-lgs.done:
+			return nil
+		case <-notify:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package svc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+// changestream is the server side of a WatchLinks stream, which keeps the changes
+// sent on it and ends once it gets the change of the last slug.
+type changestream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	last   string
+
+	changes []*proto.LinkChange
+}
+
+func (cs *changestream) Context() context.Context {
+	return cs.ctx
+}
+
+func (cs *changestream) Send(change *proto.LinkChange) error {
+	cs.changes = append(cs.changes, change)
+	if change.Slug == cs.last {
+		cs.cancel()
+	}
+	return nil
+}
+
+func TestWatchLinksMilestones(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	_, err = store.CreateLink("https://example.com", ptr("popular"))
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		store.RegisterHit(storage.Hit{Slug: "popular"})
+	}
+	_, err = store.CreateLink("https://example.com", ptr("last"))
+	require.NoError(t, err)
+
+	lgs := NewLinksService(store)
+
+	tests := map[string]struct {
+		milestones bool
+		want       []string
+	}{
+		"without milestones": {
+			want: []string{"created", "created"},
+		},
+		"with milestones": {
+			milestones: true,
+			want:       []string{"created", "milestone", "created"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stream := &changestream{ctx: ctx, cancel: cancel, last: "last"}
+
+			after := uint64(0)
+			err := lgs.WatchLinks(&proto.WatchLinksReq{AfterSequence: &after, Milestones: test.milestones}, stream)
+			require.NoError(t, err)
+
+			var kinds []string
+			for _, change := range stream.changes {
+				kinds = append(kinds, change.Kind)
+			}
+			assert.Equal(t, test.want, kinds)
+			assert.EqualValues(t, 3, stream.changes[len(stream.changes)-1].Sequence, "skipped changes should keep their sequence")
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/aexvir/lnk/internal/idempotency"
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// UnaryErrors is a grpc interceptor that reports the errors caused by missing
+// resources with the NotFound code, which the gateway maps to a 404 response, the
+// ones of changes no longer available with OutOfRange, the ones of invalid requests
+// with InvalidArgument, and reused idempotency keys with FailedPrecondition.
+// The rest of errors are left as they are.
+func UnaryErrors(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	return resp, statuserror(err)
+}
+
+// StreamErrors is the streaming counterpart of UnaryErrors.
+func StreamErrors(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return statuserror(handler(srv, stream))
+}
+
+func statuserror(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrChangesExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, idempotency.ErrKeyReused):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, storage.ErrInvalidSnapshot), errors.As(err, new(*invaliderror)):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return err
+}
+
+// invaliderror is the error of requests that are invalid, whatever the state of
+// the store.
+type invaliderror struct {
+	err error
+}
+
+// invalid returns an error formatted like fmt.Errorf, reported as caused by an
+// invalid request.
+func invalid(format string, args ...any) error {
+	return &invaliderror{err: fmt.Errorf(format, args...)}
+}
+
+func (ie *invaliderror) Error() string {
+	return ie.err.Error()
+}
+
+func (ie *invaliderror) Unwrap() error {
+	return ie.err
+}
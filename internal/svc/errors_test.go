@@ -0,0 +1,76 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/aexvir/lnk/internal/idempotency"
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+func TestStatusError(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+	lgs := NewLinksService(store)
+	_, slugerr := lgs.CreateLink(context.Background(), &proto.CreateLinkReq{Target: "https://example.com", Slug: ptr("a/b")})
+	_, hookerr := lgs.CreateWebhook(context.Background(), &proto.CreateWebhookReq{Url: "/relative"})
+	_, policyerr := conflictpolicy("merge")
+
+	tests := map[string]struct {
+		err error
+
+		want codes.Code
+	}{
+		"missing link": {
+			err:  fmt.Errorf("error getting link: %w", storage.ErrNotFound),
+			want: codes.NotFound,
+		},
+		"expired changes": {
+			err:  fmt.Errorf("error getting link changes: %w", storage.ErrChangesExpired),
+			want: codes.OutOfRange,
+		},
+		"reused idempotency key": {
+			err:  fmt.Errorf("error creating link: %w", idempotency.ErrKeyReused),
+			want: codes.FailedPrecondition,
+		},
+		"invalid link": {
+			err:  slugerr,
+			want: codes.InvalidArgument,
+		},
+		"invalid webhook": {
+			err:  hookerr,
+			want: codes.InvalidArgument,
+		},
+		"invalid conflict policy": {
+			err:  policyerr,
+			want: codes.InvalidArgument,
+		},
+		"invalid snapshot": {
+			err:  fmt.Errorf("error restoring snapshot: %w", storage.ErrInvalidSnapshot),
+			want: codes.InvalidArgument,
+		},
+		"anything else": {
+			err:  errors.New("error taking snapshot"),
+			want: codes.Unknown,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := statuserror(test.err)
+
+			assert.Equal(t, test.want, status.Code(err))
+			assert.Equal(t, test.err.Error(), status.Convert(err).Message(), "the message should be kept")
+		})
+	}
+
+	assert.NoError(t, statuserror(nil))
+}
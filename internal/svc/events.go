@@ -0,0 +1,101 @@
+package svc
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/aexvir/lnk/internal/events"
+	"github.com/aexvir/lnk/internal/translation"
+	"github.com/aexvir/lnk/proto"
+)
+
+// ssekeepalive is how often a comment is sent on idle event streams, so proxies
+// don't consider the connection dead.
+const ssekeepalive = 15 * time.Second
+
+func (lgs *LinksService) WatchHits(req *proto.WatchHitsReq, stream proto.Links_WatchHitsServer) error {
+	lgs.log.Write("WatchHits", req.String())
+
+	if lgs.events == nil {
+		return fmt.Errorf("hit events are not enabled")
+	}
+
+	sub := lgs.events.Subscribe(req.Slugs...)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case hit, open := <-sub.Events():
+			if !open {
+				return nil
+			}
+
+			if err := stream.Send(translation.HitToProto(hit, sub.Dropped())); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// HitEventsHandler streams the visits to the links as server-sent events, as they
+// happen. Each visit is sent as a `hit` event with the json encoded HitEvent as data.
+// The links can be filtered by passing their slugs on `slug` query parameters.
+func HitEventsHandler(broker *events.Broker) http.HandlerFunc {
+	marshaler := protojson.MarshalOptions{EmitUnpopulated: true}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			respond(w, http.StatusMethodNotAllowed, "only get requests allowed")
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			respond(w, http.StatusInternalServerError, "streaming not supported")
+			return
+		}
+
+		sub := broker.Subscribe(r.URL.Query()["slug"]...)
+		defer sub.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		keepalive := time.NewTicker(ssekeepalive)
+		defer keepalive.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-keepalive.C:
+				if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+					return
+				}
+			case hit, open := <-sub.Events():
+				if !open {
+					return
+				}
+
+				data, err := marshaler.Marshal(translation.HitToProto(hit, sub.Dropped()))
+				if err != nil {
+					return
+				}
+
+				if _, err := fmt.Fprintf(w, "event: hit\ndata: %s\n\n", data); err != nil {
+					return
+				}
+			}
+
+			flusher.Flush()
+		}
+	}
+}
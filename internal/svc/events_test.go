@@ -0,0 +1,148 @@
+package svc
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/aexvir/lnk/internal/events"
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+// hitstream is the server side of a WatchHits stream, which hands over the events
+// sent on it.
+type hitstream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *proto.HitEvent
+}
+
+func (hs *hitstream) Context() context.Context {
+	return hs.ctx
+}
+
+func (hs *hitstream) Send(event *proto.HitEvent) error {
+	hs.events <- event
+	return nil
+}
+
+func TestWatchHits(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	_, err = store.CreateLink("https://example.com", ptr("abc"))
+	require.NoError(t, err)
+	_, err = store.CreateLink("https://example.org", ptr("xyz"))
+	require.NoError(t, err)
+
+	broker, err := events.NewBroker()
+	require.NoError(t, err, "shouldn't fail initing the broker")
+
+	lgs := NewLinksService(store, WithEventBroker(broker))
+	handler, err := LinkRedirectHandler(store, WithHitPublisher(broker), WithCountryHeader("CF-IPCountry"))
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &hitstream{ctx: ctx, events: make(chan *proto.HitEvent, 10)}
+
+	done := make(chan error, 1)
+	go func() { done <- lgs.WatchHits(&proto.WatchHitsReq{Slugs: []string{"abc"}}, stream) }()
+	waitsubscribers(t, broker, 1)
+
+	record(handler, request(http.MethodGet, "/xyz"))
+	visit := request(http.MethodGet, "/abc")
+	visit.Header.Set("CF-IPCountry", "es")
+	record(handler, visit)
+
+	select {
+	case event := <-stream.events:
+		assert.Equal(t, "abc", event.Slug, "only the visits of the watched links should be sent")
+		assert.Equal(t, "https://example.com", event.Target)
+		assert.Equal(t, "ES", event.Country)
+		assert.Equal(t, "Firefox", event.Browser)
+		assert.False(t, event.Bot)
+	case <-time.After(time.Second):
+		t.Fatal("the visit should have been streamed")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "the stream should end when the client leaves")
+	case <-time.After(time.Second):
+		t.Fatal("the stream should have ended")
+	}
+	assert.Empty(t, stream.events)
+	assert.Equal(t, 0, broker.Subscribers(), "the subscription should be closed")
+
+	disabled := NewLinksService(store)
+	err = disabled.WatchHits(&proto.WatchHitsReq{}, stream)
+	assert.ErrorContains(t, err, "hit events are not enabled")
+}
+
+func TestHitEventsHandler(t *testing.T) {
+	broker, err := events.NewBroker()
+	require.NoError(t, err, "shouldn't fail initing the broker")
+
+	server := httptest.NewServer(HitEventsHandler(broker))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL, "text/plain", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "?slug=abc&slug=def")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	waitsubscribers(t, broker, 1)
+
+	when := time.Date(2022, 6, 11, 12, 0, 0, 0, time.UTC)
+	broker.Publish(events.Hit{Hit: storage.Hit{Slug: "xyz", Time: when}, Target: "https://example.org"})
+	broker.Publish(events.Hit{Hit: storage.Hit{Slug: "def", Time: when, Bot: true}, Target: "https://example.com"})
+	broker.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, lines, 3, "only the hit of the watched link should be sent, and the stream should end with the broker")
+	assert.Equal(t, "event: hit", lines[0])
+	assert.Equal(t, "", lines[2], "events should be separated by an empty line")
+
+	var event proto.HitEvent
+	require.NoError(t, protojson.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &event))
+	assert.Equal(t, "def", event.Slug)
+	assert.Equal(t, "https://example.com", event.Target)
+	assert.True(t, event.Bot)
+	assert.Equal(t, when, event.Time.AsTime())
+}
+
+// waitsubscribers waits until the broker has the amount of subscribers, as streams
+// subscribe asynchronously.
+func waitsubscribers(t *testing.T, broker *events.Broker, count int) {
+	t.Helper()
+
+	require.Eventually(
+		t,
+		func() bool { return broker.Subscribers() == count },
+		time.Second, 5*time.Millisecond,
+		"the stream should have subscribed",
+	)
+}
@@ -0,0 +1,77 @@
+package svc
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+
+	"github.com/aexvir/lnk/internal/export"
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/internal/translation"
+	"github.com/aexvir/lnk/proto"
+)
+
+func (lgs *LinksService) ExportStats(req *proto.ExportStatsReq, stream proto.Links_ExportStatsServer) error {
+	lgs.log.Write("ExportStats", req.String())
+
+	format, err := export.ParseFormat(req.Format)
+	if err != nil {
+		return err
+	}
+
+	query, err := translation.ProtoToStatsQuery(req.From, req.To, req.Granularity, req.TimeZone, req.FillGaps)
+	if err != nil {
+		return invalid("invalid stats query: %w", err)
+	}
+
+	// check that every requested link exists before starting to stream, as errors
+	// can't be reported properly once the export is halfway through; each link is
+	// exported once, however many times it's requested
+	slugs := make([]string, 0, len(req.Slugs))
+	requested := make(map[string]bool, len(req.Slugs))
+	for _, slug := range req.Slugs {
+		if requested[slug] {
+			continue
+		}
+		requested[slug] = true
+
+		if _, err := lgs.store.GetRoute(slug); err != nil {
+			return fmt.Errorf("error getting link: %w", err)
+		}
+		slugs = append(slugs, slug)
+	}
+
+	encoder := export.NewEncoder(format, query)
+	emit := func(line []byte) error {
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+
+		return stream.Send(&httpbody.HttpBody{ContentType: format.ContentType(), Data: line})
+	}
+
+	if err := encoder.Header(emit); err != nil {
+		return fmt.Errorf("error exporting stats: %w", err)
+	}
+
+	encode := func(link *storage.Link) error {
+		return encoder.Encode(link, emit)
+	}
+
+	if len(slugs) == 0 {
+		return lgs.store.WalkLinks(encode)
+	}
+
+	for _, slug := range slugs {
+		link, err := lgs.store.GetLink(slug)
+		if err != nil {
+			continue // deleted since it was checked
+		}
+
+		if err := encode(link); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
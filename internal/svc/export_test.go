@@ -0,0 +1,95 @@
+package svc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+// exportstream is the server side of an ExportStats stream, which keeps the lines
+// sent on it.
+type exportstream struct {
+	grpc.ServerStream
+	lines []string
+}
+
+func (es *exportstream) Context() context.Context {
+	return context.Background()
+}
+
+func (es *exportstream) Send(body *httpbody.HttpBody) error {
+	es.lines = append(es.lines, string(body.Data))
+	return nil
+}
+
+func TestExportStats(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	for _, slug := range []string{"abc", "xyz", "other"} {
+		_, err = store.CreateLink("https://example.com/"+slug, ptr(slug))
+		require.NoError(t, err)
+	}
+
+	lgs := NewLinksService(store)
+
+	tests := map[string]struct {
+		slugs []string
+
+		want    []string
+		wantErr string
+	}{
+		"every link": {
+			want: []string{"abc", "other", "xyz"},
+		},
+		"some links": {
+			slugs: []string{"xyz", "abc"},
+			want:  []string{"xyz", "abc"},
+		},
+		"repeated links": {
+			slugs: []string{"abc", "xyz", "abc"},
+			want:  []string{"abc", "xyz"},
+		},
+		"missing link": {
+			slugs:   []string{"abc", "missing"},
+			wantErr: "no link with slug missing found",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			stream := &exportstream{}
+			err := lgs.ExportStats(&proto.ExportStatsReq{Slugs: test.slugs, Format: "ndjson"}, stream)
+
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				assert.Empty(t, stream.lines, "nothing should be streamed if a link is missing")
+				return
+			}
+			require.NoError(t, err)
+
+			var slugs []string
+			for _, line := range stream.lines {
+				var link struct {
+					Slug string `json:"slug"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(line), &link))
+				slugs = append(slugs, link.Slug)
+			}
+
+			if test.slugs == nil {
+				assert.ElementsMatch(t, test.want, slugs)
+				return
+			}
+			assert.Equal(t, test.want, slugs, "links should be exported once, in the requested order")
+		})
+	}
+}
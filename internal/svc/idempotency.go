@@ -0,0 +1,76 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/aexvir/lnk/internal/idempotency"
+)
+
+// IdempotencyMetadata is the metadata the idempotency keys can be sent on, instead
+// of the request field; the gateway maps the Idempotency-Key header to it.
+const IdempotencyMetadata = "idempotency-key"
+
+// idempotencyfield is the name of the field of the requests that carry a key.
+const idempotencyfield = "idempotency_key"
+
+// remember runs fn only for the first call made with the idempotency key of the
+// request, returning the same result to its retries.
+// Calls without a key always run fn.
+func remember[T protobuf.Message](ctx context.Context, cache *idempotency.Cache[protobuf.Message], req protobuf.Message, fn func() (T, error)) (T, error) {
+	key := idempotencykey(ctx, req)
+	if key == "" {
+		return fn()
+	}
+
+	var zero T
+	fp, err := fingerprint(req)
+	if err != nil {
+		return zero, err
+	}
+
+	result, err := cache.Do(ctx, key, fp, func() (protobuf.Message, error) { return fn() })
+	if err != nil {
+		return zero, err
+	}
+
+	return result.(T), nil
+}
+
+// idempotencykey returns the key of the request, taken from its field or from the
+// metadata of the call.
+func idempotencykey(ctx context.Context, req protobuf.Message) string {
+	msg := req.ProtoReflect()
+	if field := msg.Descriptor().Fields().ByName(idempotencyfield); field != nil {
+		if key := msg.Get(field).String(); key != "" {
+			return key
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(IdempotencyMetadata); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// fingerprint identifies the contents of the request, regardless of its key.
+// The kind of request is part of it, so a key can't be reused for other calls.
+func fingerprint(req protobuf.Message) ([]byte, error) {
+	stripped := protobuf.Clone(req)
+	msg := stripped.ProtoReflect()
+	if field := msg.Descriptor().Fields().ByName(idempotencyfield); field != nil {
+		msg.Clear(field)
+	}
+
+	encoded, err := protobuf.MarshalOptions{Deterministic: true}.Marshal(stripped)
+	if err != nil {
+		return nil, fmt.Errorf("error fingerprinting request: %w", err)
+	}
+
+	return append([]byte(msg.Descriptor().FullName()+"\n"), encoded...), nil
+}
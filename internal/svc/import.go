@@ -0,0 +1,125 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aexvir/lnk/internal/importer"
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+// maximportsize is the largest file that can be imported.
+const maximportsize = 32 << 20
+
+// importfailed is the outcome of the links that couldn't be imported.
+const importfailed = "failed"
+
+func (lgs *LinksService) ImportLinks(ctx context.Context, req *proto.ImportLinksReq) (*proto.ImportLinksResp, error) {
+	data := req.GetData().GetData()
+	lgs.log.Write("ImportLinks", "format: %s, bytes: %d, conflicts: %s, dry run: %t", req.Format, len(data), req.Conflicts, req.DryRun)
+
+	if len(data) == 0 {
+		return nil, invalid("the file to import is empty")
+	}
+	if len(data) > maximportsize {
+		return nil, invalid("files can't be larger than %d bytes", maximportsize)
+	}
+
+	format, err := importer.ParseFormat(req.Format, data)
+	if err != nil {
+		return nil, invalid("%w", err)
+	}
+
+	mapping, err := importer.ParseMapping(req.Columns)
+	if err != nil {
+		return nil, invalid("%w", err)
+	}
+
+	policy, err := conflictpolicy(req.Conflicts)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := importer.Read(data, format, mapping)
+	if err != nil {
+		return nil, invalid("error reading the file: %w", err)
+	}
+
+	results := make([]*proto.ImportResult, len(records))
+	links := make([]*storage.Link, 0, len(records))
+	positions := make([]int, 0, len(records)) // position of each valid link on the records
+
+	for i, rec := range records {
+		results[i] = &proto.ImportResult{Line: uint32(rec.Line), Target: rec.Target}
+
+		if rec.Err == nil {
+			if err := validaterules(rec.Link.Rules); err != nil {
+				rec.Err = fmt.Errorf("invalid redirect rules: %w", err)
+			} else if err := validatesplit(rec.Link.Split); err != nil {
+				rec.Err = fmt.Errorf("invalid split: %w", err)
+			}
+		}
+
+		if rec.Err != nil {
+			if rec.Link != nil {
+				results[i].Slug = rec.Link.Slug
+			}
+			results[i].Outcome = importfailed
+			results[i].Error = rec.Err.Error()
+			continue
+		}
+
+		links = append(links, rec.Link)
+		positions = append(positions, i)
+	}
+
+	for j, stored := range lgs.store.ImportLinks(links, policy, req.DryRun) {
+		result := results[positions[j]]
+		result.Slug = stored.Slug
+
+		if stored.Err != nil {
+			result.Slug = links[j].Slug
+			result.Outcome = importfailed
+			result.Error = stored.Err.Error()
+			continue
+		}
+
+		result.Outcome = string(stored.Outcome)
+		if stored.Outcome == storage.ImportRenamed {
+			result.OriginalSlug = links[j].Slug
+		}
+	}
+
+	resp := proto.ImportLinksResp{Results: results, DryRun: req.DryRun}
+	for _, result := range results {
+		switch result.Outcome {
+		case string(storage.ImportCreated):
+			resp.Created++
+		case string(storage.ImportOverwritten):
+			resp.Overwritten++
+		case string(storage.ImportRenamed):
+			resp.Renamed++
+		case string(storage.ImportSkipped):
+			resp.Skipped++
+		default:
+			resp.Failed++
+		}
+	}
+
+	return &resp, nil
+}
+
+// conflictpolicy parses the conflict policy of an import, skipping conflicts by default.
+func conflictpolicy(value string) (storage.ConflictPolicy, error) {
+	switch value {
+	case "", "skip":
+		return storage.ConflictSkip, nil
+	case "overwrite":
+		return storage.ConflictOverwrite, nil
+	case "rename":
+		return storage.ConflictRename, nil
+	default:
+		return 0, invalid("unknown conflict policy %q, it must be skip, overwrite or rename", value)
+	}
+}
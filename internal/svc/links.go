@@ -2,12 +2,17 @@ package svc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"path"
+	"strings"
+	"time"
 
+	protobuf "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/aexvir/lnk/internal/events"
+	"github.com/aexvir/lnk/internal/idempotency"
 	"github.com/aexvir/lnk/internal/logging"
 	"github.com/aexvir/lnk/internal/storage"
 	"github.com/aexvir/lnk/internal/translation"
@@ -15,27 +20,94 @@ import (
 )
 
 type LinkStore interface {
-	CreateLink(target string, slug *string) (string, error)
+	CreateLink(target string, slug *string, opts ...storage.LinkOption) (string, error)
 	GetLink(slug string) (*storage.Link, error)
+	GetRoute(slug string) (*storage.Link, error)
 	DeleteLink(slug string) error
+	BatchCreateLinks(links []storage.NewLink, atomic bool) []storage.BatchResult
+	BatchDeleteLinks(slugs []string, atomic bool) []storage.BatchResult
+	ImportLinks(links []*storage.Link, policy storage.ConflictPolicy, dryrun bool) []storage.ImportResult
 	AllLinks() []*storage.Link
+	WalkLinks(fn func(link *storage.Link) error) error
+	Overview(query storage.StatsQuery, limit int) storage.Overview
+	LastSequence() uint64
+	ChangesSince(sequence uint64) ([]storage.Change, <-chan struct{}, error)
+
+	CreateWebhook(hook storage.Webhook) (storage.Webhook, error)
+	GetWebhooks() []storage.Webhook
+	DeleteWebhook(id string) error
+	DeadLetters(webhook string) []storage.Delivery
+	RequeueDeadLetter(id string) error
 
 	GetTarget(slug string) (string, error)
-	RegisterHit(slug string)
+	RegisterHit(hit storage.Hit)
+	BatchRegisterHits(hits []storage.Hit)
 }
 
 type LinksService struct {
 	proto.UnimplementedLinksServer
 
-	store LinkStore
-	log   *logging.Logger
+	store       LinkStore
+	events      *events.Broker
+	idempotency *idempotency.Cache[protobuf.Message]
+	baseurl     string
+	adminkey    []byte
+	log         *logging.Logger
+
+	// done is closed when the service is shutting down, to end the streams
+	done chan struct{}
 }
 
-func NewLinksService(store LinkStore) LinksService {
+// NewLinksService instantiates the service that manages links on the store.
+// Customize it via ServiceOptions.
+func NewLinksService(store LinkStore, opts ...ServiceOption) LinksService {
 	log := logging.NewLogger("lnk.links")
-	return LinksService{
-		store: store,
-		log:   log,
+	// the default settings are always valid
+	keys, _ := idempotency.NewCache[protobuf.Message]()
+	lgs := LinksService{
+		store:       store,
+		idempotency: keys,
+		baseurl:     "http://localhost:8000",
+		log:         log,
+		done:        make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(&lgs)
+	}
+
+	return lgs
+}
+
+// Close ends the streams that wait for changes, so the server can shut down
+// gracefully. It must only be called once.
+func (lgs *LinksService) Close() {
+	close(lgs.done)
+}
+
+type ServiceOption func(lgs *LinksService)
+
+// WithBaseUrl sets the public url the service is reachable at, used for building
+// the full url of the shortened links.
+func WithBaseUrl(url string) ServiceOption {
+	return func(lgs *LinksService) {
+		lgs.baseurl = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithEventBroker sets the broker the visits to the links are watched from.
+// Watching visits is not possible without it.
+func WithEventBroker(broker *events.Broker) ServiceOption {
+	return func(lgs *LinksService) {
+		lgs.events = broker
+	}
+}
+
+// WithIdempotencyCache sets the cache the results of the calls made with an
+// idempotency key are remembered on, for customizing how long they're kept.
+func WithIdempotencyCache(cache *idempotency.Cache[protobuf.Message]) ServiceOption {
+	return func(lgs *LinksService) {
+		lgs.idempotency = cache
 	}
 }
 
@@ -45,16 +117,32 @@ func (lgs *LinksService) ListLinks(ctx context.Context, _ *emptypb.Empty) (*prot
 
 	var list proto.LinkList
 	for _, link := range links {
-		list.Links = append(list.Links, translation.DbLinkToProto(link))
+		list.Links = append(list.Links, translation.DbLinkToProto(link, storage.DefaultStatsQuery()))
 	}
 
 	return &list, nil
 }
 
 func (lgs *LinksService) CreateLink(ctx context.Context, req *proto.CreateLinkReq) (*proto.LinkId, error) {
-	lgs.log.Write("CreateLink", req.String())
+	// the request isn't logged as it is, since it may have the password of the link
+	lgs.log.Write("CreateLink", "slug: %s, target: %s, protected: %t", req.GetSlug(), req.Target, req.Password != nil)
+
+	link, err := remember(ctx, lgs.idempotency, req, func() (*proto.LinkId, error) { return lgs.createlink(req) })
+	if errors.Is(err, idempotency.ErrKeyReused) {
+		return nil, fmt.Errorf("error creating link: %w", err)
+	}
+
+	return link, err
+}
+
+// createlink creates the link specified on the request.
+func (lgs *LinksService) createlink(req *proto.CreateLinkReq) (*proto.LinkId, error) {
+	opts, err := linkoptions(req)
+	if err != nil {
+		return nil, err
+	}
 
-	link, err := lgs.store.CreateLink(req.Target, req.Slug)
+	link, err := lgs.store.CreateLink(req.Target, req.Slug, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating link: %w", err)
 	}
@@ -64,46 +152,91 @@ func (lgs *LinksService) CreateLink(ctx context.Context, req *proto.CreateLinkRe
 	}, nil
 }
 
-func (lgs *LinksService) GetLink(ctx context.Context, req *proto.LinkId) (*proto.LinkDetails, error) {
-	lgs.log.Write("GetLink", "slug: %s", req.Slug)
+// linkoptions validates the settings of the link specified on the request, and
+// translates them to the options of the store.
+func linkoptions(req *proto.CreateLinkReq) ([]storage.LinkOption, error) {
+	if slug := req.GetSlug(); slug != "" && !storage.ValidSlug(slug) {
+		return nil, invalid("invalid slug %q; slugs can only have letters, digits, `.`, `_`, `~` and `-`", slug)
+	}
+
+	rules := translation.ProtoRulesToDb(req.Rules)
+	if err := validaterules(rules); err != nil {
+		return nil, invalid("invalid redirect rules: %w", err)
+	}
+
+	split := translation.ProtoSplitToDb(req.Split)
+	if err := validatesplit(split); err != nil {
+		return nil, invalid("invalid split targets: %w", err)
+	}
+
+	opts := []storage.LinkOption{
+		storage.WithRules(rules),
+		storage.WithSplit(split),
+	}
+
+	if req.Password != nil {
+		if *req.Password == "" {
+			return nil, invalid("password can't be empty")
+		}
+
+		hash, err := HashPassword(*req.Password)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, storage.WithPasswordHash(hash))
+	}
+
+	if req.Interstitial {
+		delay := time.Duration(req.InterstitialDelay) * time.Second
+		if delay > maxinterstitialdelay {
+			return nil, invalid("interstitial delay can't be longer than %s", maxinterstitialdelay)
+		}
+		opts = append(opts, storage.WithInterstitial(delay))
+	}
+
+	return opts, nil
+}
+
+func (lgs *LinksService) GetLink(ctx context.Context, req *proto.GetLinkReq) (*proto.LinkDetails, error) {
+	lgs.log.Write("GetLink", req.String())
+
+	query, err := translation.ProtoToStatsQuery(req.From, req.To, req.Granularity, req.TimeZone, req.FillGaps)
+	if err != nil {
+		return nil, invalid("invalid stats query: %w", err)
+	}
 
 	link, err := lgs.store.GetLink(req.Slug)
 	if err != nil {
 		return nil, fmt.Errorf("error getting link: %w", err)
 	}
 
-	return translation.DbLinkToProto(link), nil
+	return translation.DbLinkToProto(link, query), nil
 }
 
-func (lgs *LinksService) DeleteLink(ctx context.Context, req *proto.LinkId) (*emptypb.Empty, error) {
-	lgs.log.Write("DeleteLink", "slug: %s", req.Slug)
-
-	return nil, lgs.store.DeleteLink(req.Slug)
-}
+func (lgs *LinksService) GetLinkStats(ctx context.Context, req *proto.LinkStatsReq) (*proto.LinkStats, error) {
+	lgs.log.Write("GetLinkStats", req.String())
 
-// LinkRedirectHandler fetches a shortened link by slug and if there is a link
-// with that slug, it redirects to that link's target url.
-func LinkRedirectHandler(store LinkStore) http.HandlerFunc {
-	log := logging.NewLogger("lnk.redirect")
+	query, err := translation.ProtoToStatsQuery(req.From, req.To, req.Granularity, req.TimeZone, req.FillGaps)
+	if err != nil {
+		return nil, invalid("invalid stats query: %w", err)
+	}
 
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			respond(w, http.StatusMethodNotAllowed, "only get requests allowed")
-			return
-		}
+	link, err := lgs.store.GetLink(req.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("error getting link: %w", err)
+	}
 
-		slug := path.Base(r.URL.Path)
-		log.Write("visit", "slug: %s", slug)
+	return translation.DbLinkToStats(link, query), nil
+}
 
-		target, err := store.GetTarget(slug)
-		if err != nil {
-			respond(w, http.StatusNotFound, err.Error())
-			return
-		}
+func (lgs *LinksService) DeleteLink(ctx context.Context, req *proto.LinkId) (*emptypb.Empty, error) {
+	lgs.log.Write("DeleteLink", "slug: %s", req.Slug)
 
-		store.RegisterHit(slug)
-		http.Redirect(w, r, target, http.StatusTemporaryRedirect)
+	if err := lgs.store.DeleteLink(req.Slug); err != nil {
+		return nil, fmt.Errorf("error deleting link: %w", err)
 	}
+
+	return &emptypb.Empty{}, nil
 }
 
 func respond(w http.ResponseWriter, status int, msg string, args ...any) {
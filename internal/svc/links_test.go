@@ -0,0 +1,80 @@
+package svc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+func TestCreateLinkValidation(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	lgs := NewLinksService(store)
+
+	tests := map[string]struct {
+		req *proto.CreateLinkReq
+
+		wantErr string
+	}{
+		"random slug": {
+			req: &proto.CreateLinkReq{Target: "https://example.com"},
+		},
+		"empty slug": {
+			req: &proto.CreateLinkReq{Target: "https://example.com", Slug: ptr("")},
+		},
+		"custom slug": {
+			req: &proto.CreateLinkReq{Target: "https://example.com", Slug: ptr("Some_slug-1.0~")},
+		},
+		"preview suffix": {
+			req:     &proto.CreateLinkReq{Target: "https://example.com", Slug: ptr("abc+")},
+			wantErr: `invalid slug "abc+"`,
+		},
+		"path separators": {
+			req:     &proto.CreateLinkReq{Target: "https://example.com", Slug: ptr("a/b")},
+			wantErr: `invalid slug "a/b"`,
+		},
+		"spaces": {
+			req:     &proto.CreateLinkReq{Target: "https://example.com", Slug: ptr("a b")},
+			wantErr: `invalid slug "a b"`,
+		},
+		"reserved slug": {
+			req:     &proto.CreateLinkReq{Target: "https://example.com", Slug: ptr("api")},
+			wantErr: `invalid slug "api"`,
+		},
+		"parent directory": {
+			req:     &proto.CreateLinkReq{Target: "https://example.com", Slug: ptr("..")},
+			wantErr: `invalid slug ".."`,
+		},
+		"empty password": {
+			req:     &proto.CreateLinkReq{Target: "https://example.com", Password: ptr("")},
+			wantErr: "password can't be empty",
+		},
+		"long interstitial": {
+			req:     &proto.CreateLinkReq{Target: "https://example.com", Interstitial: true, InterstitialDelay: 61},
+			wantErr: "interstitial delay can't be longer than 1m0s",
+		},
+		"single split target": {
+			req:     &proto.CreateLinkReq{Target: "https://example.com", Split: []*proto.SplitTarget{{Target: "https://a.com", Weight: 1}}},
+			wantErr: "invalid split targets",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := lgs.CreateLink(context.Background(), test.req)
+
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package svc
+
+import (
+	"context"
+
+	"github.com/aexvir/lnk/internal/translation"
+	"github.com/aexvir/lnk/proto"
+)
+
+const (
+	defaultoverviewlimit = 10
+	maxoverviewlimit     = 100
+)
+
+func (lgs *LinksService) GetOverview(ctx context.Context, req *proto.OverviewReq) (*proto.Overview, error) {
+	lgs.log.Write("GetOverview", req.String())
+
+	query, err := translation.ProtoToStatsQuery(req.From, req.To, req.Granularity, req.TimeZone, req.FillGaps)
+	if err != nil {
+		return nil, invalid("invalid stats query: %w", err)
+	}
+
+	limit := int(req.Limit)
+	switch {
+	case limit == 0:
+		limit = defaultoverviewlimit
+	case limit > maxoverviewlimit:
+		return nil, invalid("limit can't be greater than %d", maxoverviewlimit)
+	}
+
+	return translation.OverviewToProto(lgs.store.Overview(query, limit), query), nil
+}
@@ -0,0 +1,75 @@
+package svc
+
+import (
+	"html/template"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// maxinterstitialdelay caps how long interstitial pages make visitors wait.
+const maxinterstitialdelay = time.Minute
+
+var previewpage = template.Must(
+	template.New("preview").Parse(`
+<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <meta name="robots" content="noindex">
+    <title>Link preview</title>
+  </head>
+  <body>
+    <h1>/{{.Slug}}</h1>
+    <p>This link goes to <a href="{{.Target}}" rel="noopener noreferrer">{{.Target}}</a></p>
+    {{if or .Rules .Split}}<p>Depending on the visitor, it may redirect somewhere else.</p>{{end}}
+    <p>Created on {{.CreatedAt.Format "2006-01-02"}} and visited {{.Hits}} times.</p>
+  </body>
+</html>
+`),
+)
+
+// renderpreview renders a page with the link details instead of redirecting.
+func renderpreview(w http.ResponseWriter, link *storage.Link) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_ = previewpage.Execute(w, link)
+}
+
+var interstitialpage = template.Must(
+	template.New("interstitial").Parse(`
+<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <meta name="robots" content="noindex">
+    {{if .Delay}}<meta http-equiv="refresh" content="{{.Delay}};url={{.Target}}">{{end}}
+    <title>You are leaving</title>
+  </head>
+  <body>
+    <p>You are being redirected to <strong>{{.Target}}</strong></p>
+    {{if .Delay}}<p>The redirect will happen in {{.Delay}} seconds.</p>{{end}}
+    <p><a href="{{.Target}}" rel="noopener noreferrer">Continue</a></p>
+  </body>
+</html>
+`),
+)
+
+// renderinterstitial renders a warning page showing where the visitor is going.
+// If the delay is not zero, the page redirects to the target once it elapses.
+func renderinterstitial(w http.ResponseWriter, target string, delay time.Duration) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = interstitialpage.Execute(
+		w,
+		struct {
+			Target string
+			Delay  int
+		}{target, int(math.Ceil(delay.Seconds()))},
+	)
+}
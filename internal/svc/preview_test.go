@@ -0,0 +1,110 @@
+package svc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func TestParseSlug(t *testing.T) {
+	tests := map[string]struct {
+		target string
+
+		slug    string
+		preview bool
+	}{
+		"slug":                     {target: "/abc", slug: "abc"},
+		"preview suffix":           {target: "/abc+", slug: "abc", preview: true},
+		"preview parameter":        {target: "/abc?preview", slug: "abc", preview: true},
+		"valued preview parameter": {target: "/abc?preview=1", slug: "abc", preview: true},
+		"other parameters":         {target: "/abc?utm_source=mail", slug: "abc"},
+		"escaped plus":             {target: "/abc%2B", slug: "abc", preview: true},
+		"nested path":              {target: "/some/abc", slug: "abc"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			slug, preview := parseslug(httptest.NewRequest(http.MethodGet, test.target, nil))
+
+			assert.Equal(t, test.slug, slug)
+			assert.Equal(t, test.preview, preview)
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	slug, err := store.CreateLink("https://example.com/?q=<script>", ptr("abc"))
+	require.NoError(t, err)
+
+	handler, err := LinkRedirectHandler(store)
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	for _, target := range []string{"/abc+", "/abc?preview"} {
+		resp := record(handler, request(http.MethodGet, target))
+
+		assert.Equal(t, http.StatusOK, resp.Code, "previews should be rendered instead of redirecting")
+		assert.Equal(t, "text/html; charset=utf-8", resp.Header().Get("Content-Type"))
+		assert.Contains(t, resp.Body.String(), "<h1>/abc</h1>")
+		assert.Contains(t, resp.Body.String(), "https://example.com/?q=%3cscript%3e", "the target should be escaped")
+		assert.NotContains(t, resp.Body.String(), "<script>")
+	}
+
+	assert.EqualValues(t, 0, hits(t, store, slug), "previews aren't visits")
+
+	resp := record(handler, request(http.MethodGet, "/missing+"))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestInterstitial(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	_, err = store.CreateLink("https://example.com", ptr("manual"), storage.WithInterstitial(0))
+	require.NoError(t, err)
+	_, err = store.CreateLink("https://example.com", ptr("delayed"), storage.WithInterstitial(2500*time.Millisecond))
+	require.NoError(t, err)
+
+	handler, err := LinkRedirectHandler(store)
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	tests := map[string]struct {
+		slug string
+
+		refresh string
+	}{
+		"click through": {
+			slug: "manual",
+		},
+		"delayed redirect": {
+			slug:    "delayed",
+			refresh: `<meta http-equiv="refresh" content="3;url=https://example.com">`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := record(handler, request(http.MethodGet, "/"+test.slug))
+
+			assert.Equal(t, http.StatusOK, resp.Code, "the warning page should be rendered instead of redirecting")
+			assert.Equal(t, "no-store", resp.Header().Get("Cache-Control"))
+			assert.Contains(t, resp.Body.String(), `<a href="https://example.com" rel="noopener noreferrer">Continue</a>`)
+
+			if test.refresh == "" {
+				assert.NotContains(t, resp.Body.String(), "http-equiv")
+				return
+			}
+			assert.Contains(t, resp.Body.String(), test.refresh, "the delay should be rounded up to seconds")
+		})
+	}
+
+	assert.EqualValues(t, 1, hits(t, store, "manual"), "the warning page counts as a visit")
+}
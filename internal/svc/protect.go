@@ -0,0 +1,230 @@
+package svc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+const (
+	// authcookieage is how long a visitor can follow a protected link without
+	// being asked for the passphrase again.
+	authcookieage = 15 * time.Minute
+	// maxattempts is the amount of passphrase attempts allowed for each slug
+	// during attemptswindow.
+	maxattempts    = 5
+	attemptswindow = time.Minute
+	// maxformsize caps the size of the passphrase form submissions.
+	maxformsize = 4096
+)
+
+// HashPassword derives the hash that is stored for protected links.
+func HashPassword(password string) ([]byte, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	return hash, nil
+}
+
+// guard restricts access to password protected links.
+// Visitors that enter the correct passphrase get a signed cookie that grants them
+// access to that link until it expires.
+type guard struct {
+	secret   []byte
+	attempts *limiter
+}
+
+func newguard(secret []byte) (*guard, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("error generating cookie secret: %w", err)
+		}
+	}
+
+	return &guard{
+		secret:   secret,
+		attempts: newlimiter(maxattempts, attemptswindow),
+	}, nil
+}
+
+// authorized checks whether the request carries a valid access cookie for the slug.
+func (g *guard) authorized(r *http.Request, slug string) bool {
+	cookie, err := r.Cookie(authcookie(slug))
+	if err != nil {
+		return false
+	}
+
+	expiry, signature, found := strings.Cut(cookie.Value, ".")
+	if !found {
+		return false
+	}
+
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(g.sign(slug, expiry)))
+}
+
+// challenge asks the visitor for the passphrase of the link.
+// GET requests get the passphrase form, while POST requests are the form submissions,
+// which are verified and if correct, the visitor is granted access and sent back
+// to the link.
+func (g *guard) challenge(w http.ResponseWriter, r *http.Request, link *storage.Link) {
+	if r.Method != http.MethodPost {
+		renderpasswordform(w, http.StatusOK, "")
+		return
+	}
+
+	if !g.attempts.allow(link.Slug) {
+		renderpasswordform(w, http.StatusTooManyRequests, "too many attempts, try again later")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxformsize)
+	if err := r.ParseForm(); err != nil {
+		renderpasswordform(w, http.StatusBadRequest, "invalid form submission")
+		return
+	}
+
+	err := bcrypt.CompareHashAndPassword(link.PasswordHash, []byte(r.PostForm.Get("password")))
+	if err != nil {
+		renderpasswordform(w, http.StatusUnauthorized, "wrong passphrase")
+		return
+	}
+
+	expiry := strconv.FormatInt(time.Now().Add(authcookieage).Unix(), 10)
+	http.SetCookie(
+		w,
+		&http.Cookie{
+			Name:     authcookie(link.Slug),
+			Value:    expiry + "." + g.sign(link.Slug, expiry),
+			Path:     "/" + link.Slug,
+			MaxAge:   int(authcookieage.Seconds()),
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		},
+	)
+
+	// the cookie is only sent under the path of the link, which `/slug+` isn't, so
+	// previews are sent back to their `/slug?preview` url instead
+	back := url.URL{Path: "/" + link.Slug, RawQuery: r.URL.RawQuery}
+	if _, preview := parseslug(r); preview {
+		query := r.URL.Query()
+		query.Set("preview", "")
+		back.RawQuery = query.Encode()
+	}
+
+	http.Redirect(w, r, back.RequestURI(), http.StatusSeeOther)
+}
+
+func (g *guard) sign(slug, expiry string) string {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write([]byte(slug + ":" + expiry))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func authcookie(slug string) string {
+	return "lnk_auth_" + slug
+}
+
+// limiter allows a fixed amount of events per key on each time window.
+type limiter struct {
+	limit  int
+	window time.Duration
+
+	windows map[string]*window
+	// pruned is when the expired windows were last dropped
+	pruned time.Time
+	now    func() time.Time
+	mutex  sync.Mutex
+}
+
+type window struct {
+	start time.Time
+	count int
+}
+
+func newlimiter(limit int, period time.Duration) *limiter {
+	return &limiter{
+		limit:   limit,
+		window:  period,
+		windows: make(map[string]*window),
+		pruned:  time.Now(),
+		now:     time.Now,
+	}
+}
+
+// allow registers an event for the key and reports whether it's within the limit.
+func (l *limiter) allow(key string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+
+	// drop the expired windows once per period, so the map doesn't grow forever
+	// without going through it on every event
+	if now.Sub(l.pruned) >= l.window {
+		for k, win := range l.windows {
+			if now.Sub(win.start) >= l.window {
+				delete(l.windows, k)
+			}
+		}
+		l.pruned = now
+	}
+
+	win := l.windows[key]
+	if win == nil || now.Sub(win.start) >= l.window {
+		win = &window{start: now}
+		l.windows[key] = win
+	}
+
+	win.count++
+	return win.count <= l.limit
+}
+
+var passwordform = template.Must(
+	template.New("password").Parse(`
+<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>Protected link</title>
+  </head>
+  <body>
+    <form method="post">
+      <p>This link is protected, enter the passphrase to continue.</p>
+      {{if .Error}}<p><strong>{{.Error}}</strong></p>{{end}}
+      <input type="password" name="password" autofocus required>
+      <button type="submit">Continue</button>
+    </form>
+  </body>
+</html>
+`),
+)
+
+func renderpasswordform(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = passwordform.Execute(w, struct{ Error string }{msg})
+}
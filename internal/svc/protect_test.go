@@ -0,0 +1,220 @@
+package svc
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func TestGuardAuthorized(t *testing.T) {
+	g, err := newguard([]byte("secret"))
+	require.NoError(t, err, "shouldn't fail initing the guard")
+
+	other, err := newguard([]byte("other secret"))
+	require.NoError(t, err, "shouldn't fail initing the guard")
+
+	valid := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+
+	tests := map[string]struct {
+		cookie *http.Cookie
+
+		want bool
+	}{
+		"valid cookie": {
+			cookie: &http.Cookie{Name: authcookie("abc"), Value: valid + "." + g.sign("abc", valid)},
+			want:   true,
+		},
+		"no cookie": {},
+		"expired cookie": {
+			cookie: &http.Cookie{Name: authcookie("abc"), Value: expired + "." + g.sign("abc", expired)},
+		},
+		"extended expiry": {
+			cookie: &http.Cookie{Name: authcookie("abc"), Value: strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10) + "." + g.sign("abc", valid)},
+		},
+		"cookie of another slug": {
+			cookie: &http.Cookie{Name: authcookie("abc"), Value: valid + "." + g.sign("xyz", valid)},
+		},
+		"signed with another secret": {
+			cookie: &http.Cookie{Name: authcookie("abc"), Value: valid + "." + other.sign("abc", valid)},
+		},
+		"malformed value": {
+			cookie: &http.Cookie{Name: authcookie("abc"), Value: g.sign("abc", valid)},
+		},
+		"non numeric expiry": {
+			cookie: &http.Cookie{Name: authcookie("abc"), Value: "never." + g.sign("abc", "never")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/abc", nil)
+			if test.cookie != nil {
+				r.AddCookie(test.cookie)
+			}
+
+			assert.Equal(t, test.want, g.authorized(r, "abc"))
+		})
+	}
+}
+
+func TestProtectedRedirect(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	hash, err := HashPassword("open sesame")
+	require.NoError(t, err)
+
+	slug, err := store.CreateLink("https://example.com", ptr("abc"), storage.WithPasswordHash(hash))
+	require.NoError(t, err)
+
+	handler, err := LinkRedirectHandler(store, WithCookieSecret([]byte("secret")))
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	resp := serve(handler, http.MethodGet, "/"+slug, nil)
+	assert.Equal(t, http.StatusOK, resp.Code, "visitors should get the passphrase form")
+	assert.Contains(t, resp.Body.String(), `type="password"`)
+	assert.EqualValues(t, 0, hits(t, store, slug), "showing the form isn't a visit")
+
+	resp = serve(handler, http.MethodPost, "/"+slug, form("wrong"))
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.Contains(t, resp.Body.String(), "wrong passphrase")
+	assert.Empty(t, resp.Result().Cookies(), "wrong passphrases shouldn't grant access")
+
+	resp = serve(handler, http.MethodPost, "/"+slug, form("open sesame"))
+	require.Equal(t, http.StatusSeeOther, resp.Code, "the visitor should be sent back to the link")
+	assert.Equal(t, "/"+slug, resp.Header().Get("Location"))
+
+	cookies := resp.Result().Cookies()
+	require.Len(t, cookies, 1, "the visitor should get the access cookie")
+	assert.Equal(t, authcookie(slug), cookies[0].Name)
+	assert.Equal(t, "/"+slug, cookies[0].Path, "the cookie should only be sent on the link")
+	assert.True(t, cookies[0].HttpOnly)
+
+	resp = serve(handler, http.MethodGet, "/"+slug, nil, cookies...)
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.Code, "the cookie should grant access to the link")
+	assert.Equal(t, "https://example.com", resp.Header().Get("Location"))
+	assert.EqualValues(t, 1, hits(t, store, slug))
+
+	resp = serve(handler, http.MethodPost, "/"+slug+"+", form("open sesame"))
+	require.Equal(t, http.StatusSeeOther, resp.Code)
+	assert.Equal(t, "/"+slug+"?preview=", resp.Header().Get("Location"), "previews should be sent to a url under the cookie path")
+
+	resp = serve(handler, http.MethodGet, "/"+slug+"?preview", nil, cookies...)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "<h1>/abc</h1>", "the cookie should grant access to the preview")
+
+	restarted, err := LinkRedirectHandler(store, WithCookieSecret([]byte("rotated")))
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	resp = serve(restarted, http.MethodGet, "/"+slug, nil, cookies...)
+	assert.Equal(t, http.StatusOK, resp.Code, "cookies signed with another secret shouldn't grant access")
+}
+
+func TestProtectedRedirectLimits(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	hash, err := HashPassword("open sesame")
+	require.NoError(t, err)
+
+	for _, slug := range []string{"abc", "xyz"} {
+		_, err = store.CreateLink("https://example.com", ptr(slug), storage.WithPasswordHash(hash))
+		require.NoError(t, err)
+	}
+
+	handler, err := LinkRedirectHandler(store)
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	large := form(strings.Repeat("a", maxformsize))
+	resp := serve(handler, http.MethodPost, "/xyz", large)
+	assert.Equal(t, http.StatusBadRequest, resp.Code, "large form submissions should be rejected")
+
+	for i := 0; i < maxattempts; i++ {
+		resp := serve(handler, http.MethodPost, "/abc", form("wrong"))
+		require.Equal(t, http.StatusUnauthorized, resp.Code, "the first attempts should be checked")
+	}
+
+	resp = serve(handler, http.MethodPost, "/abc", form("open sesame"))
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code, "attempts over the limit should be rejected, even if right")
+	assert.Empty(t, resp.Result().Cookies())
+
+	resp = serve(handler, http.MethodPost, "/xyz", form("open sesame"))
+	assert.Equal(t, http.StatusSeeOther, resp.Code, "the attempts should be limited per link")
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2022, 6, 11, 12, 0, 0, 0, time.UTC)
+
+	limit := newlimiter(2, time.Minute)
+	limit.pruned = now
+	limit.now = func() time.Time { return now }
+
+	assert.True(t, limit.allow("a"))
+	assert.True(t, limit.allow("a"))
+	assert.False(t, limit.allow("a"), "the third event should be over the limit")
+	assert.True(t, limit.allow("b"), "each key should have its own limit")
+
+	now = now.Add(30 * time.Second)
+	assert.False(t, limit.allow("a"), "the limit should last the whole window")
+	assert.True(t, limit.allow("c"))
+
+	now = now.Add(30 * time.Second)
+	assert.True(t, limit.allow("a"), "the limit should be reset once the window is over")
+	assert.Len(t, limit.windows, 2, "the expired windows should have been dropped")
+}
+
+// firefox is the user agent of the requests, which would be considered bots without one.
+const firefox = "Mozilla/5.0 (X11; Linux x86_64; rv:101.0) Gecko/20100101 Firefox/101.0"
+
+// serve makes a request to the handler, with a form body if not nil.
+func serve(handler http.HandlerFunc, method, target string, body url.Values, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	r := request(method, target)
+	if body != nil {
+		r.Body = io.NopCloser(strings.NewReader(body.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for _, cookie := range cookies {
+		r.AddCookie(cookie)
+	}
+
+	return record(handler, r)
+}
+
+// request builds a request made by a browser.
+func request(method, target string) *http.Request {
+	r := httptest.NewRequest(method, target, nil)
+	r.Header.Set("User-Agent", firefox)
+	return r
+}
+
+// record the response of the handler to the request.
+func record(handler http.HandlerFunc, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler(w, r)
+	return w
+}
+
+func form(password string) url.Values {
+	return url.Values{"password": {password}}
+}
+
+func hits(t *testing.T, store LinkStore, slug string) uint64 {
+	link, err := store.GetLink(slug)
+	require.NoError(t, err)
+	return link.Hits
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
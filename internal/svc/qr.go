@@ -0,0 +1,93 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+
+	"github.com/aexvir/lnk/internal/qrcode"
+	"github.com/aexvir/lnk/proto"
+)
+
+// maxqrsize caps the side of the rendered qr codes so a request can't make the
+// server render huge images.
+const maxqrsize = 2048
+
+func (lgs *LinksService) GetLinkQR(ctx context.Context, req *proto.LinkQRReq) (*httpbody.HttpBody, error) {
+	lgs.log.Write("GetLinkQR", req.String())
+
+	if _, err := lgs.store.GetLink(req.Slug); err != nil {
+		return nil, fmt.Errorf("error getting link: %w", err)
+	}
+
+	opts, err := qroptions(req)
+	if err != nil {
+		return nil, invalid("invalid qr options: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/%s", lgs.baseurl, req.Slug)
+
+	var buf bytes.Buffer
+	var contenttype string
+
+	switch req.Format {
+	case "", "png":
+		contenttype = "image/png"
+		err = qrcode.PNG(&buf, url, opts)
+	case "svg":
+		contenttype = "image/svg+xml"
+		err = qrcode.SVG(&buf, url, opts)
+	default:
+		return nil, invalid("unknown image format %q", req.Format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error rendering qr code: %w", err)
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: contenttype,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// qroptions builds the rendering options from the request, using the defaults
+// for anything that's not specified.
+func qroptions(req *proto.LinkQRReq) (qrcode.Options, error) {
+	opts := qrcode.DefaultOptions()
+
+	if req.Size != 0 {
+		if req.Size > maxqrsize {
+			return opts, fmt.Errorf("size can't be larger than %d", maxqrsize)
+		}
+		opts.Size = int(req.Size)
+	}
+
+	if req.Margin != nil {
+		opts.Margin = int(*req.Margin)
+	}
+
+	var err error
+
+	if req.Level != "" {
+		if opts.Level, err = qrcode.ParseLevel(req.Level); err != nil {
+			return opts, err
+		}
+	}
+
+	if req.Foreground != "" {
+		if opts.Foreground, err = qrcode.ParseColor(req.Foreground); err != nil {
+			return opts, err
+		}
+	}
+
+	if req.Background != "" {
+		if opts.Background, err = qrcode.ParseColor(req.Background); err != nil {
+			return opts, err
+		}
+	}
+
+	return opts, nil
+}
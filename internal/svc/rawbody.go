@@ -0,0 +1,79 @@
+package svc
+
+import (
+	"fmt"
+	"io"
+
+	gateway "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// MaxBodySize is the largest body that can be uploaded to the api on a single
+// message, e.g. a file to import.
+// Larger uploads, like snapshots to restore, are streamed in chunks instead.
+const MaxBodySize = maximportsize
+
+// MaxMessageSize is the largest grpc message the service has to accept, the uploads
+// plus room for their encoding.
+const MaxMessageSize = MaxBodySize + 1<<20
+
+// bodychunksize is the size of the messages streamed bodies are split in.
+const bodychunksize = 1 << 20
+
+// RawBody is a gateway marshaler that reads the request bodies bound to an HttpBody
+// field as they are, so files can be uploaded without wrapping them in json.
+// Bodies of rpcs that stream HttpBody messages are read in chunks, one per message.
+// Everything else is handled by the wrapped marshaler.
+type RawBody struct {
+	gateway.Marshaler
+}
+
+// NewRawBodyMarshaler wraps the default marshaler of the gateway.
+func NewRawBodyMarshaler() *RawBody {
+	return &RawBody{
+		Marshaler: &gateway.HTTPBodyMarshaler{
+			Marshaler: &gateway.JSONPb{
+				MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+				UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+			},
+		},
+	}
+}
+
+func (rb *RawBody) NewDecoder(r io.Reader) gateway.Decoder {
+	return gateway.DecoderFunc(
+		func(v any) error {
+			switch body := v.(type) {
+			case **httpbody.HttpBody:
+				// read one byte more than allowed so larger bodies can be rejected
+				data, err := io.ReadAll(io.LimitReader(r, MaxBodySize+1))
+				if err != nil {
+					return fmt.Errorf("error reading body: %w", err)
+				}
+				if len(data) > MaxBodySize {
+					return fmt.Errorf("bodies can't be larger than %d bytes", MaxBodySize)
+				}
+
+				*body = &httpbody.HttpBody{Data: data}
+				return nil
+
+			case *httpbody.HttpBody:
+				chunk := make([]byte, bodychunksize)
+				n, err := io.ReadFull(r, chunk)
+				if n == 0 && (err == io.EOF || err == nil) {
+					return io.EOF
+				}
+				if err != nil && err != io.ErrUnexpectedEOF {
+					return fmt.Errorf("error reading body: %w", err)
+				}
+
+				body.Data = chunk[:n]
+				return nil
+
+			default:
+				return rb.Marshaler.NewDecoder(r).Decode(v)
+			}
+		},
+	)
+}
@@ -0,0 +1,206 @@
+package svc
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/aexvir/lnk/internal/events"
+	"github.com/aexvir/lnk/internal/logging"
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// LinkRedirectHandler fetches a shortened link by slug and if there is a link
+// with that slug, it redirects to that link's target url, or to the target of the
+// first of its rules that matches the visit.
+// When no rule matches and the link splits its visits, the visitor is redirected
+// to the arm it's assigned to instead.
+// Password protected links serve a passphrase form instead of redirecting, until
+// the visitor enters the correct passphrase.
+// Visits of bots, link preview fetchers and prefetching browsers are redirected as
+// well, but they're counted separately from the ones of people.
+// Slugs followed by a `+`, or requests with the `preview` query parameter, render
+// a page with the link details instead of redirecting.
+// Customize it via RedirectOptions.
+func LinkRedirectHandler(store LinkStore, opts ...RedirectOption) (http.HandlerFunc, error) {
+	log := logging.NewLogger("lnk.redirect")
+
+	cfg := redirectconfig{
+		countryheader: "X-Country-Code",
+		recorder:      storerecorder{store},
+		publisher:     nopublisher{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	guard, err := newguard(cfg.cookiesecret)
+	if err != nil {
+		return nil, err
+	}
+
+	visitors, err := newfingerprinter(cfg.visitorsalt, cfg.ipheader)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodPost {
+			respond(w, http.StatusMethodNotAllowed, "only get, head and post requests allowed")
+			return
+		}
+
+		slug, preview := parseslug(r)
+		log.Write("visit", "slug: %s", slug)
+
+		link, err := store.GetRoute(slug)
+		if err != nil {
+			respond(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if len(link.PasswordHash) > 0 && !guard.authorized(r, slug) {
+			guard.challenge(w, r, link)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			respond(w, http.StatusMethodNotAllowed, "only get and head requests allowed")
+			return
+		}
+
+		if preview {
+			renderpreview(w, link)
+			return
+		}
+
+		visit := newvisit(r, cfg.countryheader)
+		hit := visit.hit(slug)
+		hit.Visitor = visitors.visitor(r)
+		target := link.Target
+
+		if rule := matchrule(link.Rules, visit); rule >= 0 {
+			hit.Rule = &rule
+			target = link.Rules[rule].Target
+		} else if len(link.Split) > 0 {
+			if arm := pickarm(w, r, slug, link.Split); arm >= 0 {
+				hit.Arm = &arm
+				target = link.Split[arm].Target
+			}
+		}
+
+		cfg.recorder.Record(hit)
+		cfg.publisher.Publish(events.Hit{Hit: hit, Target: target})
+
+		if link.Interstitial {
+			renderinterstitial(w, target, link.InterstitialDelay)
+			return
+		}
+
+		http.Redirect(w, r, target, http.StatusTemporaryRedirect)
+	}, nil
+}
+
+// parseslug extracts the slug from the request path, and whether the visitor
+// asked for the link preview instead of being redirected.
+func parseslug(r *http.Request) (string, bool) {
+	slug := path.Base(r.URL.Path)
+
+	if strings.HasSuffix(slug, "+") {
+		return strings.TrimSuffix(slug, "+"), true
+	}
+
+	_, preview := r.URL.Query()["preview"]
+	return slug, preview
+}
+
+// HitRecorder registers the hits of the redirects.
+type HitRecorder interface {
+	Record(hit storage.Hit)
+}
+
+// storerecorder registers hits synchronously on the store.
+type storerecorder struct {
+	store LinkStore
+}
+
+func (sr storerecorder) Record(hit storage.Hit) {
+	sr.store.RegisterHit(hit)
+}
+
+// HitPublisher broadcasts the hits of the redirects, along with their target, to
+// whoever is watching them.
+type HitPublisher interface {
+	Publish(hit events.Hit)
+}
+
+// nopublisher discards the hits, for when nobody can watch them.
+type nopublisher struct{}
+
+func (nopublisher) Publish(events.Hit) {}
+
+type redirectconfig struct {
+	countryheader string
+	cookiesecret  []byte
+	visitorsalt   []byte
+	ipheader      string
+	recorder      HitRecorder
+	publisher     HitPublisher
+}
+
+type RedirectOption func(cfg *redirectconfig)
+
+// WithCountryHeader sets the request header from which the visitor country is read,
+// both for matching redirect rules and for the visit stats.
+// It's expected to be set by the edge proxy in front of the service.
+func WithCountryHeader(header string) RedirectOption {
+	return func(cfg *redirectconfig) {
+		cfg.countryheader = header
+	}
+}
+
+// WithCookieSecret sets the key used to sign the cookies that grant access to
+// password protected links.
+// If not set, a random one is generated, so the cookies are only valid as long as
+// the process is running.
+func WithCookieSecret(secret []byte) RedirectOption {
+	return func(cfg *redirectconfig) {
+		cfg.cookiesecret = secret
+	}
+}
+
+// WithVisitorSalt sets the salt used when hashing the ip and user agent that
+// identify unique visitors.
+// If not set, a random one is generated; replicas whose visitor counts are merged
+// must share the same salt, otherwise the same visitor is counted once per replica.
+func WithVisitorSalt(salt []byte) RedirectOption {
+	return func(cfg *redirectconfig) {
+		cfg.visitorsalt = salt
+	}
+}
+
+// WithClientIPHeader sets the request header from which the visitor ip is read,
+// like `X-Forwarded-For`, when the service is behind a proxy.
+// By default the ip of the connection is used.
+func WithClientIPHeader(header string) RedirectOption {
+	return func(cfg *redirectconfig) {
+		cfg.ipheader = header
+	}
+}
+
+// WithHitRecorder sets the recorder the hits are handed over to.
+// By default hits are registered on the store before redirecting.
+func WithHitRecorder(recorder HitRecorder) RedirectOption {
+	return func(cfg *redirectconfig) {
+		cfg.recorder = recorder
+	}
+}
+
+// WithHitPublisher sets the publisher the hits are broadcast on, after they're
+// handed over to the recorder. Publishing must not block, as it happens before
+// redirecting. By default hits are not broadcast.
+func WithHitPublisher(publisher HitPublisher) RedirectOption {
+	return func(cfg *redirectconfig) {
+		cfg.publisher = publisher
+	}
+}
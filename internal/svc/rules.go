@@ -0,0 +1,206 @@
+package svc
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/internal/useragent"
+)
+
+const (
+	DeviceIOS     = "ios"
+	DeviceAndroid = "android"
+	DeviceDesktop = "desktop"
+)
+
+// visit contains the properties of a request that redirect rules can match on
+// and that are recorded on the link stats.
+type visit struct {
+	agent    useragent.Agent
+	device   string
+	language string
+	country  string
+	referrer string
+	time     time.Time
+	bot      bool
+}
+
+// newvisit extracts the visit properties from the request.
+// The country is read from the specified header, as it's expected to be set by
+// a proxy in front of the service.
+func newvisit(r *http.Request, countryheader string) visit {
+	agent := useragent.Parse(r.UserAgent())
+
+	return visit{
+		agent:    agent,
+		device:   device(agent),
+		language: preferredlanguage(r.Header.Get("Accept-Language")),
+		country:  strings.ToUpper(strings.TrimSpace(r.Header.Get(countryheader))),
+		referrer: referrer(r),
+		time:     time.Now(),
+		bot:      automated(r, agent.Bot),
+	}
+}
+
+// hit builds the storage hit for a visit to the slug.
+func (v visit) hit(slug string) storage.Hit {
+	return storage.Hit{
+		Slug:     slug,
+		Time:     v.time,
+		Referrer: v.referrer,
+		Browser:  v.agent.Browser,
+		OS:       v.agent.OS,
+		Device:   v.agent.Device,
+		Country:  v.country,
+		Bot:      v.bot,
+	}
+}
+
+// matchrule returns the index of the first rule whose condition matches the visit,
+// or -1 if none of them do.
+func matchrule(rules []storage.Rule, v visit) int {
+	for idx, rule := range rules {
+		if matches(rule.Condition, v) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// matches checks every non-empty criteria of the condition against the visit.
+func matches(cond storage.Condition, v visit) bool {
+	if len(cond.Devices) > 0 && !contains(cond.Devices, v.device, strings.EqualFold) {
+		return false
+	}
+
+	if len(cond.Languages) > 0 && !contains(cond.Languages, v.language, langmatch) {
+		return false
+	}
+
+	if len(cond.Countries) > 0 && !contains(cond.Countries, v.country, strings.EqualFold) {
+		return false
+	}
+
+	if cond.After != nil && v.time.Before(*cond.After) {
+		return false
+	}
+
+	if cond.Before != nil && !v.time.Before(*cond.Before) {
+		return false
+	}
+
+	return true
+}
+
+func contains(values []string, value string, eq func(expected, actual string) bool) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, expected := range values {
+		if eq(expected, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// langmatch checks if the language tag matches the expected one.
+// An expected language without region matches all of its regional variants,
+// so `en` matches `en-US`, but `en-US` doesn't match `en-GB`.
+func langmatch(expected, actual string) bool {
+	if strings.EqualFold(expected, actual) {
+		return true
+	}
+
+	if strings.Contains(expected, "-") {
+		return false
+	}
+
+	primary, _, _ := strings.Cut(actual, "-")
+	return strings.EqualFold(expected, primary)
+}
+
+// device classifies the user agent into one of the device types rules match on.
+func device(agent useragent.Agent) string {
+	switch agent.OS {
+	case useragent.OSiOS:
+		return DeviceIOS
+	case useragent.OSAndroid:
+		return DeviceAndroid
+	default:
+		return DeviceDesktop
+	}
+}
+
+// referrer returns the host of the page the visitor came from, or `direct` if the
+// request doesn't come from another page.
+func referrer(r *http.Request) string {
+	ref, err := url.Parse(r.Referer())
+	if err != nil || ref.Hostname() == "" {
+		return "direct"
+	}
+
+	return strings.ToLower(ref.Hostname())
+}
+
+// preferredlanguage returns the language with the highest quality value on an
+// Accept-Language header, or an empty string if there is none.
+func preferredlanguage(header string) string {
+	var best string
+	bestq := 0.0
+
+	for _, entry := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			parsed, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if q > bestq {
+			best, bestq = tag, q
+		}
+	}
+
+	return best
+}
+
+// validaterules makes sure the rules can be evaluated before storing them.
+func validaterules(rules []storage.Rule) error {
+	for idx, rule := range rules {
+		if _, err := url.ParseRequestURI(rule.Target); err != nil {
+			return fmt.Errorf("rule %d: invalid target url: %w", idx, err)
+		}
+
+		for _, dev := range rule.Condition.Devices {
+			switch strings.ToLower(dev) {
+			case DeviceIOS, DeviceAndroid, DeviceDesktop:
+			default:
+				return fmt.Errorf("rule %d: unknown device %q", idx, dev)
+			}
+		}
+
+		after, before := rule.Condition.After, rule.Condition.Before
+		if after != nil && before != nil && !after.Before(*before) {
+			return fmt.Errorf("rule %d: empty time window", idx)
+		}
+	}
+
+	return nil
+}
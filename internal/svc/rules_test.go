@@ -0,0 +1,176 @@
+package svc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func TestMatchRule(t *testing.T) {
+	now := time.Date(2022, 6, 11, 12, 0, 0, 0, time.UTC)
+	earlier, later := now.Add(-time.Hour), now.Add(time.Hour)
+
+	rules := []storage.Rule{
+		{Condition: storage.Condition{Devices: []string{"ios"}, Countries: []string{"es"}}, Target: "https://apps.apple.com/es"},
+		{Condition: storage.Condition{Devices: []string{"ios"}}, Target: "https://apps.apple.com"},
+		{Condition: storage.Condition{Languages: []string{"de", "fr-CA"}}, Target: "https://example.com/de-fr"},
+		{Condition: storage.Condition{After: &later}, Target: "https://example.com/later"},
+		{Condition: storage.Condition{Before: &earlier}, Target: "https://example.com/earlier"},
+	}
+
+	tests := map[string]struct {
+		visit visit
+
+		want int
+	}{
+		"no match": {
+			visit: visit{device: DeviceDesktop, language: "en-US", country: "US", time: now},
+			want:  -1,
+		},
+		"all criteria match": {
+			visit: visit{device: DeviceIOS, country: "ES", time: now},
+			want:  0,
+		},
+		"first matching rule wins": {
+			visit: visit{device: DeviceIOS, language: "de", country: "FR", time: now},
+			want:  1,
+		},
+		"language without region matches its variants": {
+			visit: visit{device: DeviceAndroid, language: "de-AT", time: now},
+			want:  2,
+		},
+		"language with region only matches itself": {
+			visit: visit{device: DeviceAndroid, language: "fr-FR", time: now},
+			want:  -1,
+		},
+		"unknown values never match": {
+			visit: visit{time: now},
+			want:  -1,
+		},
+		"after the start of the window": {
+			visit: visit{time: later},
+			want:  3,
+		},
+		"before the end of the window": {
+			visit: visit{time: earlier.Add(-time.Second)},
+			want:  4,
+		},
+		"the end of the window is exclusive": {
+			visit: visit{time: earlier},
+			want:  -1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, matchrule(rules, test.visit))
+		})
+	}
+}
+
+func TestPreferredLanguage(t *testing.T) {
+	tests := map[string]struct {
+		header string
+
+		want string
+	}{
+		"empty":                 {header: "", want: ""},
+		"single language":       {header: "es-ES", want: "es-ES"},
+		"first without quality": {header: "en-US, en;q=0.9, de;q=0.8", want: "en-US"},
+		"highest quality":       {header: "de;q=0.5, fr-CA;q=0.9, en;q=0.7", want: "fr-CA"},
+		"ties keep the first":   {header: "de;q=0.8, fr;q=0.8", want: "de"},
+		"wildcards are ignored": {header: "*, nl;q=0.1", want: "nl"},
+		"malformed qualities":   {header: "de;q=high, it;q=0.2", want: "it"},
+		"zero quality":          {header: "pt;q=0", want: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, preferredlanguage(test.header))
+		})
+	}
+}
+
+func TestLangMatch(t *testing.T) {
+	tests := map[string]struct {
+		expected string
+		actual   string
+
+		want bool
+	}{
+		"same language":              {expected: "en", actual: "en", want: true},
+		"case insensitive":           {expected: "EN-us", actual: "en-US", want: true},
+		"primary matches variants":   {expected: "en", actual: "en-GB", want: true},
+		"variants don't match":       {expected: "en-US", actual: "en-GB", want: false},
+		"variant doesn't match base": {expected: "en-US", actual: "en", want: false},
+		"other language":             {expected: "en", actual: "es-ES", want: false},
+		"prefixes aren't languages":  {expected: "e", actual: "en", want: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, langmatch(test.expected, test.actual))
+		})
+	}
+}
+
+func TestRuleRedirect(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	rules := []storage.Rule{
+		{Condition: storage.Condition{Devices: []string{"ios"}}, Target: "https://apps.apple.com"},
+		{Condition: storage.Condition{Countries: []string{"de"}, Languages: []string{"de"}}, Target: "https://example.de"},
+	}
+	slug, err := store.CreateLink("https://example.com", ptr("abc"), storage.WithRules(rules))
+	require.NoError(t, err)
+
+	handler, err := LinkRedirectHandler(store, WithCountryHeader("CF-IPCountry"))
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	tests := map[string]struct {
+		headers map[string]string
+
+		want string
+	}{
+		"default target": {
+			want: "https://example.com",
+		},
+		"device rule": {
+			headers: map[string]string{"User-Agent": "Mozilla/5.0 (iPhone; CPU iPhone OS 15_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 Safari/604.1"},
+			want:    "https://apps.apple.com",
+		},
+		"country and language rule": {
+			headers: map[string]string{"CF-IPCountry": "de", "Accept-Language": "de-DE,en;q=0.5"},
+			want:    "https://example.de",
+		},
+		"partial match": {
+			headers: map[string]string{"CF-IPCountry": "at", "Accept-Language": "de-AT"},
+			want:    "https://example.com",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := request(http.MethodGet, "/"+slug)
+			for header, value := range test.headers {
+				r.Header.Set(header, value)
+			}
+
+			resp := record(handler, r)
+			assert.Equal(t, http.StatusTemporaryRedirect, resp.Code)
+			assert.Equal(t, test.want, resp.Header().Get("Location"))
+		})
+	}
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, link.Hits)
+	assert.EqualValues(t, 1, link.Rules[0].Hits, "the hits should be counted on the matching rule")
+	assert.EqualValues(t, 1, link.Rules[1].Hits, "the hits should be counted on the matching rule")
+}
@@ -0,0 +1,140 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+// snapshotcontenttype is the content type of the snapshots, which are opaque binaries.
+const snapshotcontenttype = "application/octet-stream"
+
+// maxsnapshotsize is the largest snapshot that can be restored.
+const maxsnapshotsize = 256 << 20
+
+// WithAdminKey sets the key the calls that snapshot and restore the whole store have
+// to send as a bearer token. Those calls are refused if there's no key.
+func WithAdminKey(key string) ServiceOption {
+	return func(lgs *LinksService) {
+		lgs.adminkey = []byte(key)
+	}
+}
+
+func (lgs *LinksService) SnapshotStore(ctx context.Context, _ *emptypb.Empty) (*httpbody.HttpBody, error) {
+	lgs.log.Write("SnapshotStore", "_")
+
+	if err := lgs.authorizeadmin(ctx); err != nil {
+		return nil, err
+	}
+
+	store, err := lgs.snapshotter()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := store.Snapshot(&buf); err != nil {
+		return nil, fmt.Errorf("error taking snapshot: %w", err)
+	}
+
+	return &httpbody.HttpBody{ContentType: snapshotcontenttype, Data: buf.Bytes()}, nil
+}
+
+func (lgs *LinksService) RestoreStore(stream proto.Links_RestoreStoreServer) error {
+	lgs.log.Write("RestoreStore", "_")
+
+	if err := lgs.authorizeadmin(stream.Context()); err != nil {
+		return err
+	}
+
+	store, err := lgs.snapshotter()
+	if err != nil {
+		return err
+	}
+
+	info, err := store.Restore(&chunkreader{recv: stream.Recv, limit: maxsnapshotsize})
+	if err != nil {
+		return fmt.Errorf("error restoring snapshot: %w", err)
+	}
+
+	return stream.SendAndClose(
+		&proto.RestoreStoreResp{
+			Version:     uint32(info.Version),
+			CreatedAt:   timestamppb.New(info.CreatedAt),
+			Links:       uint32(info.Links),
+			Webhooks:    uint32(info.Webhooks),
+			Deliveries:  uint32(info.Deliveries),
+			DeadLetters: uint32(info.DeadLetters),
+		},
+	)
+}
+
+// authorizeadmin fails unless the call was made with the admin key as bearer token.
+func (lgs *LinksService) authorizeadmin(ctx context.Context) error {
+	if len(lgs.adminkey) == 0 {
+		return status.Error(codes.PermissionDenied, "the admin api is disabled, as the server has no admin key")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if !strings.HasPrefix(value, "Bearer ") {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(value, "Bearer ")), lgs.adminkey) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "the admin key is missing or wrong")
+}
+
+// snapshotter returns the store if it supports snapshots, which not every store has to.
+func (lgs *LinksService) snapshotter() (storage.Snapshotter, error) {
+	store, ok := lgs.store.(storage.Snapshotter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "the store doesn't support snapshots")
+	}
+
+	return store, nil
+}
+
+// chunkreader reads the chunks of a stream of HttpBody messages as a continuous body,
+// failing if it's longer than the limit.
+type chunkreader struct {
+	recv  func() (*httpbody.HttpBody, error)
+	limit int
+
+	chunk []byte
+	read  int
+}
+
+func (cr *chunkreader) Read(p []byte) (int, error) {
+	for len(cr.chunk) == 0 {
+		body, err := cr.recv()
+		if err != nil {
+			return 0, err
+		}
+		cr.chunk = body.GetData()
+	}
+
+	n := copy(p, cr.chunk)
+	cr.chunk = cr.chunk[n:]
+
+	if cr.read += n; cr.read > cr.limit {
+		return 0, fmt.Errorf("the body can't be larger than %d bytes", cr.limit)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,130 @@
+package svc
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func TestChunkReader(t *testing.T) {
+	tests := map[string]struct {
+		chunks []string
+		limit  int
+
+		want    string
+		wantErr string
+	}{
+		"single chunk": {
+			chunks: []string{"snapshot"},
+			limit:  100,
+			want:   "snapshot",
+		},
+		"several chunks": {
+			chunks: []string{"snap", "", "sh", "ot"},
+			limit:  100,
+			want:   "snapshot",
+		},
+		"no chunks": {
+			limit: 100,
+		},
+		"at the limit": {
+			chunks: []string{"snap", "shot"},
+			limit:  8,
+			want:   "snapshot",
+		},
+		"over the limit": {
+			chunks:  []string{"snap", "shot", "s"},
+			limit:   8,
+			wantErr: "the body can't be larger than 8 bytes",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chunks := test.chunks
+			recv := func() (*httpbody.HttpBody, error) {
+				if len(chunks) == 0 {
+					return nil, io.EOF
+				}
+				chunk := chunks[0]
+				chunks = chunks[1:]
+				return &httpbody.HttpBody{Data: []byte(chunk)}, nil
+			}
+
+			data, err := io.ReadAll(&chunkreader{recv: recv, limit: test.limit})
+
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, string(data))
+		})
+	}
+}
+
+func TestSnapshotAdminKey(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	tests := map[string]struct {
+		adminkey      string
+		authorization []string
+
+		want codes.Code
+	}{
+		"right key": {
+			adminkey:      "secret",
+			authorization: []string{"Bearer secret"},
+			want:          codes.OK,
+		},
+		"right key among others": {
+			adminkey:      "secret",
+			authorization: []string{"Bearer other", "Bearer secret"},
+			want:          codes.OK,
+		},
+		"wrong key": {
+			adminkey:      "secret",
+			authorization: []string{"Bearer other"},
+			want:          codes.Unauthenticated,
+		},
+		"key without scheme": {
+			adminkey:      "secret",
+			authorization: []string{"secret"},
+			want:          codes.Unauthenticated,
+		},
+		"missing key": {
+			adminkey: "secret",
+			want:     codes.Unauthenticated,
+		},
+		"no admin key": {
+			authorization: []string{"Bearer "},
+			want:          codes.PermissionDenied,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lgs := NewLinksService(store, WithAdminKey(test.adminkey))
+
+			ctx := context.Background()
+			if test.authorization != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{"authorization": test.authorization})
+			}
+
+			_, err := lgs.SnapshotStore(ctx, &emptypb.Empty{})
+			assert.Equal(t, test.want, status.Code(err))
+		})
+	}
+}
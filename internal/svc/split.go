@@ -0,0 +1,102 @@
+package svc
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// armcookieage is how long a visitor stays assigned to the same split arm.
+const armcookieage = 30 * 24 * time.Hour
+
+// pickarm returns the index of the split arm the visitor is assigned to.
+// Visitors that were already assigned to an arm, as recorded in the arm cookie,
+// keep the same one as long as it's still valid; the rest get a random arm
+// chosen proportionally to the arm weights, and the assignment is stored on a
+// cookie so it's sticky on later visits.
+func pickarm(w http.ResponseWriter, r *http.Request, slug string, arms []storage.Arm) int {
+	name := armcookie(slug)
+
+	if cookie, err := r.Cookie(name); err == nil {
+		idx, err := strconv.Atoi(cookie.Value)
+		if err == nil && idx >= 0 && idx < len(arms) && arms[idx].Weight > 0 {
+			return idx
+		}
+	}
+
+	idx := weightedrandom(arms)
+	if idx < 0 {
+		return idx
+	}
+
+	http.SetCookie(
+		w,
+		&http.Cookie{
+			Name:     name,
+			Value:    strconv.Itoa(idx),
+			Path:     "/" + slug,
+			MaxAge:   int(armcookieage.Seconds()),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		},
+	)
+
+	return idx
+}
+
+func armcookie(slug string) string {
+	return "lnk_arm_" + slug
+}
+
+// weightedrandom picks an arm index with a probability proportional to its weight.
+// It returns -1 if there are no arms with positive weight.
+func weightedrandom(arms []storage.Arm) int {
+	var total int64
+	for _, arm := range arms {
+		total += int64(arm.Weight)
+	}
+
+	if total == 0 {
+		return -1
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(total))
+	if err != nil {
+		return -1
+	}
+
+	pick := n.Int64()
+	for idx, arm := range arms {
+		pick -= int64(arm.Weight)
+		if pick < 0 {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// validatesplit makes sure the split arms can be used before storing them.
+func validatesplit(arms []storage.Arm) error {
+	if len(arms) == 1 {
+		return fmt.Errorf("at least two targets are needed to split visits")
+	}
+
+	for idx, arm := range arms {
+		if _, err := url.ParseRequestURI(arm.Target); err != nil {
+			return fmt.Errorf("arm %d: invalid target url: %w", idx, err)
+		}
+
+		if arm.Weight == 0 {
+			return fmt.Errorf("arm %d: weight must be positive", idx)
+		}
+	}
+
+	return nil
+}
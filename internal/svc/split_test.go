@@ -0,0 +1,148 @@
+package svc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func TestWeightedRandom(t *testing.T) {
+	tests := map[string]struct {
+		arms []storage.Arm
+
+		want map[int]bool
+	}{
+		"no arms": {
+			want: map[int]bool{-1: true},
+		},
+		"no positive weights": {
+			arms: []storage.Arm{{Weight: 0}, {Weight: 0}},
+			want: map[int]bool{-1: true},
+		},
+		"only one positive weight": {
+			arms: []storage.Arm{{Weight: 0}, {Weight: 5}, {Weight: 0}},
+			want: map[int]bool{1: true},
+		},
+		"every weighted arm": {
+			arms: []storage.Arm{{Weight: 1}, {Weight: 0}, {Weight: 1}},
+			want: map[int]bool{0: true, 2: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := make(map[int]bool)
+			for i := 0; i < 100; i++ {
+				got[weightedrandom(test.arms)] = true
+			}
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestWeightedRandomDistribution(t *testing.T) {
+	arms := []storage.Arm{{Weight: 1}, {Weight: 3}}
+
+	const draws = 10000
+	counts := make([]int, len(arms))
+	for i := 0; i < draws; i++ {
+		counts[weightedrandom(arms)]++
+	}
+
+	assert.InDelta(t, 0.25, float64(counts[0])/draws, 0.03, "the arms should be picked proportionally to their weight")
+}
+
+func TestPickArm(t *testing.T) {
+	arms := []storage.Arm{{Target: "https://a.com", Weight: 1}, {Target: "https://b.com", Weight: 0}, {Target: "https://c.com", Weight: 1}}
+
+	tests := map[string]struct {
+		cookie string
+
+		sticky bool
+		want   int
+	}{
+		"assigned arm": {
+			cookie: "2",
+			sticky: true,
+			want:   2,
+		},
+		"arm without weight": {
+			cookie: "1",
+		},
+		"arm out of range": {
+			cookie: "3",
+		},
+		"negative arm": {
+			cookie: "-1",
+		},
+		"malformed cookie": {
+			cookie: "c",
+		},
+		"no cookie": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := request(http.MethodGet, "/abc")
+			if test.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: armcookie("abc"), Value: test.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			arm := pickarm(w, r, "abc", arms)
+			cookies := w.Result().Cookies()
+
+			if test.sticky {
+				assert.Equal(t, test.want, arm, "the assigned arm should be kept")
+				assert.Empty(t, cookies, "the assignment shouldn't be stored again")
+				return
+			}
+
+			assert.Contains(t, []int{0, 2}, arm, "a weighted arm should be picked")
+			require.Len(t, cookies, 1, "the assignment should be stored")
+			assert.Equal(t, armcookie("abc"), cookies[0].Name)
+			assert.Equal(t, "/abc", cookies[0].Path, "the assignment should only be sent on the link")
+			assert.Equal(t, int(armcookieage.Seconds()), cookies[0].MaxAge)
+			assert.Equal(t, strconv.Itoa(arm), cookies[0].Value)
+		})
+	}
+}
+
+func TestSplitRedirect(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err, "shouldn't fail initing the store")
+
+	arms := []storage.Arm{{Target: "https://a.com", Weight: 1}, {Target: "https://b.com", Weight: 1}}
+	slug, err := store.CreateLink("https://example.com", ptr("abc"), storage.WithSplit(arms))
+	require.NoError(t, err)
+
+	handler, err := LinkRedirectHandler(store)
+	require.NoError(t, err, "shouldn't fail initing the handler")
+
+	resp := record(handler, request(http.MethodGet, "/"+slug))
+	require.Equal(t, http.StatusTemporaryRedirect, resp.Code)
+	target := resp.Header().Get("Location")
+	assert.Contains(t, []string{"https://a.com", "https://b.com"}, target, "visits should go to one of the arms")
+
+	cookies := resp.Result().Cookies()
+	require.Len(t, cookies, 1, "the visitor should be assigned to the arm")
+
+	for i := 0; i < 10; i++ {
+		r := request(http.MethodGet, "/"+slug)
+		r.AddCookie(cookies[0])
+
+		resp := record(handler, r)
+		assert.Equal(t, target, resp.Header().Get("Location"), "the visitor should stay on the same arm")
+	}
+
+	link, err := store.GetLink(slug)
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, link.Split[0].Hits+link.Split[1].Hits, "the hits should be counted on the arms")
+}
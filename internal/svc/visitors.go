@@ -0,0 +1,63 @@
+package svc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// fingerprinter identifies visitors by a salted hash of their ip and user agent,
+// so unique visitors can be counted without storing any of them.
+type fingerprinter struct {
+	salt     []byte
+	ipheader string
+}
+
+func newfingerprinter(salt []byte, ipheader string) (*fingerprinter, error) {
+	if len(salt) == 0 {
+		salt = make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, fmt.Errorf("error generating visitor salt: %w", err)
+		}
+	}
+
+	return &fingerprinter{
+		salt:     salt,
+		ipheader: ipheader,
+	}, nil
+}
+
+// visitor returns the hash identifying the visitor that made the request.
+func (f *fingerprinter) visitor(r *http.Request) uint64 {
+	hash := sha256.New()
+	hash.Write(f.salt)
+	hash.Write([]byte(f.clientip(r)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(r.UserAgent()))
+
+	return binary.BigEndian.Uint64(hash.Sum(nil))
+}
+
+// clientip returns the ip of the visitor, read from the configured header when the
+// service is behind a proxy, or from the connection otherwise.
+func (f *fingerprinter) clientip(r *http.Request) string {
+	if f.ipheader != "" {
+		// forwarding headers can contain the whole chain of proxies; the first
+		// entry is the client
+		if value := r.Header.Get(f.ipheader); value != "" {
+			client, _, _ := strings.Cut(value, ",")
+			return strings.TrimSpace(client)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
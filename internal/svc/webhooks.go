@@ -0,0 +1,114 @@
+package svc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/internal/translation"
+	"github.com/aexvir/lnk/internal/webhooks"
+	"github.com/aexvir/lnk/proto"
+)
+
+func (lgs *LinksService) CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error) {
+	lgs.log.Write("CreateWebhook", "url: %s, events: %v, slugs: %v", req.Url, req.Events, req.Slugs)
+
+	if err := validatewebhook(req); err != nil {
+		return nil, invalid("invalid webhook: %w", err)
+	}
+
+	hook := storage.Webhook{
+		URL:    req.Url,
+		Events: req.Events,
+		Slugs:  req.Slugs,
+	}
+
+	if req.Secret != nil {
+		hook.Secret = *req.Secret
+	} else {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("error generating webhook secret: %w", err)
+		}
+		hook.Secret = hex.EncodeToString(secret)
+	}
+
+	hook, err := lgs.store.CreateWebhook(hook)
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return translation.WebhookToProto(hook, true), nil
+}
+
+func (lgs *LinksService) ListWebhooks(ctx context.Context, _ *emptypb.Empty) (*proto.WebhookList, error) {
+	lgs.log.Write("ListWebhooks", "_")
+
+	var list proto.WebhookList
+	for _, hook := range lgs.store.GetWebhooks() {
+		list.Webhooks = append(list.Webhooks, translation.WebhookToProto(hook, false))
+	}
+
+	return &list, nil
+}
+
+func (lgs *LinksService) DeleteWebhook(ctx context.Context, req *proto.WebhookId) (*emptypb.Empty, error) {
+	lgs.log.Write("DeleteWebhook", "id: %s", req.Id)
+
+	if err := lgs.store.DeleteWebhook(req.Id); err != nil {
+		return nil, fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (lgs *LinksService) ListDeadLetters(ctx context.Context, req *proto.DeadLettersReq) (*proto.DeliveryList, error) {
+	lgs.log.Write("ListDeadLetters", req.String())
+
+	var list proto.DeliveryList
+	for _, delivery := range lgs.store.DeadLetters(req.WebhookId) {
+		list.Deliveries = append(list.Deliveries, translation.DeliveryToProto(delivery))
+	}
+
+	return &list, nil
+}
+
+func (lgs *LinksService) RetryDeadLetter(ctx context.Context, req *proto.DeliveryId) (*emptypb.Empty, error) {
+	lgs.log.Write("RetryDeadLetter", "id: %s", req.Id)
+
+	if err := lgs.store.RequeueDeadLetter(req.Id); err != nil {
+		return nil, fmt.Errorf("error retrying dead letter: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// validatewebhook checks that the webhook url is absolute and uses http, and that
+// it only subscribes to known events.
+func validatewebhook(req *proto.CreateWebhookReq) error {
+	target, err := url.Parse(req.Url)
+	if err != nil {
+		return fmt.Errorf("malformed url: %w", err)
+	}
+
+	if (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https url")
+	}
+
+	for _, event := range req.Events {
+		if !webhooks.ValidEvent(event) {
+			return fmt.Errorf("unknown event %q", event)
+		}
+	}
+
+	if req.Secret != nil && *req.Secret == "" {
+		return fmt.Errorf("secret can't be empty")
+	}
+
+	return nil
+}
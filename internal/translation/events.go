@@ -0,0 +1,25 @@
+package translation
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/aexvir/lnk/internal/events"
+	"github.com/aexvir/lnk/proto"
+)
+
+// HitToProto translates a broadcast hit to its proto event counterpart, along with
+// the amount of hits the subscriber missed so far.
+func HitToProto(hit events.Hit, dropped uint64) *proto.HitEvent {
+	return &proto.HitEvent{
+		Slug:            hit.Slug,
+		Target:          hit.Target,
+		Time:            timestamppb.New(hit.Time),
+		Referrer:        hit.Referrer,
+		Browser:         hit.Browser,
+		OperatingSystem: hit.OS,
+		Device:          hit.Device,
+		Country:         hit.Country,
+		Bot:             hit.Bot,
+		Dropped:         dropped,
+	}
+}
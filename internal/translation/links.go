@@ -1,21 +1,283 @@
 package translation
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/aexvir/lnk/internal/storage"
 	"github.com/aexvir/lnk/proto"
 )
 
 // DbLinkToProto translates a storage link model to its proto link model counterpart.
-func DbLinkToProto(link *storage.Link) *proto.LinkDetails {
-	stats := make([]*proto.DailyHits, 0, len(link.Histogram))
-	for date, count := range link.Histogram {
-		stats = append(stats, &proto.DailyHits{Date: date, Hits: count})
+// The hits histogram is aggregated as specified by the query.
+func DbLinkToProto(link *storage.Link, query storage.StatsQuery) *proto.LinkDetails {
+	return &proto.LinkDetails{
+		Slug:      link.Slug,
+		Target:    link.Target,
+		Hits:      link.Hits,
+		Stats:     HistogramToProto(link, query),
+		Rules:     DbRulesToProto(link.Rules),
+		Split:     DbSplitToProto(link.Split),
+		Protected: len(link.PasswordHash) > 0,
+		CreatedAt: timestamppb.New(link.CreatedAt),
+
+		Interstitial:      link.Interstitial,
+		InterstitialDelay: uint32(link.InterstitialDelay / time.Second),
+
+		UniqueVisitors: uniqueVisitors(link),
+		BotHits:        link.BotHits,
 	}
+}
 
-	return &proto.LinkDetails{
-		Slug:   link.Slug,
-		Target: link.Target,
-		Hits:   link.Hits,
-		Stats:  stats,
+// uniqueVisitors returns the estimated amount of distinct visitors of the link.
+func uniqueVisitors(link *storage.Link) uint64 {
+	if link.Visitors == nil {
+		return 0
+	}
+
+	return link.Visitors.Estimate()
+}
+
+// ChangeToProto translates a changelog entry to its proto counterpart.
+func ChangeToProto(change storage.Change) *proto.LinkChange {
+	result := proto.LinkChange{
+		Sequence: change.Sequence,
+		Kind:     string(change.Kind),
+		Slug:     change.Slug,
+		Time:     timestamppb.New(change.Time),
+	}
+
+	if change.Link != nil {
+		result.Link = DbLinkToProto(change.Link, storage.DefaultStatsQuery())
+	}
+
+	return &result
+}
+
+// OverviewToProto translates a storage overview to its proto counterpart.
+func OverviewToProto(overview storage.Overview, query storage.StatsQuery) *proto.Overview {
+	return &proto.Overview{
+		TotalLinks:     overview.Links,
+		TotalHits:      overview.Hits,
+		BotHits:        overview.BotHits,
+		Histogram:      seriesToProto(overview.Histogram, nil, query),
+		TopLinks:       summariesToProto(overview.Top),
+		NewestLinks:    summariesToProto(overview.Newest),
+		UnvisitedLinks: summariesToProto(overview.Unvisited),
+		TotalUnvisited: overview.UnvisitedLinks,
+	}
+}
+
+func summariesToProto(summaries []storage.LinkSummary) []*proto.LinkSummary {
+	result := make([]*proto.LinkSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(
+			result,
+			&proto.LinkSummary{
+				Slug:      summary.Slug,
+				Target:    summary.Target,
+				Hits:      summary.Hits,
+				CreatedAt: timestamppb.New(summary.CreatedAt),
+			},
+		)
+	}
+
+	return result
+}
+
+// HistogramToProto aggregates the hourly histogram of a link as specified by the query.
+// The result is sorted chronologically.
+// Unique visitors are only set when the granularity is coarser than hourly, as
+// they are tracked per day.
+func HistogramToProto(link *storage.Link, query storage.StatsQuery) []*proto.DailyHits {
+	return seriesToProto(query.Series(link.Histogram), query.UniqueVisitors(link.DailyVisitors), query)
+}
+
+// seriesToProto translates the buckets of a series, setting their unique visitors
+// if they are known.
+func seriesToProto(series []storage.Bucket, visitors map[time.Time]uint64, query storage.StatsQuery) []*proto.DailyHits {
+	stats := make([]*proto.DailyHits, 0, len(series))
+	for _, bucket := range series {
+		entry := &proto.DailyHits{Date: query.Granularity.Format(bucket.Start), Hits: bucket.Hits}
+		if visitors != nil {
+			unique := visitors[bucket.Start]
+			entry.UniqueVisitors = &unique
+		}
+		stats = append(stats, entry)
+	}
+
+	return stats
+}
+
+// DbLinkToStats translates the visitor breakdowns of a storage link to the proto stats model.
+// The hits histogram is aggregated as specified by the query.
+func DbLinkToStats(link *storage.Link, query storage.StatsQuery) *proto.LinkStats {
+	return &proto.LinkStats{
+		Histogram:        HistogramToProto(link, query),
+		Slug:             link.Slug,
+		Hits:             link.Hits,
+		Referrers:        breakdownToProto(link.Breakdown.Referrers),
+		Browsers:         breakdownToProto(link.Breakdown.Browsers),
+		OperatingSystems: breakdownToProto(link.Breakdown.Systems),
+		Devices:          breakdownToProto(link.Breakdown.Devices),
+		Countries:        breakdownToProto(link.Breakdown.Countries),
+		UniqueVisitors:   uniqueVisitors(link),
+		BotHits:          link.BotHits,
+	}
+}
+
+// breakdownToProto returns the breakdown entries sorted by hits, and alphabetically
+// for values with the same amount of hits.
+func breakdownToProto(counters map[string]uint64) []*proto.BreakdownEntry {
+	entries := make([]*proto.BreakdownEntry, 0, len(counters))
+	for value, hits := range counters {
+		entries = append(entries, &proto.BreakdownEntry{Value: value, Hits: hits})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Hits != entries[j].Hits {
+			return entries[i].Hits > entries[j].Hits
+		}
+		return entries[i].Value < entries[j].Value
+	})
+
+	return entries
+}
+
+// DbSplitToProto translates storage split arms to their proto counterparts.
+func DbSplitToProto(arms []storage.Arm) []*proto.SplitTarget {
+	if len(arms) == 0 {
+		return nil
+	}
+
+	result := make([]*proto.SplitTarget, 0, len(arms))
+	for _, arm := range arms {
+		result = append(result, &proto.SplitTarget{Target: arm.Target, Weight: arm.Weight, Hits: arm.Hits})
+	}
+
+	return result
+}
+
+// ProtoSplitToDb translates proto split targets to their storage counterparts.
+// Hit counters are not translated, as they are managed by the storage itself.
+func ProtoSplitToDb(targets []*proto.SplitTarget) []storage.Arm {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	result := make([]storage.Arm, 0, len(targets))
+	for _, target := range targets {
+		result = append(result, storage.Arm{Target: target.Target, Weight: target.Weight})
+	}
+
+	return result
+}
+
+// DbRulesToProto translates storage redirect rules to their proto counterparts.
+func DbRulesToProto(rules []storage.Rule) []*proto.RedirectRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]*proto.RedirectRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(
+			result,
+			&proto.RedirectRule{
+				Condition: &proto.RuleCondition{
+					Devices:   rule.Condition.Devices,
+					Languages: rule.Condition.Languages,
+					Countries: rule.Condition.Countries,
+					After:     timeToProto(rule.Condition.After),
+					Before:    timeToProto(rule.Condition.Before),
+				},
+				Target: rule.Target,
+				Hits:   rule.Hits,
+			},
+		)
+	}
+
+	return result
+}
+
+// ProtoRulesToDb translates proto redirect rules to their storage counterparts.
+// Hit counters are not translated, as they are managed by the storage itself.
+func ProtoRulesToDb(rules []*proto.RedirectRule) []storage.Rule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]storage.Rule, 0, len(rules))
+	for _, rule := range rules {
+		cond := rule.GetCondition()
+		result = append(
+			result,
+			storage.Rule{
+				Condition: storage.Condition{
+					Devices:   cond.GetDevices(),
+					Languages: cond.GetLanguages(),
+					Countries: cond.GetCountries(),
+					After:     timeFromProto(cond.GetAfter()),
+					Before:    timeFromProto(cond.GetBefore()),
+				},
+				Target: rule.Target,
+			},
+		)
+	}
+
+	return result
+}
+
+// ProtoToStatsQuery builds a stats query from its proto parameters, using the
+// defaults for the ones that are not set.
+func ProtoToStatsQuery(from, to *timestamppb.Timestamp, granularity, timezone string, fillgaps bool) (storage.StatsQuery, error) {
+	query := storage.DefaultStatsQuery()
+	query.FillGaps = fillgaps
+
+	var err error
+	if query.Granularity, err = storage.ParseGranularity(granularity); err != nil {
+		return query, err
+	}
+
+	if timezone != "" {
+		if query.Location, err = time.LoadLocation(timezone); err != nil {
+			return query, fmt.Errorf("unknown time zone %q", timezone)
+		}
+	}
+
+	if from != nil {
+		query.From = from.AsTime()
+	}
+
+	if to != nil {
+		query.To = to.AsTime()
+	}
+
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return query, fmt.Errorf("empty time range")
+	}
+
+	if query.FillGaps && query.TooManyBuckets() {
+		return query, fmt.Errorf("time range too long; at most %d buckets can be filled", storage.MaxSeriesBuckets)
+	}
+
+	return query, nil
+}
+
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
 	}
+	t := ts.AsTime()
+	return &t
 }
@@ -0,0 +1,39 @@
+package translation
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/proto"
+)
+
+// WebhookToProto translates a storage webhook to its proto counterpart.
+// The secret is only included if requested.
+func WebhookToProto(hook storage.Webhook, secret bool) *proto.Webhook {
+	result := proto.Webhook{
+		Id:        hook.ID,
+		Url:       hook.URL,
+		Events:    hook.Events,
+		Slugs:     hook.Slugs,
+		CreatedAt: timestamppb.New(hook.CreatedAt),
+	}
+
+	if secret {
+		result.Secret = hook.Secret
+	}
+
+	return &result
+}
+
+// DeliveryToProto translates a storage webhook delivery to its proto counterpart.
+func DeliveryToProto(delivery storage.Delivery) *proto.Delivery {
+	return &proto.Delivery{
+		Id:        delivery.ID,
+		WebhookId: delivery.Webhook,
+		Event:     delivery.Event,
+		Payload:   string(delivery.Payload),
+		Attempts:  uint32(delivery.Attempts),
+		LastError: delivery.LastError,
+		CreatedAt: timestamppb.New(delivery.Created),
+	}
+}
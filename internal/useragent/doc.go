@@ -0,0 +1,5 @@
+// Package useragent classifies visitors from their User-Agent header.
+//
+// It's not meant to be exhaustive, just good enough for aggregated stats on the
+// most common browsers, operating systems and devices.
+package useragent
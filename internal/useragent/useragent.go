@@ -0,0 +1,137 @@
+package useragent
+
+import (
+	_ "embed"
+	"strings"
+)
+
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+
+	OSiOS     = "iOS"
+	OSAndroid = "Android"
+
+	Other = "Other"
+)
+
+// Agent contains the properties extracted from a user agent.
+type Agent struct {
+	Browser string
+	OS      string
+	Device  string
+	Bot     bool
+}
+
+// marker associates a substring of the user agent with the name it identifies.
+type marker struct {
+	token string
+	name  string
+}
+
+// browsers are checked in order, as most browsers include the tokens of the ones
+// they're based on, e.g. edge and opera user agents also contain chrome and safari.
+var browsers = []marker{
+	{"Edg", "Edge"},
+	{"OPR/", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"YaBrowser/", "Yandex"},
+	{"Chrome/", "Chrome"},
+	{"CriOS/", "Chrome"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"MSIE ", "Internet Explorer"},
+	{"Trident/", "Internet Explorer"},
+	{"Safari/", "Safari"},
+	{"curl/", "curl"},
+	{"Wget/", "Wget"},
+}
+
+// systems are checked in order, for the same reason as browsers; e.g. android user
+// agents contain linux, and iOS ones contain mac os x.
+var systems = []marker{
+	{"iPhone", OSiOS},
+	{"iPad", OSiOS},
+	{"iPod", OSiOS},
+	{"Android", OSAndroid},
+	{"Windows", "Windows"},
+	{"CrOS", "ChromeOS"},
+	{"Macintosh", "macOS"},
+	{"Mac OS X", "macOS"},
+	{"Linux", "Linux"},
+}
+
+//go:embed bots.txt
+var botlist string
+
+// botmarkers are lowercase substrings that only appear on automated clients.
+// They're maintained on bots.txt.
+var botmarkers = parsemarkers(botlist)
+
+// parsemarkers reads one marker per line, skipping empty lines and comments.
+func parsemarkers(list string) []string {
+	var markers []string
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		markers = append(markers, line)
+	}
+
+	return markers
+}
+
+// Parse classifies the user agent.
+// Unknown browsers and operating systems are reported as Other.
+func Parse(ua string) Agent {
+	agent := Agent{
+		Browser: match(ua, browsers),
+		OS:      match(ua, systems),
+		Bot:     isbot(ua),
+	}
+	agent.Device = device(ua, agent)
+
+	return agent
+}
+
+func match(ua string, markers []marker) string {
+	for _, m := range markers {
+		if strings.Contains(ua, m.token) {
+			return m.name
+		}
+	}
+
+	return Other
+}
+
+func isbot(ua string) bool {
+	if ua == "" {
+		return true
+	}
+
+	lower := strings.ToLower(ua)
+	for _, m := range botmarkers {
+		if strings.Contains(lower, m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func device(ua string, agent Agent) string {
+	switch {
+	case agent.Bot:
+		return DeviceBot
+	case strings.Contains(ua, "iPad"), strings.Contains(ua, "Tablet"),
+		agent.OS == OSAndroid && !strings.Contains(ua, "Mobile"):
+		return DeviceTablet
+	case strings.Contains(ua, "Mobi"), strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPod"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
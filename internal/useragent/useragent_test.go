@@ -0,0 +1,83 @@
+package useragent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		ua   string
+		want Agent
+	}{
+		"chrome on windows": {
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.0.0 Safari/537.36",
+			want: Agent{Browser: "Chrome", OS: "Windows", Device: DeviceDesktop},
+		},
+		"edge on windows": {
+			ua:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.0.0 Safari/537.36 Edg/103.0.1264.37",
+			want: Agent{Browser: "Edge", OS: "Windows", Device: DeviceDesktop},
+		},
+		"safari on iphone": {
+			ua:   "Mozilla/5.0 (iPhone; CPU iPhone OS 15_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 Safari/604.1",
+			want: Agent{Browser: "Safari", OS: OSiOS, Device: DeviceMobile},
+		},
+		"safari on ipad": {
+			ua:   "Mozilla/5.0 (iPad; CPU OS 15_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 Safari/604.1",
+			want: Agent{Browser: "Safari", OS: OSiOS, Device: DeviceTablet},
+		},
+		"chrome on android phone": {
+			ua:   "Mozilla/5.0 (Linux; Android 12; Pixel 6) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.5060.71 Mobile Safari/537.36",
+			want: Agent{Browser: "Chrome", OS: OSAndroid, Device: DeviceMobile},
+		},
+		"chrome on android tablet": {
+			ua:   "Mozilla/5.0 (Linux; Android 12; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.5060.71 Safari/537.36",
+			want: Agent{Browser: "Chrome", OS: OSAndroid, Device: DeviceTablet},
+		},
+		"firefox on linux": {
+			ua:   "Mozilla/5.0 (X11; Linux x86_64; rv:101.0) Gecko/20100101 Firefox/101.0",
+			want: Agent{Browser: "Firefox", OS: "Linux", Device: DeviceDesktop},
+		},
+		"googlebot": {
+			ua:   "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want: Agent{Browser: Other, OS: Other, Device: DeviceBot, Bot: true},
+		},
+		"slack link preview": {
+			ua:   "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)",
+			want: Agent{Browser: Other, OS: Other, Device: DeviceBot, Bot: true},
+		},
+		"facebook link preview": {
+			ua:   "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)",
+			want: Agent{Browser: Other, OS: Other, Device: DeviceBot, Bot: true},
+		},
+		"whatsapp link preview": {
+			ua:   "WhatsApp/2.22.20.72 A",
+			want: Agent{Browser: Other, OS: Other, Device: DeviceBot, Bot: true},
+		},
+		"headless chrome": {
+			ua:   "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/103.0.5060.53 Safari/537.36",
+			want: Agent{Browser: "Chrome", OS: "Linux", Device: DeviceBot, Bot: true},
+		},
+		"curl": {
+			ua:   "curl/7.84.0",
+			want: Agent{Browser: "curl", OS: Other, Device: DeviceBot, Bot: true},
+		},
+		"empty": {
+			ua:   "",
+			want: Agent{Browser: Other, OS: Other, Device: DeviceBot, Bot: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, Parse(test.ua))
+		})
+	}
+}
+
+func TestParseMarkers(t *testing.T) {
+	list := "# comment\n\nSlackbot\n  Curl/  \n"
+	assert.Equal(t, []string{"slackbot", "curl/"}, parsemarkers(list))
+	assert.NotEmpty(t, botmarkers, "the embedded bot list should have been loaded")
+}
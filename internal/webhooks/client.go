@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateDestination is returned when a delivery would connect to an address that
+// isn't public, while the dispatcher is restricted to public destinations.
+var ErrPrivateDestination = errors.New("destination is not a public address")
+
+// reserved are the ranges that aren't covered by the checks of netip.Addr but aren't
+// public either, like the shared address space some clouds serve their metadata on.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// newclient returns the client the deliveries are sent with by default.
+// Redirects aren't followed, as they'd send the payloads to urls nobody subscribed.
+// If publiconly is set, connections to addresses that aren't public are refused;
+// the check happens when dialing, so hostnames can't resolve to them either.
+func newclient(publiconly bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if publiconly {
+		dialer := net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   publicdestination,
+		}
+		transport.DialContext = dialer.DialContext
+		// proxies would connect to the destination on behalf of the dispatcher
+		transport.Proxy = nil
+	}
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// publicdestination is a dialer control that fails unless the address it connects
+// to is public.
+func publicdestination(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrPrivateDestination, address)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !public(addr) {
+		return fmt.Errorf("%w: %s", ErrPrivateDestination, host)
+	}
+
+	return nil
+}
+
+// public checks whether the address is reachable on the internet, which excludes
+// loopback, private and link-local addresses, where cloud metadata services live.
+func public(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,105 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+func TestPublic(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":          true,
+		"2606:2800:220:1::248":   true,
+		"127.0.0.1":              false,
+		"::1":                    false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"169.254.169.254":        false,
+		"100.100.100.200":        false,
+		"0.0.0.0":                false,
+		"::":                     false,
+		"fe80::1":                false,
+		"fd00:ec2::254":          false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:93.184.216.34":   true,
+		"224.0.0.1":              false,
+		"255.255.255.255":        false,
+		"64:ff9b::a9fe:a9fe":     false,
+		"fe80::1%eth0":           false,
+		"198.18.0.1":             false,
+		"192.0.0.170":            false,
+		"240.0.0.1":              false,
+		"8.8.8.8":                true,
+		"2a00:1450:4001::200e":   true,
+		"::ffff:169.254.169.254": false,
+	}
+
+	for address, want := range tests {
+		t.Run(address, func(t *testing.T) {
+			assert.Equal(t, want, public(netip.MustParseAddr(address)))
+		})
+	}
+}
+
+func TestPublicDestination(t *testing.T) {
+	assert.NoError(t, publicdestination("tcp4", "93.184.216.34:443", nil))
+	assert.ErrorIs(t, publicdestination("tcp4", "127.0.0.1:80", nil), ErrPrivateDestination)
+	assert.ErrorIs(t, publicdestination("tcp6", "[::1]:80", nil), ErrPrivateDestination)
+	assert.ErrorIs(t, publicdestination("tcp", "localhost", nil), ErrPrivateDestination, "malformed addresses should be refused")
+}
+
+func TestDispatcherRedirects(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+
+	target := &receiver{secret: "target"}
+	targetsrv := httptest.NewServer(target)
+	defer targetsrv.Close()
+
+	redirectsrv := httptest.NewServer(http.RedirectHandler(targetsrv.URL, http.StatusTemporaryRedirect))
+	defer redirectsrv.Close()
+
+	hook, err := store.CreateWebhook(storage.Webhook{URL: redirectsrv.URL, Secret: target.secret})
+	require.NoError(t, err)
+
+	newdispatcher(t, store, WithMaxAttempts(1))
+
+	_, err = store.CreateLink("https://example.com", nil)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(store.DeadLetters(hook.ID)) == 1 }, time.Second, 5*time.Millisecond, "redirects should fail the delivery")
+	assert.Equal(t, "unexpected response status 307", store.DeadLetters(hook.ID)[0].LastError)
+	assert.Empty(t, target.received(), "redirects shouldn't be followed")
+}
+
+func TestDispatcherPublicDestinationsOnly(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+
+	local := &receiver{secret: "local"}
+	localsrv := httptest.NewServer(local)
+	defer localsrv.Close()
+
+	hook, err := store.CreateWebhook(storage.Webhook{URL: localsrv.URL, Secret: local.secret})
+	require.NoError(t, err)
+
+	newdispatcher(t, store, WithMaxAttempts(1), WithPublicDestinationsOnly())
+
+	_, err = store.CreateLink("https://example.com", nil)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(store.DeadLetters(hook.ID)) == 1 }, time.Second, 5*time.Millisecond, "deliveries to loopback should fail")
+	assert.Contains(t, store.DeadLetters(hook.ID)[0].LastError, ErrPrivateDestination.Error())
+	assert.Empty(t, local.received())
+
+	_, err = NewDispatcher(store, WithHTTPClient(http.DefaultClient), WithPublicDestinationsOnly())
+	assert.Error(t, err, "custom clients can't be restricted")
+}
@@ -0,0 +1,454 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aexvir/lnk/internal/logging"
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// Events webhooks can subscribe to.
+// There's no expiry event, as links don't expire.
+const (
+	EventCreated   = "link.created"
+	EventUpdated   = "link.updated"
+	EventDeleted   = "link.deleted"
+	EventMilestone = "link.milestone"
+)
+
+// events maps the kinds of changes of the changelog to the events they trigger.
+var events = map[storage.ChangeKind]string{
+	storage.ChangeCreated:   EventCreated,
+	storage.ChangeUpdated:   EventUpdated,
+	storage.ChangeDeleted:   EventDeleted,
+	storage.ChangeMilestone: EventMilestone,
+}
+
+// ValidEvent checks whether webhooks can subscribe to the event.
+func ValidEvent(event string) bool {
+	for _, known := range events {
+		if event == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Store is where the webhooks and their deliveries are kept, and where the changes
+// to the links are read from.
+type Store interface {
+	LastSequence() uint64
+	ChangesSince(sequence uint64) ([]storage.Change, <-chan struct{}, error)
+
+	GetWebhook(id string) (storage.Webhook, error)
+	GetWebhooks() []storage.Webhook
+
+	EnqueueDeliveries(deliveries []storage.Delivery) error
+	ClaimDeliveries(now time.Time, lease time.Duration, limit int) []storage.Delivery
+	CompleteDelivery(id string)
+	RescheduleDelivery(id string, reason string, at time.Time)
+	DeadLetterDelivery(id string, reason string)
+}
+
+// Payload is the json body sent to the webhooks.
+type Payload struct {
+	Event string `json:"event"`
+	// Sequence of the change that triggered the event, which identifies it.
+	Sequence uint64      `json:"sequence"`
+	Time     time.Time   `json:"time"`
+	Link     PayloadLink `json:"link"`
+}
+
+// PayloadLink is the state of the link after the change; only the slug is set for
+// deleted links.
+type PayloadLink struct {
+	Slug   string `json:"slug"`
+	Target string `json:"target,omitempty"`
+	Hits   uint64 `json:"hits"`
+}
+
+// Dispatcher turns the changes of the links into deliveries for the webhooks
+// subscribed to them, and sends them.
+type Dispatcher struct {
+	store      Store
+	client     *http.Client
+	publiconly bool
+
+	maxattempts int
+	backoff     time.Duration
+	maxbackoff  time.Duration
+	interval    time.Duration
+	workers     int
+	timeout     time.Duration
+
+	wake chan struct{}
+	done chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+	log  *logging.Logger
+}
+
+// NewDispatcher instantiates a dispatcher for the store and starts following its
+// changes from now on.
+// By default deliveries are attempted up to 8 times, waiting 10 seconds after the
+// first failure and doubling the wait on every retry up to an hour. Due deliveries
+// are checked every second, and sent by up to 4 workers, giving up on requests that
+// take over 10 seconds, without following redirects. This can be customized via Options.
+// The dispatcher has to be closed to stop it; undelivered events are kept on the store.
+func NewDispatcher(store Store, opts ...Option) (*Dispatcher, error) {
+	dsp := Dispatcher{
+		store:       store,
+		maxattempts: 8,
+		backoff:     10 * time.Second,
+		maxbackoff:  time.Hour,
+		interval:    time.Second,
+		workers:     4,
+		timeout:     10 * time.Second,
+		wake:        make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		log:         logging.NewLogger("lnk.webhooks"),
+	}
+
+	for _, opt := range opts {
+		if err := opt(&dsp); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case dsp.client == nil:
+		dsp.client = newclient(dsp.publiconly)
+	case dsp.publiconly:
+		return nil, errors.New("custom http clients can't be restricted to public destinations")
+	}
+
+	dsp.wg.Add(2)
+	go dsp.follow(store.LastSequence())
+	go dsp.deliver()
+
+	return &dsp, nil
+}
+
+// Close stops following changes and sending deliveries, waiting for the attempts
+// in flight to finish, or until the context is done.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.once.Do(func() { close(d.done) })
+
+	finished := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// follow queues deliveries for the changes after the sequence, as they happen.
+func (d *Dispatcher) follow(sequence uint64) {
+	defer d.wg.Done()
+
+	for {
+		changes, notify, err := d.store.ChangesSince(sequence)
+		if errors.Is(err, storage.ErrChangesExpired) {
+			// fell too far behind; the missed events can't be recovered
+			d.log.Write("follow", "skipping changes: %s", err)
+			sequence = d.store.LastSequence()
+			continue
+		}
+
+		for _, change := range changes {
+			if err := d.enqueue(change); err != nil {
+				d.log.Write("enqueue", "error queueing deliveries of change %d: %s", change.Sequence, err)
+			}
+			sequence = change.Sequence
+		}
+
+		if len(changes) > 0 {
+			d.signal()
+			continue
+		}
+
+		select {
+		case <-d.done:
+			return
+		case <-notify:
+		}
+	}
+}
+
+// enqueue queues a delivery of the change for every webhook subscribed to it.
+func (d *Dispatcher) enqueue(change storage.Change) error {
+	event, known := events[change.Kind]
+	if !known {
+		return nil
+	}
+
+	payload := Payload{
+		Event:    event,
+		Sequence: change.Sequence,
+		Time:     change.Time,
+		Link:     PayloadLink{Slug: change.Slug},
+	}
+	if change.Link != nil {
+		payload.Link.Target = change.Link.Target
+		payload.Link.Hits = change.Link.Hits
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []storage.Delivery
+	for _, hook := range d.store.GetWebhooks() {
+		if subscribed(hook, event, change.Slug) {
+			deliveries = append(deliveries, storage.Delivery{Webhook: hook.ID, Event: event, Payload: body})
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return d.store.EnqueueDeliveries(deliveries)
+}
+
+func subscribed(hook storage.Webhook, event, slug string) bool {
+	return matches(hook.Events, event) && matches(hook.Slugs, slug)
+}
+
+// matches checks if the value is on the filter; empty filters match everything.
+func matches(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, candidate := range filter {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// signal wakes up the delivery loop without waiting for the next check.
+func (d *Dispatcher) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the due deliveries periodically, and whenever new ones are queued.
+func (d *Dispatcher) deliver() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+
+		d.drain()
+	}
+}
+
+// drain sends due deliveries until there are none left, or the dispatcher is closed.
+func (d *Dispatcher) drain() {
+	for {
+		select {
+		case <-d.done:
+			return
+		default:
+		}
+
+		// the lease covers the attempt, with some leeway for updating the store
+		due := d.store.ClaimDeliveries(time.Now(), 2*d.timeout, d.workers)
+		if len(due) == 0 {
+			return
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(len(due))
+		for _, delivery := range due {
+			go func(delivery storage.Delivery) {
+				defer wg.Done()
+				d.attempt(delivery)
+			}(delivery)
+		}
+		wg.Wait()
+	}
+}
+
+// attempt sends the delivery, and updates it on the store depending on the outcome.
+func (d *Dispatcher) attempt(delivery storage.Delivery) {
+	hook, err := d.store.GetWebhook(delivery.Webhook)
+	if err != nil {
+		// the webhook was deleted after queueing the delivery
+		d.store.CompleteDelivery(delivery.ID)
+		return
+	}
+
+	err = d.send(hook, delivery)
+	if err == nil {
+		d.store.CompleteDelivery(delivery.ID)
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	if attempts >= d.maxattempts {
+		d.log.Write("deadletter", "delivery %s to %s failed %d times: %s", delivery.ID, hook.URL, attempts, err)
+		d.store.DeadLetterDelivery(delivery.ID, err.Error())
+		return
+	}
+
+	d.store.RescheduleDelivery(delivery.ID, err.Error(), time.Now().Add(d.delay(attempts)))
+}
+
+// delay returns how long to wait before retrying after the specified failed attempts.
+func (d *Dispatcher) delay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < attempts && delay < d.maxbackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > d.maxbackoff {
+		return d.maxbackoff
+	}
+
+	return delay
+}
+
+func (d *Dispatcher) send(hook storage.Webhook, delivery storage.Delivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "lnk-webhooks")
+	req.Header.Set("Lnk-Event", delivery.Event)
+	req.Header.Set("Lnk-Delivery", delivery.ID)
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, time.Now(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+type Option func(dsp *Dispatcher) error
+
+// WithHTTPClient sets the client the deliveries are sent with, instead of the default
+// one that doesn't follow redirects.
+func WithHTTPClient(client *http.Client) Option {
+	return func(dsp *Dispatcher) error {
+		if client == nil {
+			return errors.New("http client can't be nil")
+		}
+		dsp.client = client
+		return nil
+	}
+}
+
+// WithPublicDestinationsOnly refuses to send deliveries to addresses that aren't
+// public, like loopback, private or link-local ones, so webhooks can't be used to
+// reach the network of the service or the metadata of its cloud.
+// It can't be combined with WithHTTPClient.
+func WithPublicDestinationsOnly() Option {
+	return func(dsp *Dispatcher) error {
+		dsp.publiconly = true
+		return nil
+	}
+}
+
+// WithMaxAttempts sets how many times a delivery is attempted before moving it to
+// the dead letters.
+func WithMaxAttempts(attempts int) Option {
+	return func(dsp *Dispatcher) error {
+		if attempts < 1 {
+			return errors.New("there must be at least one attempt")
+		}
+		dsp.maxattempts = attempts
+		return nil
+	}
+}
+
+// WithBackoff sets how long to wait before the first retry of a delivery, which is
+// doubled on every retry up to the max.
+func WithBackoff(initial, max time.Duration) Option {
+	return func(dsp *Dispatcher) error {
+		if initial <= 0 || max < initial {
+			return errors.New("backoff must be positive and not greater than its max")
+		}
+		dsp.backoff = initial
+		dsp.maxbackoff = max
+		return nil
+	}
+}
+
+// WithPollInterval sets how often the store is checked for due deliveries.
+func WithPollInterval(interval time.Duration) Option {
+	return func(dsp *Dispatcher) error {
+		if interval <= 0 {
+			return errors.New("poll interval must be positive")
+		}
+		dsp.interval = interval
+		return nil
+	}
+}
+
+// WithWorkers sets how many deliveries are sent concurrently.
+func WithWorkers(workers int) Option {
+	return func(dsp *Dispatcher) error {
+		if workers < 1 {
+			return errors.New("there must be at least one worker")
+		}
+		dsp.workers = workers
+		return nil
+	}
+}
+
+// WithTimeout sets how long to wait for the webhooks to respond.
+func WithTimeout(timeout time.Duration) Option {
+	return func(dsp *Dispatcher) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		dsp.timeout = timeout
+		return nil
+	}
+}
@@ -0,0 +1,248 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aexvir/lnk/internal/storage"
+)
+
+// receiver is a webhook endpoint that records the payloads it gets, failing the
+// first requests if asked to.
+type receiver struct {
+	secret string
+	fails  int
+
+	mutex    sync.Mutex
+	requests int
+	payloads []Payload
+	errors   []error
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.requests++
+	if rc.requests <= rc.fails {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	if err := Verify(rc.secret, r.Header.Get(SignatureHeader), body, time.Minute); err != nil {
+		rc.errors = append(rc.errors, err)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		rc.errors = append(rc.errors, err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if r.Header.Get("Lnk-Event") != payload.Event {
+		rc.errors = append(rc.errors, assert.AnError)
+	}
+
+	rc.payloads = append(rc.payloads, payload)
+}
+
+func (rc *receiver) received() []Payload {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	return append([]Payload(nil), rc.payloads...)
+}
+
+func newdispatcher(t *testing.T, store Store, opts ...Option) *Dispatcher {
+	opts = append(
+		[]Option{WithBackoff(10*time.Millisecond, 40*time.Millisecond), WithPollInterval(5 * time.Millisecond)},
+		opts...,
+	)
+
+	dsp, err := NewDispatcher(store, opts...)
+	require.NoError(t, err, "shouldn't fail creating the dispatcher")
+
+	t.Cleanup(
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			assert.NoError(t, dsp.Close(ctx), "the dispatcher should close in time")
+		},
+	)
+
+	return dsp
+}
+
+func TestDispatcherDelivery(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+
+	all := &receiver{secret: "all"}
+	allsrv := httptest.NewServer(all)
+	defer allsrv.Close()
+
+	filtered := &receiver{secret: "filtered"}
+	filteredsrv := httptest.NewServer(filtered)
+	defer filteredsrv.Close()
+
+	_, err = store.CreateWebhook(storage.Webhook{URL: allsrv.URL, Secret: all.secret})
+	require.NoError(t, err)
+	_, err = store.CreateWebhook(
+		storage.Webhook{URL: filteredsrv.URL, Secret: filtered.secret, Events: []string{EventDeleted}, Slugs: []string{"bbb"}},
+	)
+	require.NoError(t, err)
+
+	newdispatcher(t, store)
+
+	for _, slug := range []string{"aaa", "bbb"} {
+		slug := slug
+		_, err := store.CreateLink("https://example.com/"+slug, &slug)
+		require.NoError(t, err)
+	}
+	require.NoError(t, store.DeleteLink("aaa"))
+	require.NoError(t, store.DeleteLink("bbb"))
+
+	assert.Eventually(t, func() bool { return len(all.received()) == 4 }, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool { return len(filtered.received()) == 1 }, time.Second, 5*time.Millisecond)
+
+	events := make(map[uint64]Payload)
+	for _, payload := range all.received() {
+		events[payload.Sequence] = payload
+	}
+	assert.Equal(t, EventCreated, events[1].Event)
+	assert.Equal(t, PayloadLink{Slug: "aaa", Target: "https://example.com/aaa"}, events[1].Link)
+	assert.Equal(t, EventDeleted, events[4].Event)
+	assert.Equal(t, PayloadLink{Slug: "bbb"}, events[4].Link)
+
+	assert.Equal(t, EventDeleted, filtered.received()[0].Event)
+	assert.Equal(t, "bbb", filtered.received()[0].Link.Slug)
+
+	assert.Empty(t, all.errors, "every request should be properly signed")
+	assert.Empty(t, filtered.errors, "every request should be properly signed")
+}
+
+func TestDispatcherRetries(t *testing.T) {
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+
+	flaky := &receiver{secret: "flaky", fails: 2}
+	flakysrv := httptest.NewServer(flaky)
+	defer flakysrv.Close()
+
+	down := &receiver{secret: "down", fails: 1000}
+	downsrv := httptest.NewServer(down)
+	defer downsrv.Close()
+
+	_, err = store.CreateWebhook(storage.Webhook{URL: flakysrv.URL, Secret: flaky.secret})
+	require.NoError(t, err)
+	downhook, err := store.CreateWebhook(storage.Webhook{URL: downsrv.URL, Secret: down.secret})
+	require.NoError(t, err)
+
+	newdispatcher(t, store, WithMaxAttempts(3))
+
+	_, err = store.CreateLink("https://example.com", nil)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return len(flaky.received()) == 1 }, time.Second, 5*time.Millisecond, "the third attempt should succeed")
+
+	assert.Eventually(t, func() bool { return len(store.DeadLetters("")) == 1 }, time.Second, 5*time.Millisecond, "the delivery that kept failing should be dead")
+
+	dead := store.DeadLetters(downhook.ID)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 3, dead[0].Attempts, "every attempt should be recorded")
+	assert.Equal(t, "unexpected response status 503", dead[0].LastError)
+	assert.Equal(t, EventCreated, dead[0].Event)
+
+	down.mutex.Lock()
+	down.fails = 0
+	down.mutex.Unlock()
+
+	require.NoError(t, store.RequeueDeadLetter(dead[0].ID))
+	assert.Eventually(t, func() bool { return len(down.received()) == 1 }, time.Second, 5*time.Millisecond, "requeued dead letters should be delivered")
+	assert.Empty(t, store.DeadLetters(""))
+}
+
+func TestDispatcherDelay(t *testing.T) {
+	dsp := Dispatcher{backoff: time.Second, maxbackoff: 5 * time.Second}
+
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 30: 5 * time.Second} {
+		assert.Equal(t, want, dsp.delay(attempts), "unexpected delay after %d attempts", attempts)
+	}
+}
+
+func TestSignature(t *testing.T) {
+	payload := []byte(`{"event":"link.created"}`)
+	header := Sign("secret", time.Now(), payload)
+
+	assert.NoError(t, Verify("secret", header, payload, time.Minute))
+	assert.Error(t, Verify("other", header, payload, time.Minute), "other secrets should fail")
+	assert.Error(t, Verify("secret", header, []byte(`{}`), time.Minute), "other payloads should fail")
+	assert.Error(t, Verify("secret", "garbage", payload, time.Minute), "malformed headers should fail")
+
+	old := Sign("secret", time.Now().Add(-time.Hour), payload)
+	assert.Error(t, Verify("secret", old, payload, time.Minute), "old signatures should fail")
+}
+
+func TestDispatcherRestore(t *testing.T) {
+	rc := &receiver{secret: "secret"}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	source, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	_, err = source.CreateWebhook(storage.Webhook{URL: srv.URL, Secret: rc.secret})
+	require.NoError(t, err)
+	for _, slug := range []string{"aaa", "bbb"} {
+		slug := slug
+		_, err := source.CreateLink("https://example.com/"+slug, &slug)
+		require.NoError(t, err)
+	}
+
+	var snapshot bytes.Buffer
+	require.NoError(t, source.Snapshot(&snapshot))
+
+	// the changelog of the store is ahead of the snapshot, so the restore is recorded
+	// right after the changes the dispatcher already followed
+	store, err := storage.NewMemoryStorage()
+	require.NoError(t, err)
+	_, err = store.CreateWebhook(storage.Webhook{URL: srv.URL, Secret: rc.secret})
+	require.NoError(t, err)
+
+	newdispatcher(t, store)
+
+	for _, slug := range []string{"xxx", "yyy", "zzz"} {
+		slug := slug
+		_, err := store.CreateLink("https://example.com/"+slug, &slug)
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return len(rc.received()) == 3 }, time.Second, 5*time.Millisecond)
+
+	_, err = store.Restore(&snapshot)
+	require.NoError(t, err)
+
+	slug := "new"
+	_, err = store.CreateLink("https://example.com/new", &slug)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(rc.received()) >= 4 }, time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	received := rc.received()
+	require.Len(t, received, 4, "restoring the store shouldn't send an event per link")
+	assert.Equal(t, "new", received[3].Link.Slug)
+	assert.EqualValues(t, 5, received[3].Sequence, "the restore should take a single sequence number")
+}
@@ -0,0 +1,10 @@
+// Package webhooks notifies subscribers of the changes done to the links, by sending
+// signed json payloads to their urls.
+//
+// Events are taken from the store changelog and queued on the store as deliveries,
+// which are retried with exponential backoff until they succeed, or they run out of
+// attempts and are moved to the dead letters.
+//
+// Payloads are signed with the secret of the webhook, on the Lnk-Signature header;
+// receivers can check it with Verify.
+package webhooks
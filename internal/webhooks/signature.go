@@ -0,0 +1,62 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the header the payload signature is sent on, formatted as
+// `t=<unix timestamp>,v1=<hex hmac>`.
+const SignatureHeader = "Lnk-Signature"
+
+// Sign computes the signature header of a payload sent at the specified time.
+// The hmac covers both the timestamp and the payload, so requests can't be replayed
+// with a different timestamp.
+func Sign(secret string, at time.Time, payload []byte) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, digest(secret, timestamp, payload))
+}
+
+// Verify checks the signature header of a payload, rejecting the ones signed more
+// than tolerance ago.
+func Verify(secret string, header string, payload []byte, tolerance time.Duration) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return errors.New("malformed signature")
+	}
+
+	if time.Since(time.Unix(unix, 0)) > tolerance {
+		return errors.New("signature too old")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(digest(secret, timestamp, payload))) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+func digest(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
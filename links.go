@@ -0,0 +1,424 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/aexvir/lnk/internal/output"
+	"github.com/aexvir/lnk/proto"
+)
+
+func newcreatecmd(flags *globals) *cobra.Command {
+	var req proto.CreateLinkReq
+	var slug, password string
+
+	cmd := &cobra.Command{
+		Use:   "create <target>",
+		Short: "Create a link redirecting to the target",
+		Example: "  lnkctl create https://example.com/some/long/path\n" +
+			"  lnkctl create https://example.com --slug example --interstitial",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.Target = args[0]
+			if cmd.Flags().Changed("slug") {
+				req.Slug = &slug
+			}
+			if cmd.Flags().Changed("password") {
+				req.Password = &password
+			}
+
+			printer, err := flags.printer(cmd)
+			if err != nil {
+				return err
+			}
+
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			link, err := lnk.CreateLink(cmd.Context(), &req)
+			if err != nil {
+				return fmt.Errorf("error creating link: %w", err)
+			}
+
+			return printer.Print(link, func() []output.Rows {
+				return []output.Rows{{Rows: [][]string{{"slug", link.Slug}, {"target", req.Target}}}}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&slug, "slug", "", "slug of the link; a random one is generated if not set")
+	cmd.Flags().StringVar(&password, "password", "", "password visitors have to enter to be redirected")
+	cmd.Flags().BoolVar(&req.Interstitial, "interstitial", false, "show a page with the destination before redirecting")
+	cmd.Flags().Uint32Var(&req.InterstitialDelay, "interstitial-delay", 0, "seconds the interstitial page waits before redirecting")
+	cmd.Flags().StringVar(&req.IdempotencyKey, "idempotency-key", "", "key that makes retries of the creation return the same link")
+
+	return cmd
+}
+
+func newgetcmd(flags *globals) *cobra.Command {
+	var query statsquery
+
+	cmd := &cobra.Command{
+		Use:               "get <slug>",
+		Short:             "Show the details of a link and its hits over time",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: flags.completeslugs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := proto.GetLinkReq{Slug: args[0], Granularity: query.granularity, TimeZone: query.timezone, FillGaps: query.fillgaps}
+			if err := query.bounds(&req.From, &req.To); err != nil {
+				return err
+			}
+
+			printer, err := flags.printer(cmd)
+			if err != nil {
+				return err
+			}
+
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			link, err := lnk.GetLink(cmd.Context(), &req)
+			if err != nil {
+				return fmt.Errorf("error getting link: %w", err)
+			}
+
+			return printer.Print(link, func() []output.Rows {
+				details := output.Rows{
+					Rows: [][]string{
+						{"slug", link.Slug},
+						{"target", link.Target},
+						{"hits", strconv.FormatUint(link.Hits, 10)},
+						{"unique visitors", strconv.FormatUint(link.UniqueVisitors, 10)},
+						{"bot hits", strconv.FormatUint(link.BotHits, 10)},
+						{"protected", strconv.FormatBool(link.Protected)},
+						{"interstitial", strconv.FormatBool(link.Interstitial)},
+						{"created at", formattime(link.CreatedAt)},
+					},
+				}
+				for _, split := range link.Split {
+					details.Rows = append(details.Rows, []string{"split target", fmt.Sprintf("%s (weight %d, %d hits)", split.Target, split.Weight, split.Hits)})
+				}
+				for _, rule := range link.Rules {
+					details.Rows = append(details.Rows, []string{"rule target", fmt.Sprintf("%s (%d hits)", rule.Target, rule.Hits)})
+				}
+
+				return []output.Rows{details, histogram(link.Stats)}
+			})
+		},
+	}
+
+	query.register(cmd)
+
+	return cmd
+}
+
+func newlistcmd(flags *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List every link",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := flags.printer(cmd)
+			if err != nil {
+				return err
+			}
+
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			links, err := lnk.ListLinks(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("error listing links: %w", err)
+			}
+
+			return printer.Print(links, func() []output.Rows {
+				table := output.Rows{Headers: []string{"slug", "target", "hits", "created at"}}
+				for _, link := range links.Links {
+					table.Rows = append(table.Rows, []string{link.Slug, link.Target, strconv.FormatUint(link.Hits, 10), formattime(link.CreatedAt)})
+				}
+				return []output.Rows{table}
+			})
+		},
+	}
+}
+
+func newdeletecmd(flags *globals) *cobra.Command {
+	var transactional bool
+
+	cmd := &cobra.Command{
+		Use:               "delete <slug>...",
+		Aliases:           []string{"rm"},
+		Short:             "Delete links",
+		Long:              "Delete links, all of them on a single call. Links that don't exist are reported as failed.",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: flags.completeslugs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := flags.printer(cmd)
+			if err != nil {
+				return err
+			}
+
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			resp, err := lnk.BatchDeleteLinks(cmd.Context(), &proto.BatchDeleteLinksReq{Slugs: args, Transactional: transactional})
+			if err != nil {
+				return fmt.Errorf("error deleting links: %w", err)
+			}
+
+			err = printer.Print(resp, func() []output.Rows {
+				return []output.Rows{batchtable(resp.Results)}
+			})
+			if err != nil {
+				return err
+			}
+
+			if resp.Failed > 0 {
+				return fmt.Errorf("%d of %d links failed to be deleted", resp.Failed, len(args))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&transactional, "transactional", false, "delete every link or none of them")
+
+	return cmd
+}
+
+func newstatscmd(flags *globals) *cobra.Command {
+	var query statsquery
+	var limit uint32
+
+	cmd := &cobra.Command{
+		Use:   "stats [slug]",
+		Short: "Show the breakdown of the hits of a link, or the overview of every link",
+		Example: "  lnkctl stats\n" +
+			"  lnkctl stats example --from 2022-06-01 --granularity week",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: flags.completeslugs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			printer, err := flags.printer(cmd)
+			if err != nil {
+				return err
+			}
+
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			if len(args) == 0 {
+				req := proto.OverviewReq{Granularity: query.granularity, TimeZone: query.timezone, FillGaps: query.fillgaps, Limit: limit}
+				if err := query.bounds(&req.From, &req.To); err != nil {
+					return err
+				}
+
+				overview, err := lnk.GetOverview(cmd.Context(), &req)
+				if err != nil {
+					return fmt.Errorf("error getting overview: %w", err)
+				}
+
+				return printer.Print(overview, func() []output.Rows {
+					totals := output.Rows{
+						Rows: [][]string{
+							{"links", strconv.FormatUint(overview.TotalLinks, 10)},
+							{"hits", strconv.FormatUint(overview.TotalHits, 10)},
+							{"bot hits", strconv.FormatUint(overview.BotHits, 10)},
+							{"unvisited links", strconv.FormatUint(overview.TotalUnvisited, 10)},
+						},
+					}
+					top := output.Rows{Headers: []string{"top link", "target", "hits"}}
+					for _, link := range overview.TopLinks {
+						top.Rows = append(top.Rows, []string{link.Slug, link.Target, strconv.FormatUint(link.Hits, 10)})
+					}
+					return []output.Rows{totals, top, histogram(overview.Histogram)}
+				})
+			}
+
+			req := proto.LinkStatsReq{Slug: args[0], Granularity: query.granularity, TimeZone: query.timezone, FillGaps: query.fillgaps}
+			if err := query.bounds(&req.From, &req.To); err != nil {
+				return err
+			}
+
+			stats, err := lnk.GetLinkStats(cmd.Context(), &req)
+			if err != nil {
+				return fmt.Errorf("error getting stats: %w", err)
+			}
+
+			return printer.Print(stats, func() []output.Rows {
+				tables := []output.Rows{{
+					Rows: [][]string{
+						{"slug", stats.Slug},
+						{"hits", strconv.FormatUint(stats.Hits, 10)},
+						{"unique visitors", strconv.FormatUint(stats.UniqueVisitors, 10)},
+					},
+				}}
+
+				breakdowns := []struct {
+					name    string
+					entries []*proto.BreakdownEntry
+				}{
+					{"referrer", stats.Referrers},
+					{"browser", stats.Browsers},
+					{"operating system", stats.OperatingSystems},
+					{"device", stats.Devices},
+					{"country", stats.Countries},
+				}
+				for _, breakdown := range breakdowns {
+					if len(breakdown.entries) == 0 {
+						continue
+					}
+					table := output.Rows{Headers: []string{breakdown.name, "hits"}}
+					for _, entry := range breakdown.entries {
+						table.Rows = append(table.Rows, []string{entry.Value, strconv.FormatUint(entry.Hits, 10)})
+					}
+					tables = append(tables, table)
+				}
+
+				return append(tables, histogram(stats.Histogram))
+			})
+		},
+	}
+
+	query.register(cmd)
+	cmd.Flags().Uint32Var(&limit, "limit", 0, "amount of links on the rankings of the overview")
+
+	return cmd
+}
+
+// statsquery are the flags that shape the histograms of the stats.
+type statsquery struct {
+	from, to    string
+	granularity string
+	timezone    string
+	fillgaps    bool
+}
+
+func (q *statsquery) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&q.from, "from", "", "inclusive start of the time range, as rfc3339 or yyyy-mm-dd")
+	cmd.Flags().StringVar(&q.to, "to", "", "exclusive end of the time range, as rfc3339 or yyyy-mm-dd")
+	cmd.Flags().StringVar(&q.granularity, "granularity", "", "size of the histogram buckets; hour, day, week or month")
+	cmd.Flags().StringVar(&q.timezone, "time-zone", "", "iana time zone the buckets are aligned to")
+	cmd.Flags().BoolVar(&q.fillgaps, "fill-gaps", false, "include the buckets without hits")
+
+	_ = cmd.RegisterFlagCompletionFunc("granularity", fixedcompletion("hour", "day", "week", "month"))
+}
+
+// bounds parses the time range of the query into the timestamps of a request.
+func (q *statsquery) bounds(from, to **timestamppb.Timestamp) error {
+	for _, bound := range []struct {
+		name  string
+		value string
+		dest  **timestamppb.Timestamp
+	}{{"from", q.from, from}, {"to", q.to, to}} {
+		if bound.value == "" {
+			continue
+		}
+
+		parsed, err := parsetime(bound.value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", bound.name, err)
+		}
+		*bound.dest = timestamppb.New(parsed)
+	}
+
+	return nil
+}
+
+// parsetime parses rfc3339 timestamps, or dates which stand for midnight utc.
+func parsetime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a rfc3339 timestamp nor a yyyy-mm-dd date", value)
+	}
+
+	return parsed, nil
+}
+
+// histogram tabulates the buckets of a histogram.
+func histogram(buckets []*proto.DailyHits) output.Rows {
+	table := output.Rows{Headers: []string{"date", "hits", "unique visitors"}}
+	for _, bucket := range buckets {
+		unique := "-"
+		if bucket.UniqueVisitors != nil {
+			unique = strconv.FormatUint(*bucket.UniqueVisitors, 10)
+		}
+		table.Rows = append(table.Rows, []string{bucket.Date, strconv.FormatUint(bucket.Hits, 10), unique})
+	}
+
+	return table
+}
+
+// batchtable tabulates the results of a batch.
+func batchtable(results []*proto.BatchResult) output.Rows {
+	table := output.Rows{Headers: []string{"slug", "result"}}
+	for _, result := range results {
+		outcome := "ok"
+		if result.Error != "" {
+			outcome = result.Error
+		}
+		table.Rows = append(table.Rows, []string{result.Slug, outcome})
+	}
+
+	return table
+}
+
+func formattime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
+
+// completeslugs completes the arguments with the slugs of the links on the server.
+func (g *globals) completeslugs(cmd *cobra.Command, args []string, prefix string) ([]string, cobra.ShellCompDirective) {
+	lnk, err := g.connect()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	defer lnk.Close()
+
+	links, err := lnk.ListLinks(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	given := make(map[string]bool, len(args))
+	for _, arg := range args {
+		given[arg] = true
+	}
+
+	var slugs []string
+	for _, link := range links.Links {
+		if strings.HasPrefix(link.Slug, prefix) && !given[link.Slug] {
+			slugs = append(slugs, link.Slug)
+		}
+	}
+
+	return slugs, cobra.ShellCompDirectiveNoFileComp
+}
@@ -24,7 +24,7 @@ func Build(ctx context.Context) error {
 
 // Run the lnk server without building it
 func Run(ctx context.Context) error {
-	return run(ctx, "go", "run", ".")
+	return run(ctx, "go", "run", ".", "serve")
 }
 
 // Generate proto definitions and code
@@ -2,75 +2,121 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
-	"net"
-	"net/http"
-	"strings"
-
-	gateway "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/reflection"
-
-	"github.com/aexvir/lnk/internal/logging"
-	"github.com/aexvir/lnk/internal/storage"
-	"github.com/aexvir/lnk/internal/svc"
-	"github.com/aexvir/lnk/proto"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	_ "time/tzdata" // stats can be requested on any time zone, even if the host has no tz database
+
+	"github.com/spf13/cobra"
+
+	"github.com/aexvir/lnk/client"
+	"github.com/aexvir/lnk/internal/output"
 )
 
-const port = 8000
-const grpcaddr = ":9000"
+// globals are the flags shared by every command talking to a server.
+type globals struct {
+	server string
+	apikey string
+	output string
+}
 
 func main() {
-	log := logging.NewLogger("server")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	listener, err := net.Listen("tcp", grpcaddr)
-	if err != nil {
-		panic(err)
+	if err := newrootcmd().ExecuteContext(ctx); err != nil {
+		stop()
+		os.Exit(1)
 	}
+}
 
-	store, err := storage.NewMemoryStorage()
-	if err != nil {
-		panic(err)
+func newrootcmd() *cobra.Command {
+	var flags globals
+
+	root := &cobra.Command{
+		Use:   "lnkctl",
+		Short: "Run and manage lnk, a link shortener",
+		Long: "Run and manage lnk, a link shortener.\n\n" +
+			"The server to manage is reached over the rest api for http:// and https:// urls,\n" +
+			"and over grpc for grpc:// and grpcs:// ones, e.g. grpc://localhost:9000.",
+		SilenceUsage: true,
 	}
 
-	grpcsrv := grpc.NewServer()
-	linksvc := svc.NewLinksService(store)
+	root.PersistentFlags().StringVarP(&flags.server, "server", "s", envdefault("LNK_SERVER", "http://localhost:8000"), "url of the lnk server [$LNK_SERVER]")
+	root.PersistentFlags().StringVar(&flags.apikey, "api-key", os.Getenv("LNK_API_KEY"), "api key sent as bearer token [$LNK_API_KEY]")
+	root.PersistentFlags().StringVarP(&flags.output, "output", "o", envdefault("LNK_OUTPUT", string(output.Table)), "output format; table, json or yaml [$LNK_OUTPUT]")
 
-	proto.RegisterLinksServer(grpcsrv, &linksvc)
-	reflection.Register(grpcsrv)
+	_ = root.RegisterFlagCompletionFunc("output", fixedcompletion(output.Formats...))
 
-	go func() {
-		err := grpcsrv.Serve(listener)
-		if err != nil {
-			panic(err)
-		}
-	}()
+	root.AddCommand(
+		newservecmd(),
+		newcreatecmd(&flags),
+		newgetcmd(&flags),
+		newlistcmd(&flags),
+		newdeletecmd(&flags),
+		newstatscmd(&flags),
+		newexportcmd(&flags),
+		newimportcmd(&flags),
+		newsnapshotcmd(&flags),
+		newrestorecmd(&flags),
+	)
 
-	apimux := gateway.NewServeMux()
-	rpcopts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-	err = proto.RegisterLinksHandlerFromEndpoint(context.Background(), apimux, grpcaddr, rpcopts)
+	return root
+}
+
+// connect instantiates a client for the server set via flags.
+func (g *globals) connect() (client.Client, error) {
+	endpoint, err := url.Parse(g.server)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("invalid server url: %w", err)
 	}
 
-	mux := http.NewServeMux()
+	var opts []client.ClientOpt
 
-	// todo: replace with different mux that allows more advanced routing
-	mux.HandleFunc("/api/docs", svc.OpenapiDocsHandler)
-	mux.HandleFunc("/api/schema.yaml", svc.OpenapiSchemaHandler)
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasPrefix(r.URL.Path, "/api") {
-			apimux.ServeHTTP(w, r)
-			return
+	switch endpoint.Scheme {
+	case "http", "https":
+		opts = append(opts, client.WithBaseUrl(g.server))
+	case "grpc", "grpcs":
+		opts = append(opts, client.WithTransport(client.TransportGRPC), client.WithTarget(endpoint.Host))
+		if endpoint.Scheme == "grpcs" {
+			opts = append(opts, client.WithTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
 		}
-		svc.LinkRedirectHandler(store)(w, r)
-	})
+	default:
+		return nil, fmt.Errorf("unsupported server url %q, expected an http, https, grpc or grpcs one", g.server)
+	}
 
-	log.Write("startup", "listening on port %d", port)
+	if g.apikey != "" {
+		opts = append(opts, client.WithMetadata("authorization", "Bearer "+g.apikey))
+	}
+
+	return client.New(opts...)
+}
 
-	err = http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
+// printer writes the results of the command on the output format set via flags.
+func (g *globals) printer(cmd *cobra.Command) (*output.Printer, error) {
+	format, err := output.ParseFormat(g.output)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	return output.NewPrinter(cmd.OutOrStdout(), format), nil
+}
+
+// envdefault returns the value of the environment variable, or the fallback if unset.
+func envdefault(name, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+
+	return fallback
+}
+
+// fixedcompletion completes flags that take one of the values.
+func fixedcompletion(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
 	}
 }
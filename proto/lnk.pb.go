@@ -9,9 +9,11 @@ package proto
 import (
 	_ "github.com/google/gnostic/openapiv3"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -32,10 +34,28 @@ type LinkDetails struct {
 	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
 	// Target url where the link is redirecting to.
 	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
-	// Total amount of hits for this link.
+	// Total amount of hits for this link, excluding the ones from bots.
 	Hits uint64 `protobuf:"varint,3,opt,name=hits,proto3" json:"hits,omitempty"`
-	// Daily breakdown of the hits.
+	// Breakdown of the hits over time; daily in utc unless requested otherwise.
+	// Always sorted chronologically, oldest bucket first.
 	Stats []*DailyHits `protobuf:"bytes,4,rep,name=stats,proto3" json:"stats,omitempty"`
+	// Conditional redirect rules of the link, in evaluation order.
+	Rules []*RedirectRule `protobuf:"bytes,5,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Weighted targets the visits are split between, with the hits each of them received.
+	Split []*SplitTarget `protobuf:"bytes,6,rep,name=split,proto3" json:"split,omitempty"`
+	// Whether a passphrase is needed to follow the link.
+	Protected bool `protobuf:"varint,7,opt,name=protected,proto3" json:"protected,omitempty"`
+	// Time when the link was created.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Whether visitors go through a warning page before being redirected.
+	Interstitial bool `protobuf:"varint,9,opt,name=interstitial,proto3" json:"interstitial,omitempty"`
+	// Seconds the warning page waits before redirecting; zero means visitors have to click through.
+	InterstitialDelay uint32 `protobuf:"varint,10,opt,name=interstitial_delay,json=interstitialDelay,proto3" json:"interstitial_delay,omitempty"`
+	// Approximate amount of distinct visitors of the link.
+	UniqueVisitors uint64 `protobuf:"varint,11,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	// Amount of hits from bots, link preview fetchers and prefetching browsers, which
+	// are excluded from every other counter.
+	BotHits uint64 `protobuf:"varint,12,opt,name=bot_hits,json=botHits,proto3" json:"bot_hits,omitempty"`
 }
 
 func (x *LinkDetails) Reset() {
@@ -98,6 +118,62 @@ func (x *LinkDetails) GetStats() []*DailyHits {
 	return nil
 }
 
+func (x *LinkDetails) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *LinkDetails) GetSplit() []*SplitTarget {
+	if x != nil {
+		return x.Split
+	}
+	return nil
+}
+
+func (x *LinkDetails) GetProtected() bool {
+	if x != nil {
+		return x.Protected
+	}
+	return false
+}
+
+func (x *LinkDetails) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LinkDetails) GetInterstitial() bool {
+	if x != nil {
+		return x.Interstitial
+	}
+	return false
+}
+
+func (x *LinkDetails) GetInterstitialDelay() uint32 {
+	if x != nil {
+		return x.InterstitialDelay
+	}
+	return 0
+}
+
+func (x *LinkDetails) GetUniqueVisitors() uint64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *LinkDetails) GetBotHits() uint64 {
+	if x != nil {
+		return x.BotHits
+	}
+	return 0
+}
+
 type CreateLinkReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -106,7 +182,26 @@ type CreateLinkReq struct {
 	// Target url where to redirect when visiting the shortened link.
 	Target string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
 	// Custom slug to use on the shortened link instead of generating a random one.
+	// It can only have letters, digits, `.`, `_`, `~` and `-`, and it can't be `api`.
 	Slug *string `protobuf:"bytes,2,opt,name=slug,proto3,oneof" json:"slug,omitempty"`
+	// Conditional redirect rules, evaluated in order when the link is visited.
+	// The first rule that matches the visit decides the target; if none of them
+	// match, the visit is redirected to the base target url.
+	Rules []*RedirectRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Weighted targets to split the visits between, e.g. for A/B testing.
+	// Visitors are assigned to a target proportionally to its weight, and keep being
+	// redirected to the same one on repeated visits. Rules take precedence over the split.
+	Split []*SplitTarget `protobuf:"bytes,4,rep,name=split,proto3" json:"split,omitempty"`
+	// Passphrase visitors have to enter before being redirected. Only a hash of it is stored.
+	Password *string `protobuf:"bytes,5,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	// Show visitors a warning page with the target url before redirecting them.
+	Interstitial bool `protobuf:"varint,6,opt,name=interstitial,proto3" json:"interstitial,omitempty"`
+	// Seconds the warning page waits before redirecting; zero means visitors have to click through.
+	InterstitialDelay uint32 `protobuf:"varint,7,opt,name=interstitial_delay,json=interstitialDelay,proto3" json:"interstitial_delay,omitempty"`
+	// Key that identifies the request, so retrying it returns the link created by the
+	// first attempt instead of creating another one. Keys are remembered for a limited
+	// time, and can also be sent on the Idempotency-Key header.
+	IdempotencyKey string `protobuf:"bytes,8,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 }
 
 func (x *CreateLinkReq) Reset() {
@@ -155,17 +250,66 @@ func (x *CreateLinkReq) GetSlug() string {
 	return ""
 }
 
-type LinkId struct {
+func (x *CreateLinkReq) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *CreateLinkReq) GetSplit() []*SplitTarget {
+	if x != nil {
+		return x.Split
+	}
+	return nil
+}
+
+func (x *CreateLinkReq) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *CreateLinkReq) GetInterstitial() bool {
+	if x != nil {
+		return x.Interstitial
+	}
+	return false
+}
+
+func (x *CreateLinkReq) GetInterstitialDelay() uint32 {
+	if x != nil {
+		return x.InterstitialDelay
+	}
+	return 0
+}
+
+func (x *CreateLinkReq) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type BatchCreateLinksReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Identifier of a redirecting link. Used as the url path for redirects.
-	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Links to create, up to 10000. Their idempotency keys are ignored; the one of the
+	// batch applies to the whole batch.
+	Links []*CreateLinkReq `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	// Create every link or none of them; if any link fails, the rest fail as aborted.
+	Transactional bool `protobuf:"varint,2,opt,name=transactional,proto3" json:"transactional,omitempty"`
+	// Key that identifies the request, so retrying it returns the results of the first
+	// attempt instead of creating the links again. Keys are remembered for a limited
+	// time, and can also be sent on the Idempotency-Key header.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 }
 
-func (x *LinkId) Reset() {
-	*x = LinkId{}
+func (x *BatchCreateLinksReq) Reset() {
+	*x = BatchCreateLinksReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_lnk_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -173,13 +317,13 @@ func (x *LinkId) Reset() {
 	}
 }
 
-func (x *LinkId) String() string {
+func (x *BatchCreateLinksReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LinkId) ProtoMessage() {}
+func (*BatchCreateLinksReq) ProtoMessage() {}
 
-func (x *LinkId) ProtoReflect() protoreflect.Message {
+func (x *BatchCreateLinksReq) ProtoReflect() protoreflect.Message {
 	mi := &file_lnk_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -191,31 +335,47 @@ func (x *LinkId) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LinkId.ProtoReflect.Descriptor instead.
-func (*LinkId) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCreateLinksReq.ProtoReflect.Descriptor instead.
+func (*BatchCreateLinksReq) Descriptor() ([]byte, []int) {
 	return file_lnk_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *LinkId) GetSlug() string {
+func (x *BatchCreateLinksReq) GetLinks() []*CreateLinkReq {
 	if x != nil {
-		return x.Slug
+		return x.Links
+	}
+	return nil
+}
+
+func (x *BatchCreateLinksReq) GetTransactional() bool {
+	if x != nil {
+		return x.Transactional
+	}
+	return false
+}
+
+func (x *BatchCreateLinksReq) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
 	}
 	return ""
 }
 
-type DailyHits struct {
+type BatchCreateLinksResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// ISO8601 formatted date for the day which hits are returned.
-	Date string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
-	// Amount of hits for the link on the specified date.
-	Hits uint64 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	// Result of each of the requested links, in the same order.
+	Results []*BatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Amount of links created.
+	Succeeded uint32 `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// Amount of links that failed to be created, including the aborted ones.
+	Failed uint32 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
 }
 
-func (x *DailyHits) Reset() {
-	*x = DailyHits{}
+func (x *BatchCreateLinksResp) Reset() {
+	*x = BatchCreateLinksResp{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_lnk_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -223,13 +383,13 @@ func (x *DailyHits) Reset() {
 	}
 }
 
-func (x *DailyHits) String() string {
+func (x *BatchCreateLinksResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DailyHits) ProtoMessage() {}
+func (*BatchCreateLinksResp) ProtoMessage() {}
 
-func (x *DailyHits) ProtoReflect() protoreflect.Message {
+func (x *BatchCreateLinksResp) ProtoReflect() protoreflect.Message {
 	mi := &file_lnk_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -241,35 +401,49 @@ func (x *DailyHits) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DailyHits.ProtoReflect.Descriptor instead.
-func (*DailyHits) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCreateLinksResp.ProtoReflect.Descriptor instead.
+func (*BatchCreateLinksResp) Descriptor() ([]byte, []int) {
 	return file_lnk_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *DailyHits) GetDate() string {
+func (x *BatchCreateLinksResp) GetResults() []*BatchResult {
 	if x != nil {
-		return x.Date
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-func (x *DailyHits) GetHits() uint64 {
+func (x *BatchCreateLinksResp) GetSucceeded() uint32 {
 	if x != nil {
-		return x.Hits
+		return x.Succeeded
 	}
 	return 0
 }
 
-type LinkList struct {
+func (x *BatchCreateLinksResp) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type BatchDeleteLinksReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Links []*LinkDetails `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	// Identifiers of the links to delete, up to 10000.
+	Slugs []string `protobuf:"bytes,1,rep,name=slugs,proto3" json:"slugs,omitempty"`
+	// Delete every link or none of them; if any link fails, the rest fail as aborted.
+	Transactional bool `protobuf:"varint,2,opt,name=transactional,proto3" json:"transactional,omitempty"`
+	// Key that identifies the request, so retrying it returns the results of the first
+	// attempt instead of reporting the deleted links as missing. Keys are remembered for a
+	// limited time, and can also be sent on the Idempotency-Key header.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 }
 
-func (x *LinkList) Reset() {
-	*x = LinkList{}
+func (x *BatchDeleteLinksReq) Reset() {
+	*x = BatchDeleteLinksReq{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_lnk_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -277,13 +451,13 @@ func (x *LinkList) Reset() {
 	}
 }
 
-func (x *LinkList) String() string {
+func (x *BatchDeleteLinksReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LinkList) ProtoMessage() {}
+func (*BatchDeleteLinksReq) ProtoMessage() {}
 
-func (x *LinkList) ProtoReflect() protoreflect.Message {
+func (x *BatchDeleteLinksReq) ProtoReflect() protoreflect.Message {
 	mi := &file_lnk_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -295,189 +469,3937 @@ func (x *LinkList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LinkList.ProtoReflect.Descriptor instead.
-func (*LinkList) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchDeleteLinksReq.ProtoReflect.Descriptor instead.
+func (*BatchDeleteLinksReq) Descriptor() ([]byte, []int) {
 	return file_lnk_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *LinkList) GetLinks() []*LinkDetails {
+func (x *BatchDeleteLinksReq) GetSlugs() []string {
 	if x != nil {
-		return x.Links
+		return x.Slugs
 	}
 	return nil
 }
 
-var File_lnk_proto protoreflect.FileDescriptor
+func (x *BatchDeleteLinksReq) GetTransactional() bool {
+	if x != nil {
+		return x.Transactional
+	}
+	return false
+}
 
-var file_lnk_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x6c, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x6c, 0x6e, 0x6b,
-	0x1a, 0x24, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70,
-	0x69, 0x2f, 0x76, 0x33, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xab, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x73, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
-	0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61, 0x27,
-	0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x32, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0xba, 0x47, 0x17, 0x3a, 0x15, 0x12, 0x13, 0x27,
-	0x68, 0x74, 0x74, 0x70, 0x3a, 0x2f, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6f,
-	0x6d, 0x27, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x04, 0x68, 0x69,
-	0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12,
-	0x02, 0x34, 0x32, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x44,
-	0x61, 0x69, 0x6c, 0x79, 0x48, 0x69, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22,
-	0x76, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71,
-	0x12, 0x32, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x42, 0x1a, 0xba, 0x47, 0x17, 0x3a, 0x15, 0x12, 0x13, 0x27, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x2f,
-	0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x27, 0x52, 0x06, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x73, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x27, 0x48, 0x00, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x88, 0x01, 0x01, 0x42, 0x07,
-	0x0a, 0x05, 0x5f, 0x73, 0x6c, 0x75, 0x67, 0x22, 0x2d, 0x0a, 0x06, 0x4c, 0x69, 0x6e, 0x6b, 0x49,
-	0x64, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
-	0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61, 0x27,
-	0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x22, 0x53, 0x0a, 0x09, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x48,
-	0x69, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x42, 0x13, 0xba, 0x47, 0x10, 0x3a, 0x0e, 0x12, 0x0c, 0x27, 0x32, 0x30, 0x32, 0x32, 0x2d,
-	0x30, 0x36, 0x2d, 0x31, 0x31, 0x27, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x04,
-	0x68, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a,
-	0x04, 0x12, 0x02, 0x34, 0x32, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x22, 0x32, 0x0a, 0x08, 0x4c,
-	0x69, 0x6e, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e,
-	0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x32,
-	0x89, 0x03, 0x0a, 0x05, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x59, 0x0a, 0x09, 0x4c, 0x69, 0x73,
-	0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0d,
-	0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x25, 0xba,
-	0x47, 0x10, 0x12, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x6c, 0x69, 0x6e,
-	0x6b, 0x73, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c,
-	0x69, 0x6e, 0x6b, 0x73, 0x12, 0x5e, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69,
-	0x6e, 0x6b, 0x12, 0x12, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c,
-	0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x0b, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e,
-	0x6b, 0x49, 0x64, 0x22, 0x2f, 0xba, 0x47, 0x17, 0x12, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x20, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x65, 0x64, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b,
-	0x73, 0x3a, 0x01, 0x2a, 0x12, 0x5d, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x12,
-	0x0b, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x64, 0x1a, 0x10, 0x2e, 0x6c,
-	0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x33,
-	0xba, 0x47, 0x17, 0x12, 0x15, 0x47, 0x65, 0x74, 0x20, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
-	0x20, 0x6f, 0x66, 0x20, 0x61, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13,
-	0x12, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x2f, 0x7b, 0x73, 0x6c,
-	0x75, 0x67, 0x7d, 0x12, 0x66, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69, 0x6e,
-	0x6b, 0x12, 0x0b, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x64, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x33, 0xba, 0x47, 0x17, 0x12, 0x15, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x20, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x65, 0x64, 0x20, 0x6c, 0x69,
-	0x6e, 0x6b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x2a, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c,
-	0x69, 0x6e, 0x6b, 0x73, 0x2f, 0x7b, 0x73, 0x6c, 0x75, 0x67, 0x7d, 0x42, 0x98, 0x01, 0x5a, 0x09,
-	0x6c, 0x6e, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0xba, 0x47, 0x89, 0x01, 0x12, 0x86, 0x01,
-	0x0a, 0x07, 0x4c, 0x6e, 0x6b, 0x20, 0x41, 0x50, 0x49, 0x12, 0x43, 0x4c, 0x6e, 0x6b, 0x20, 0x69,
-	0x73, 0x20, 0x61, 0x20, 0x62, 0x61, 0x73, 0x69, 0x63, 0x20, 0x75, 0x72, 0x6c, 0x20, 0x73, 0x68,
-	0x6f, 0x72, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x20, 0x74, 0x68, 0x61, 0x74, 0x20, 0x63, 0x61, 0x6e,
-	0x20, 0x62, 0x65, 0x20, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x20, 0x76, 0x69, 0x61, 0x20,
-	0x72, 0x65, 0x73, 0x74, 0x20, 0x61, 0x70, 0x69, 0x20, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x22, 0x2f,
-	0x0a, 0x0e, 0x41, 0x6c, 0x65, 0x78, 0x20, 0x56, 0x69, 0x73, 0x63, 0x72, 0x65, 0x61, 0x6e, 0x75,
-	0x12, 0x1d, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x65, 0x78, 0x76, 0x69, 0x72, 0x2f, 0x6c, 0x6e, 0x6b, 0x32,
-	0x05, 0x30, 0x2e, 0x31, 0x2e, 0x30, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *BatchDeleteLinksReq) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
 }
 
-var (
-	file_lnk_proto_rawDescOnce sync.Once
-	file_lnk_proto_rawDescData = file_lnk_proto_rawDesc
-)
+type BatchDeleteLinksResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_lnk_proto_rawDescGZIP() []byte {
-	file_lnk_proto_rawDescOnce.Do(func() {
-		file_lnk_proto_rawDescData = protoimpl.X.CompressGZIP(file_lnk_proto_rawDescData)
-	})
-	return file_lnk_proto_rawDescData
+	// Result of each of the requested slugs, in the same order.
+	Results []*BatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Amount of links deleted.
+	Succeeded uint32 `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// Amount of links that failed to be deleted, including the aborted ones.
+	Failed uint32 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
 }
 
-var file_lnk_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_lnk_proto_goTypes = []interface{}{
-	(*LinkDetails)(nil),   // 0: lnk.LinkDetails
-	(*CreateLinkReq)(nil), // 1: lnk.CreateLinkReq
-	(*LinkId)(nil),        // 2: lnk.LinkId
-	(*DailyHits)(nil),     // 3: lnk.DailyHits
-	(*LinkList)(nil),      // 4: lnk.LinkList
-	(*emptypb.Empty)(nil), // 5: google.protobuf.Empty
+func (x *BatchDeleteLinksResp) Reset() {
+	*x = BatchDeleteLinksResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_lnk_proto_depIdxs = []int32{
-	3, // 0: lnk.LinkDetails.stats:type_name -> lnk.DailyHits
-	0, // 1: lnk.LinkList.links:type_name -> lnk.LinkDetails
-	5, // 2: lnk.Links.ListLinks:input_type -> google.protobuf.Empty
-	1, // 3: lnk.Links.CreateLink:input_type -> lnk.CreateLinkReq
-	2, // 4: lnk.Links.GetLink:input_type -> lnk.LinkId
-	2, // 5: lnk.Links.DeleteLink:input_type -> lnk.LinkId
-	4, // 6: lnk.Links.ListLinks:output_type -> lnk.LinkList
-	2, // 7: lnk.Links.CreateLink:output_type -> lnk.LinkId
-	0, // 8: lnk.Links.GetLink:output_type -> lnk.LinkDetails
-	5, // 9: lnk.Links.DeleteLink:output_type -> google.protobuf.Empty
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+func (x *BatchDeleteLinksResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_lnk_proto_init() }
-func file_lnk_proto_init() {
-	if File_lnk_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_lnk_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LinkDetails); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_lnk_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateLinkReq); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_lnk_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LinkId); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (*BatchDeleteLinksResp) ProtoMessage() {}
+
+func (x *BatchDeleteLinksResp) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_lnk_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DailyHits); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteLinksResp.ProtoReflect.Descriptor instead.
+func (*BatchDeleteLinksResp) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchDeleteLinksResp) GetResults() []*BatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchDeleteLinksResp) GetSucceeded() uint32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BatchDeleteLinksResp) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type ImportLinksReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Format of the file; `csv`, `ndjson` or `json` for lnk dumps. Guessed from the contents
+	// of the file if not set.
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// File to import, up to 32MB.
+	Data *httpbody.HttpBody `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Columns of the CSV file holding each field of the links, as `field=column` pairs; an
+	// empty column ignores the field. Fields are `slug`, `target`, `hits`, `bot_hits`,
+	// `created_at` and `date`; with a `date` column, every row holds the hits of a link on
+	// that date, like the CSV export. Columns named after a field, or after its usual names
+	// on other shorteners, like `url` or `clicks`, are mapped by default.
+	Columns []string `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
+	// What to do with the links whose slug already exists; `skip` them, which is the default,
+	// `overwrite` the existing links, or `rename` them by adding a numeric suffix to the slug.
+	Conflicts string `protobuf:"bytes,4,opt,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// Validate the file and report what would be imported, without importing anything.
+	DryRun bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *ImportLinksReq) Reset() {
+	*x = ImportLinksReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportLinksReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportLinksReq) ProtoMessage() {}
+
+func (x *ImportLinksReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportLinksReq.ProtoReflect.Descriptor instead.
+func (*ImportLinksReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImportLinksReq) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportLinksReq) GetData() *httpbody.HttpBody {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportLinksReq) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *ImportLinksReq) GetConflicts() string {
+	if x != nil {
+		return x.Conflicts
+	}
+	return ""
+}
+
+func (x *ImportLinksReq) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportLinksResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Result of each of the links of the file, in the same order.
+	Results []*ImportResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Amount of links created with their own slug, or a generated one if they had none.
+	Created uint32 `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	// Amount of existing links overwritten.
+	Overwritten uint32 `protobuf:"varint,3,opt,name=overwritten,proto3" json:"overwritten,omitempty"`
+	// Amount of links created with another slug, as theirs already existed.
+	Renamed uint32 `protobuf:"varint,4,opt,name=renamed,proto3" json:"renamed,omitempty"`
+	// Amount of links skipped, as their slug already existed.
+	Skipped uint32 `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// Amount of links that failed to be imported, mostly because they're invalid.
+	Failed uint32 `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Whether nothing was imported, as requested.
+	DryRun bool `protobuf:"varint,7,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *ImportLinksResp) Reset() {
+	*x = ImportLinksResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportLinksResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportLinksResp) ProtoMessage() {}
+
+func (x *ImportLinksResp) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportLinksResp.ProtoReflect.Descriptor instead.
+func (*ImportLinksResp) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImportLinksResp) GetResults() []*ImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportLinksResp) GetCreated() uint32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportLinksResp) GetOverwritten() uint32 {
+	if x != nil {
+		return x.Overwritten
+	}
+	return 0
+}
+
+func (x *ImportLinksResp) GetRenamed() uint32 {
+	if x != nil {
+		return x.Renamed
+	}
+	return 0
+}
+
+func (x *ImportLinksResp) GetSkipped() uint32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportLinksResp) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportLinksResp) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Line of the file the link starts at, or its position on the list of a JSON dump.
+	Line uint32 `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	// Slug the link was imported with.
+	Slug string `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Slug the link had on the file, if it was imported with another one.
+	OriginalSlug string `protobuf:"bytes,3,opt,name=original_slug,json=originalSlug,proto3" json:"original_slug,omitempty"`
+	// Target url of the link.
+	Target string `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	// What happened to the link; `created`, `overwritten`, `renamed`, `skipped` or `failed`.
+	Outcome string `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	// Reason the link failed to be imported; empty unless the outcome is `failed`.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ImportResult) Reset() {
+	*x = ImportResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResult) ProtoMessage() {}
+
+func (x *ImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResult.ProtoReflect.Descriptor instead.
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ImportResult) GetLine() uint32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportResult) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ImportResult) GetOriginalSlug() string {
+	if x != nil {
+		return x.OriginalSlug
+	}
+	return ""
+}
+
+func (x *ImportResult) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ImportResult) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *ImportResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the link the result refers to; empty for links that failed to be
+	// created without a custom slug.
+	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Reason the operation failed on this link; empty if it succeeded.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// Whether the operation failed on this link only because other links of a transactional
+	// batch failed.
+	Aborted bool `protobuf:"varint,3,opt,name=aborted,proto3" json:"aborted,omitempty"`
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchResult) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *BatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchResult) GetAborted() bool {
+	if x != nil {
+		return x.Aborted
+	}
+	return false
+}
+
+type RedirectRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Condition the visit has to meet for this rule to apply.
+	Condition *RuleCondition `protobuf:"bytes,1,opt,name=condition,proto3" json:"condition,omitempty"`
+	// Alternative target url used when the condition matches.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Amount of visits redirected by this rule. Ignored when creating a link.
+	Hits uint64 `protobuf:"varint,3,opt,name=hits,proto3" json:"hits,omitempty"`
+}
+
+func (x *RedirectRule) Reset() {
+	*x = RedirectRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedirectRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectRule) ProtoMessage() {}
+
+func (x *RedirectRule) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedirectRule.ProtoReflect.Descriptor instead.
+func (*RedirectRule) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RedirectRule) GetCondition() *RuleCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+type SplitTarget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Target url for this arm of the split.
+	Target string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	// Relative weight of this arm; the share of visits it receives is its weight
+	// divided by the sum of all the weights.
+	Weight uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Amount of visits redirected to this arm. Ignored when creating a link.
+	Hits uint64 `protobuf:"varint,3,opt,name=hits,proto3" json:"hits,omitempty"`
+}
+
+func (x *SplitTarget) Reset() {
+	*x = SplitTarget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SplitTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitTarget) ProtoMessage() {}
+
+func (x *SplitTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitTarget.ProtoReflect.Descriptor instead.
+func (*SplitTarget) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SplitTarget) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SplitTarget) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *SplitTarget) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+type RuleCondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Devices the visitor has to be using, any of `ios`, `android` or `desktop`.
+	Devices []string `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Languages the visitor has to prefer, matched against the most preferred language
+	// of the `Accept-Language` header. A language without region matches all its regions.
+	Languages []string `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"`
+	// ISO 3166-1 alpha-2 codes of the countries the visitor has to come from, as
+	// reported by the edge proxy.
+	Countries []string `protobuf:"bytes,3,rep,name=countries,proto3" json:"countries,omitempty"`
+	// The visit has to happen at or after this time.
+	After *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	// The visit has to happen before this time.
+	Before *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+}
+
+func (x *RuleCondition) Reset() {
+	*x = RuleCondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuleCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleCondition) ProtoMessage() {}
+
+func (x *RuleCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleCondition.ProtoReflect.Descriptor instead.
+func (*RuleCondition) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RuleCondition) GetDevices() []string {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *RuleCondition) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *RuleCondition) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *RuleCondition) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *RuleCondition) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+type LinkId struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of a redirecting link. Used as the url path for redirects.
+	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+}
+
+func (x *LinkId) Reset() {
+	*x = LinkId{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkId) ProtoMessage() {}
+
+func (x *LinkId) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkId.ProtoReflect.Descriptor instead.
+func (*LinkId) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LinkId) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type LinkQRReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the link the QR code points to.
+	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Image format, either `png` or `svg`. Defaults to `png`.
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	// Side of the image in pixels. Defaults to 256, and can be at most 2048.
+	Size uint32 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Error correction level, one of `L`, `M`, `Q` or `H`. Defaults to `M`.
+	Level string `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	// Width of the quiet zone around the code, in modules. Defaults to 4.
+	Margin *uint32 `protobuf:"varint,5,opt,name=margin,proto3,oneof" json:"margin,omitempty"`
+	// Hex color of the code modules. Defaults to `000000`.
+	Foreground string `protobuf:"bytes,6,opt,name=foreground,proto3" json:"foreground,omitempty"`
+	// Hex color of the background. Defaults to `ffffff`.
+	Background string `protobuf:"bytes,7,opt,name=background,proto3" json:"background,omitempty"`
+}
+
+func (x *LinkQRReq) Reset() {
+	*x = LinkQRReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkQRReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkQRReq) ProtoMessage() {}
+
+func (x *LinkQRReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkQRReq.ProtoReflect.Descriptor instead.
+func (*LinkQRReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LinkQRReq) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *LinkQRReq) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *LinkQRReq) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *LinkQRReq) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LinkQRReq) GetMargin() uint32 {
+	if x != nil && x.Margin != nil {
+		return *x.Margin
+	}
+	return 0
+}
+
+func (x *LinkQRReq) GetForeground() string {
+	if x != nil {
+		return x.Foreground
+	}
+	return ""
+}
+
+func (x *LinkQRReq) GetBackground() string {
+	if x != nil {
+		return x.Background
+	}
+	return ""
+}
+
+type GetLinkReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the link to get.
+	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Inclusive start of the time range of the hits breakdown. Unbounded if not set.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Exclusive end of the time range of the hits breakdown. Unbounded if not set.
+	To *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Size of the buckets of the hits breakdown; `hour`, `day`, `week` or `month`.
+	// Defaults to `day`.
+	Granularity string `protobuf:"bytes,4,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	// IANA time zone the buckets of the hits breakdown are aligned to. Defaults to `UTC`.
+	TimeZone string `protobuf:"bytes,5,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Include the buckets without hits on the hits breakdown, with zero hits. The buckets
+	// span the requested time range; from the first bucket with hits if there's no start,
+	// and until now if there's no end.
+	// At most 10000 buckets can be filled; longer ranges are rejected, and if the hits go
+	// further back than that, only the buckets with hits are included.
+	FillGaps bool `protobuf:"varint,6,opt,name=fill_gaps,json=fillGaps,proto3" json:"fill_gaps,omitempty"`
+}
+
+func (x *GetLinkReq) Reset() {
+	*x = GetLinkReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLinkReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkReq) ProtoMessage() {}
+
+func (x *GetLinkReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkReq.ProtoReflect.Descriptor instead.
+func (*GetLinkReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetLinkReq) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *GetLinkReq) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetLinkReq) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetLinkReq) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *GetLinkReq) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *GetLinkReq) GetFillGaps() bool {
+	if x != nil {
+		return x.FillGaps
+	}
+	return false
+}
+
+type ExportStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifiers of the links to export. All links are exported if empty, and repeated
+	// ones are exported once.
+	Slugs []string `protobuf:"bytes,1,rep,name=slugs,proto3" json:"slugs,omitempty"`
+	// Format of the export, either `csv` or `ndjson`. Defaults to `csv`.
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	// Inclusive start of the time range of the exported hits. Unbounded if not set.
+	From *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// Exclusive end of the time range of the exported hits. Unbounded if not set.
+	To *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Size of the buckets of the exported hits; `hour`, `day`, `week` or `month`.
+	// Defaults to `day`.
+	Granularity string `protobuf:"bytes,5,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	// IANA time zone the buckets of the exported hits are aligned to. Defaults to `UTC`.
+	TimeZone string `protobuf:"bytes,6,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Include the buckets without hits, with zero hits. The buckets span the requested
+	// time range; from the first bucket with hits if there's no start, and until now if
+	// there's no end.
+	// At most 10000 buckets can be filled; longer ranges are rejected, and if the hits go
+	// further back than that, only the buckets with hits are included.
+	FillGaps bool `protobuf:"varint,7,opt,name=fill_gaps,json=fillGaps,proto3" json:"fill_gaps,omitempty"`
+}
+
+func (x *ExportStatsReq) Reset() {
+	*x = ExportStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStatsReq) ProtoMessage() {}
+
+func (x *ExportStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStatsReq.ProtoReflect.Descriptor instead.
+func (*ExportStatsReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExportStatsReq) GetSlugs() []string {
+	if x != nil {
+		return x.Slugs
+	}
+	return nil
+}
+
+func (x *ExportStatsReq) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportStatsReq) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ExportStatsReq) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ExportStatsReq) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *ExportStatsReq) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *ExportStatsReq) GetFillGaps() bool {
+	if x != nil {
+		return x.FillGaps
+	}
+	return false
+}
+
+type OverviewReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Inclusive start of the time range of the hits breakdown and top links. Unbounded if not set.
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Exclusive end of the time range of the hits breakdown and top links. Unbounded if not set.
+	To *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Size of the buckets of the hits breakdown; `hour`, `day`, `week` or `month`.
+	// Defaults to `day`.
+	Granularity string `protobuf:"bytes,3,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	// IANA time zone the buckets of the hits breakdown are aligned to. Defaults to `UTC`.
+	TimeZone string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Include the buckets without hits on the hits breakdown, with zero hits.
+	FillGaps bool `protobuf:"varint,5,opt,name=fill_gaps,json=fillGaps,proto3" json:"fill_gaps,omitempty"`
+	// Maximum amount of links on each of the link lists. Defaults to 10, and can be at most 100.
+	Limit uint32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *OverviewReq) Reset() {
+	*x = OverviewReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OverviewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverviewReq) ProtoMessage() {}
+
+func (x *OverviewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverviewReq.ProtoReflect.Descriptor instead.
+func (*OverviewReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OverviewReq) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *OverviewReq) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *OverviewReq) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *OverviewReq) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *OverviewReq) GetFillGaps() bool {
+	if x != nil {
+		return x.FillGaps
+	}
+	return false
+}
+
+func (x *OverviewReq) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Overview struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Amount of shortened links.
+	TotalLinks uint64 `protobuf:"varint,1,opt,name=total_links,json=totalLinks,proto3" json:"total_links,omitempty"`
+	// Amount of hits of all links, excluding the ones from bots.
+	TotalHits uint64 `protobuf:"varint,2,opt,name=total_hits,json=totalHits,proto3" json:"total_hits,omitempty"`
+	// Amount of hits of all links from bots, link preview fetchers and prefetching browsers.
+	BotHits uint64 `protobuf:"varint,3,opt,name=bot_hits,json=botHits,proto3" json:"bot_hits,omitempty"`
+	// Breakdown of the hits of all links over time within the requested range.
+	// Always sorted chronologically, oldest bucket first.
+	Histogram []*DailyHits `protobuf:"bytes,4,rep,name=histogram,proto3" json:"histogram,omitempty"`
+	// Links with the most hits within the requested range, most visited first; their hits
+	// only count the ones within the range.
+	TopLinks []*LinkSummary `protobuf:"bytes,5,rep,name=top_links,json=topLinks,proto3" json:"top_links,omitempty"`
+	// Most recently created links, newest first.
+	NewestLinks []*LinkSummary `protobuf:"bytes,6,rep,name=newest_links,json=newestLinks,proto3" json:"newest_links,omitempty"`
+	// Links that were never visited, oldest first.
+	UnvisitedLinks []*LinkSummary `protobuf:"bytes,7,rep,name=unvisited_links,json=unvisitedLinks,proto3" json:"unvisited_links,omitempty"`
+	// Amount of links that were never visited, including the ones not listed.
+	TotalUnvisited uint64 `protobuf:"varint,8,opt,name=total_unvisited,json=totalUnvisited,proto3" json:"total_unvisited,omitempty"`
+}
+
+func (x *Overview) Reset() {
+	*x = Overview{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Overview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Overview) ProtoMessage() {}
+
+func (x *Overview) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Overview.ProtoReflect.Descriptor instead.
+func (*Overview) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Overview) GetTotalLinks() uint64 {
+	if x != nil {
+		return x.TotalLinks
+	}
+	return 0
+}
+
+func (x *Overview) GetTotalHits() uint64 {
+	if x != nil {
+		return x.TotalHits
+	}
+	return 0
+}
+
+func (x *Overview) GetBotHits() uint64 {
+	if x != nil {
+		return x.BotHits
+	}
+	return 0
+}
+
+func (x *Overview) GetHistogram() []*DailyHits {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *Overview) GetTopLinks() []*LinkSummary {
+	if x != nil {
+		return x.TopLinks
+	}
+	return nil
+}
+
+func (x *Overview) GetNewestLinks() []*LinkSummary {
+	if x != nil {
+		return x.NewestLinks
+	}
+	return nil
+}
+
+func (x *Overview) GetUnvisitedLinks() []*LinkSummary {
+	if x != nil {
+		return x.UnvisitedLinks
+	}
+	return nil
+}
+
+func (x *Overview) GetTotalUnvisited() uint64 {
+	if x != nil {
+		return x.TotalUnvisited
+	}
+	return 0
+}
+
+type LinkSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the link.
+	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Target url where the link is redirecting to.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Amount of hits of the link, excluding the ones from bots.
+	Hits uint64 `protobuf:"varint,3,opt,name=hits,proto3" json:"hits,omitempty"`
+	// Time the link was created at.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *LinkSummary) Reset() {
+	*x = LinkSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkSummary) ProtoMessage() {}
+
+func (x *LinkSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkSummary.ProtoReflect.Descriptor instead.
+func (*LinkSummary) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LinkSummary) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *LinkSummary) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *LinkSummary) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *LinkSummary) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type WatchHitsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifiers of the links to watch. Visits to every link are streamed if empty.
+	Slugs []string `protobuf:"bytes,1,rep,name=slugs,proto3" json:"slugs,omitempty"`
+}
+
+func (x *WatchHitsReq) Reset() {
+	*x = WatchHitsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchHitsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchHitsReq) ProtoMessage() {}
+
+func (x *WatchHitsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchHitsReq.ProtoReflect.Descriptor instead.
+func (*WatchHitsReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchHitsReq) GetSlugs() []string {
+	if x != nil {
+		return x.Slugs
+	}
+	return nil
+}
+
+type HitEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the visited link.
+	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Url the visitor was redirected to.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Time of the visit.
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Host of the page the visitor came from; `direct` if it didn't come from another page.
+	Referrer string `protobuf:"bytes,4,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	// Browser used by the visitor.
+	Browser string `protobuf:"bytes,5,opt,name=browser,proto3" json:"browser,omitempty"`
+	// Operating system used by the visitor.
+	OperatingSystem string `protobuf:"bytes,6,opt,name=operating_system,json=operatingSystem,proto3" json:"operating_system,omitempty"`
+	// Class of device used by the visitor; `desktop`, `mobile`, `tablet` or `bot`.
+	Device string `protobuf:"bytes,7,opt,name=device,proto3" json:"device,omitempty"`
+	// Country the visitor came from, as reported by the edge proxy.
+	Country string `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	// Whether the visit came from a bot, link preview fetcher or prefetching browser.
+	Bot bool `protobuf:"varint,9,opt,name=bot,proto3" json:"bot,omitempty"`
+	// Amount of visits skipped so far on this stream because the client wasn't keeping up.
+	Dropped uint64 `protobuf:"varint,10,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *HitEvent) Reset() {
+	*x = HitEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HitEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HitEvent) ProtoMessage() {}
+
+func (x *HitEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HitEvent.ProtoReflect.Descriptor instead.
+func (*HitEvent) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *HitEvent) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *HitEvent) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *HitEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *HitEvent) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *HitEvent) GetBrowser() string {
+	if x != nil {
+		return x.Browser
+	}
+	return ""
+}
+
+func (x *HitEvent) GetOperatingSystem() string {
+	if x != nil {
+		return x.OperatingSystem
+	}
+	return ""
+}
+
+func (x *HitEvent) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *HitEvent) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *HitEvent) GetBot() bool {
+	if x != nil {
+		return x.Bot
+	}
+	return false
+}
+
+func (x *HitEvent) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type WatchLinksReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sequence number of the last change the client got; the changes after it are streamed.
+	// If not set, only the changes from now on are streamed.
+	// Fails if the changes after it are no longer available, or if it's ahead of the latest
+	// change, e.g. because the service started over; the links must be reloaded then.
+	AfterSequence *uint64 `protobuf:"varint,1,opt,name=after_sequence,json=afterSequence,proto3,oneof" json:"after_sequence,omitempty"`
+	// Whether to stream the milestones reached by the links too, which don't change them.
+	Milestones bool `protobuf:"varint,2,opt,name=milestones,proto3" json:"milestones,omitempty"`
+}
+
+func (x *WatchLinksReq) Reset() {
+	*x = WatchLinksReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchLinksReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLinksReq) ProtoMessage() {}
+
+func (x *WatchLinksReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLinksReq.ProtoReflect.Descriptor instead.
+func (*WatchLinksReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchLinksReq) GetAfterSequence() uint64 {
+	if x != nil && x.AfterSequence != nil {
+		return *x.AfterSequence
+	}
+	return 0
+}
+
+func (x *WatchLinksReq) GetMilestones() bool {
+	if x != nil {
+		return x.Milestones
+	}
+	return false
+}
+
+type LinkChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sequence number of the change, increasing with every change.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Kind of change; `created`, `updated`, `deleted` or `milestone`, or `restored` when the
+	// whole store was restored from a snapshot, which has no slug and means every link may
+	// have changed.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Identifier of the changed link; empty for `restored` changes.
+	Slug string `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Time the change was done at.
+	Time *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// State of the link right after the change, without stats or unique visitors; not set
+	// for deletions.
+	Link *LinkDetails `protobuf:"bytes,5,opt,name=link,proto3" json:"link,omitempty"`
+}
+
+func (x *LinkChange) Reset() {
+	*x = LinkChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkChange) ProtoMessage() {}
+
+func (x *LinkChange) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkChange.ProtoReflect.Descriptor instead.
+func (*LinkChange) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LinkChange) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *LinkChange) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *LinkChange) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *LinkChange) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *LinkChange) GetLink() *LinkDetails {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+type CreateWebhookReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Url the events are sent to.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Events to subscribe to; `link.created`, `link.updated`, `link.deleted` or `link.milestone`.
+	// Subscribes to all of them if empty. There's no expiry event, as links don't expire.
+	Events []string `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	// Identifiers of the links whose events are sent. Events of every link are sent if empty.
+	Slugs []string `protobuf:"bytes,3,rep,name=slugs,proto3" json:"slugs,omitempty"`
+	// Secret the payloads are signed with. A random one is generated if not set.
+	Secret *string `protobuf:"bytes,4,opt,name=secret,proto3,oneof" json:"secret,omitempty"`
+}
+
+func (x *CreateWebhookReq) Reset() {
+	*x = CreateWebhookReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateWebhookReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookReq) ProtoMessage() {}
+
+func (x *CreateWebhookReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookReq.ProtoReflect.Descriptor instead.
+func (*CreateWebhookReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateWebhookReq) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookReq) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *CreateWebhookReq) GetSlugs() []string {
+	if x != nil {
+		return x.Slugs
+	}
+	return nil
+}
+
+func (x *CreateWebhookReq) GetSecret() string {
+	if x != nil && x.Secret != nil {
+		return *x.Secret
+	}
+	return ""
+}
+
+type Webhook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the webhook.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Url the events are sent to.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Events the webhook is subscribed to; all of them if empty.
+	Events []string `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	// Identifiers of the links whose events are sent; all of them if empty.
+	Slugs []string `protobuf:"bytes,4,rep,name=slugs,proto3" json:"slugs,omitempty"`
+	// Secret the payloads are signed with. Only returned when creating the webhook.
+	Secret string `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Time the webhook was created at.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Webhook) GetSlugs() []string {
+	if x != nil {
+		return x.Slugs
+	}
+	return nil
+}
+
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type WebhookList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Webhooks []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+}
+
+func (x *WebhookList) Reset() {
+	*x = WebhookList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebhookList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookList) ProtoMessage() {}
+
+func (x *WebhookList) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookList.ProtoReflect.Descriptor instead.
+func (*WebhookList) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WebhookList) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type WebhookId struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the webhook.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *WebhookId) Reset() {
+	*x = WebhookId{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebhookId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookId) ProtoMessage() {}
+
+func (x *WebhookId) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookId.ProtoReflect.Descriptor instead.
+func (*WebhookId) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WebhookId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeadLettersReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the webhook to get the dead letters of. All of them are returned if empty.
+	WebhookId string `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+}
+
+func (x *DeadLettersReq) Reset() {
+	*x = DeadLettersReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeadLettersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLettersReq) ProtoMessage() {}
+
+func (x *DeadLettersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLettersReq.ProtoReflect.Descriptor instead.
+func (*DeadLettersReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeadLettersReq) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+type Delivery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the delivery, also sent on the `Lnk-Delivery` header.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Identifier of the webhook the delivery is for.
+	WebhookId string `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	// Event being delivered.
+	Event string `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	// Json payload sent to the webhook.
+	Payload string `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Amount of attempts done.
+	Attempts uint32 `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Error of the last attempt.
+	LastError string `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// Time the delivery was queued at.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *Delivery) Reset() {
+	*x = Delivery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Delivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delivery) ProtoMessage() {}
+
+func (x *Delivery) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delivery.ProtoReflect.Descriptor instead.
+func (*Delivery) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Delivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Delivery) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *Delivery) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *Delivery) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *Delivery) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Delivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Delivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type DeliveryList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deliveries []*Delivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+}
+
+func (x *DeliveryList) Reset() {
+	*x = DeliveryList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryList) ProtoMessage() {}
+
+func (x *DeliveryList) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryList.ProtoReflect.Descriptor instead.
+func (*DeliveryList) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeliveryList) GetDeliveries() []*Delivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type DeliveryId struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the delivery.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeliveryId) Reset() {
+	*x = DeliveryId{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryId) ProtoMessage() {}
+
+func (x *DeliveryId) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryId.ProtoReflect.Descriptor instead.
+func (*DeliveryId) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeliveryId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type LinkStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the link to get the stats of.
+	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Inclusive start of the time range of the hits breakdown. Unbounded if not set.
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Exclusive end of the time range of the hits breakdown. Unbounded if not set.
+	To *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Size of the buckets of the hits breakdown; `hour`, `day`, `week` or `month`.
+	// Defaults to `day`.
+	Granularity string `protobuf:"bytes,4,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	// IANA time zone the buckets of the hits breakdown are aligned to. Defaults to `UTC`.
+	TimeZone string `protobuf:"bytes,5,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Include the buckets without hits on the hits breakdown, with zero hits. The buckets
+	// span the requested time range; from the first bucket with hits if there's no start,
+	// and until now if there's no end.
+	// At most 10000 buckets can be filled; longer ranges are rejected, and if the hits go
+	// further back than that, only the buckets with hits are included.
+	FillGaps bool `protobuf:"varint,6,opt,name=fill_gaps,json=fillGaps,proto3" json:"fill_gaps,omitempty"`
+}
+
+func (x *LinkStatsReq) Reset() {
+	*x = LinkStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStatsReq) ProtoMessage() {}
+
+func (x *LinkStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStatsReq.ProtoReflect.Descriptor instead.
+func (*LinkStatsReq) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LinkStatsReq) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *LinkStatsReq) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *LinkStatsReq) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *LinkStatsReq) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *LinkStatsReq) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *LinkStatsReq) GetFillGaps() bool {
+	if x != nil {
+		return x.FillGaps
+	}
+	return false
+}
+
+type LinkStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the link the stats belong to.
+	Slug string `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// Total amount of hits for this link, excluding the ones from bots.
+	Hits uint64 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	// Hosts of the pages visitors came from; `direct` for visits that didn't come from another page.
+	// Only the first 500 hosts are counted apart; the visits from later ones count as `other`.
+	Referrers []*BreakdownEntry `protobuf:"bytes,3,rep,name=referrers,proto3" json:"referrers,omitempty"`
+	// Browsers used by the visitors.
+	Browsers []*BreakdownEntry `protobuf:"bytes,4,rep,name=browsers,proto3" json:"browsers,omitempty"`
+	// Operating systems used by the visitors.
+	OperatingSystems []*BreakdownEntry `protobuf:"bytes,5,rep,name=operating_systems,json=operatingSystems,proto3" json:"operating_systems,omitempty"`
+	// Classes of devices used by the visitors; `desktop`, `mobile` or `tablet`.
+	Devices []*BreakdownEntry `protobuf:"bytes,6,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Countries the visitors came from, as reported by the edge proxy.
+	// Only the first 500 countries are counted apart; the visits from later ones count as `other`.
+	Countries []*BreakdownEntry `protobuf:"bytes,7,rep,name=countries,proto3" json:"countries,omitempty"`
+	// Breakdown of the hits over time within the requested range.
+	// Always sorted chronologically, oldest bucket first.
+	Histogram []*DailyHits `protobuf:"bytes,8,rep,name=histogram,proto3" json:"histogram,omitempty"`
+	// Approximate amount of distinct visitors of the link.
+	UniqueVisitors uint64 `protobuf:"varint,9,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	// Amount of hits from bots, link preview fetchers and prefetching browsers, which
+	// are excluded from every other counter.
+	BotHits uint64 `protobuf:"varint,10,opt,name=bot_hits,json=botHits,proto3" json:"bot_hits,omitempty"`
+}
+
+func (x *LinkStats) Reset() {
+	*x = LinkStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStats) ProtoMessage() {}
+
+func (x *LinkStats) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStats.ProtoReflect.Descriptor instead.
+func (*LinkStats) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LinkStats) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *LinkStats) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *LinkStats) GetReferrers() []*BreakdownEntry {
+	if x != nil {
+		return x.Referrers
+	}
+	return nil
+}
+
+func (x *LinkStats) GetBrowsers() []*BreakdownEntry {
+	if x != nil {
+		return x.Browsers
+	}
+	return nil
+}
+
+func (x *LinkStats) GetOperatingSystems() []*BreakdownEntry {
+	if x != nil {
+		return x.OperatingSystems
+	}
+	return nil
+}
+
+func (x *LinkStats) GetDevices() []*BreakdownEntry {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *LinkStats) GetCountries() []*BreakdownEntry {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *LinkStats) GetHistogram() []*DailyHits {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *LinkStats) GetUniqueVisitors() uint64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *LinkStats) GetBotHits() uint64 {
+	if x != nil {
+		return x.BotHits
+	}
+	return 0
+}
+
+type BreakdownEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Value of the property, like the browser name.
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Amount of visits with this value.
+	Hits uint64 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+}
+
+func (x *BreakdownEntry) Reset() {
+	*x = BreakdownEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BreakdownEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreakdownEntry) ProtoMessage() {}
+
+func (x *BreakdownEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreakdownEntry.ProtoReflect.Descriptor instead.
+func (*BreakdownEntry) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BreakdownEntry) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *BreakdownEntry) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+type DailyHits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ISO8601 formatted start of the bucket which hits are returned; `2022-06-11T14:00` for
+	// hourly buckets, the date of the day or week start (monday) for daily and weekly ones,
+	// and `2022-06` for monthly ones.
+	Date string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// Amount of hits for the link on the specified date.
+	Hits uint64 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	// Approximate amount of distinct visitors of the link on the specified date.
+	// Not available for hourly buckets, as distinct visitors are only tracked per day.
+	UniqueVisitors *uint64 `protobuf:"varint,3,opt,name=unique_visitors,json=uniqueVisitors,proto3,oneof" json:"unique_visitors,omitempty"`
+}
+
+func (x *DailyHits) Reset() {
+	*x = DailyHits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DailyHits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyHits) ProtoMessage() {}
+
+func (x *DailyHits) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyHits.ProtoReflect.Descriptor instead.
+func (*DailyHits) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DailyHits) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyHits) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *DailyHits) GetUniqueVisitors() uint64 {
+	if x != nil && x.UniqueVisitors != nil {
+		return *x.UniqueVisitors
+	}
+	return 0
+}
+
+type LinkList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Links []*LinkDetails `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+}
+
+func (x *LinkList) Reset() {
+	*x = LinkList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkList) ProtoMessage() {}
+
+func (x *LinkList) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkList.ProtoReflect.Descriptor instead.
+func (*LinkList) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LinkList) GetLinks() []*LinkDetails {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type RestoreStoreResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of the format of the snapshot.
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Time the snapshot was taken at.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Amount of links restored.
+	Links uint32 `protobuf:"varint,3,opt,name=links,proto3" json:"links,omitempty"`
+	// Amount of webhooks restored.
+	Webhooks uint32 `protobuf:"varint,4,opt,name=webhooks,proto3" json:"webhooks,omitempty"`
+	// Amount of pending webhook deliveries restored.
+	Deliveries uint32 `protobuf:"varint,5,opt,name=deliveries,proto3" json:"deliveries,omitempty"`
+	// Amount of dead letters restored.
+	DeadLetters uint32 `protobuf:"varint,6,opt,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+}
+
+func (x *RestoreStoreResp) Reset() {
+	*x = RestoreStoreResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lnk_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreStoreResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStoreResp) ProtoMessage() {}
+
+func (x *RestoreStoreResp) ProtoReflect() protoreflect.Message {
+	mi := &file_lnk_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStoreResp.ProtoReflect.Descriptor instead.
+func (*RestoreStoreResp) Descriptor() ([]byte, []int) {
+	return file_lnk_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RestoreStoreResp) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RestoreStoreResp) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RestoreStoreResp) GetLinks() uint32 {
+	if x != nil {
+		return x.Links
+	}
+	return 0
+}
+
+func (x *RestoreStoreResp) GetWebhooks() uint32 {
+	if x != nil {
+		return x.Webhooks
+	}
+	return 0
+}
+
+func (x *RestoreStoreResp) GetDeliveries() uint32 {
+	if x != nil {
+		return x.Deliveries
+	}
+	return 0
+}
+
+func (x *RestoreStoreResp) GetDeadLetters() uint32 {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return 0
+}
+
+var File_lnk_proto protoreflect.FileDescriptor
+
+var file_lnk_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x6c, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x6c, 0x6e, 0x6b,
+	0x1a, 0x24, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x33, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x68, 0x74, 0x74, 0x70, 0x62, 0x6f, 0x64, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8b, 0x04,
+	0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x23, 0x0a,
+	0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c,
+	0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61, 0x27, 0x52, 0x04, 0x73, 0x6c,
+	0x75, 0x67, 0x12, 0x32, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x1a, 0xba, 0x47, 0x17, 0x3a, 0x15, 0x12, 0x13, 0x27, 0x68, 0x74, 0x74, 0x70,
+	0x3a, 0x2f, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x27, 0x52, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x04, 0x68, 0x69, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12, 0x02, 0x34, 0x32, 0x52,
+	0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x44, 0x61, 0x69, 0x6c, 0x79,
+	0x48, 0x69, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6e, 0x6b,
+	0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x74,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x74, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x37, 0x0a, 0x12, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x74, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x35, 0x52,
+	0x11, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x74, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c,
+	0x61, 0x79, 0x12, 0x32, 0x0a, 0x0f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x76, 0x69, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06,
+	0x3a, 0x04, 0x12, 0x02, 0x31, 0x37, 0x52, 0x0e, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x56, 0x69,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x08, 0x62, 0x6f, 0x74, 0x5f, 0x68, 0x69,
+	0x74, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12,
+	0x01, 0x33, 0x52, 0x07, 0x62, 0x6f, 0x74, 0x48, 0x69, 0x74, 0x73, 0x22, 0xd1, 0x03, 0x0a, 0x0d,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x32, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0xba,
+	0x47, 0x17, 0x3a, 0x15, 0x12, 0x13, 0x27, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x2f, 0x2f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x27, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x27,
+	0x48, 0x00, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x05, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6e, 0x6b,
+	0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x12, 0x46, 0x0a, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x25,
+	0xba, 0x47, 0x22, 0x3a, 0x20, 0x12, 0x1e, 0x27, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x20,
+	0x68, 0x6f, 0x72, 0x73, 0x65, 0x20, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x20, 0x73, 0x74,
+	0x61, 0x70, 0x6c, 0x65, 0x27, 0x48, 0x01, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x74, 0x69,
+	0x74, 0x69, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x73, 0x74, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x37, 0x0a, 0x12, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x73, 0x74, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x35, 0x52, 0x11,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x74, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61,
+	0x79, 0x12, 0x56, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2d, 0xba, 0x47, 0x2a, 0x3a,
+	0x28, 0x12, 0x26, 0x27, 0x33, 0x66, 0x31, 0x63, 0x35, 0x61, 0x35, 0x32, 0x2d, 0x37, 0x64, 0x30,
+	0x65, 0x2d, 0x34, 0x65, 0x38, 0x62, 0x2d, 0x39, 0x61, 0x34, 0x63, 0x2d, 0x30, 0x63, 0x36, 0x66,
+	0x38, 0x64, 0x32, 0x62, 0x31, 0x65, 0x39, 0x37, 0x27, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x73, 0x6c,
+	0x75, 0x67, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22,
+	0xbd, 0x01, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x12, 0x28, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x56, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x2d, 0xba, 0x47, 0x2a, 0x3a, 0x28, 0x12, 0x26, 0x27, 0x33, 0x66, 0x31, 0x63, 0x35, 0x61,
+	0x35, 0x32, 0x2d, 0x37, 0x64, 0x30, 0x65, 0x2d, 0x34, 0x65, 0x38, 0x62, 0x2d, 0x39, 0x61, 0x34,
+	0x63, 0x2d, 0x30, 0x63, 0x36, 0x66, 0x38, 0x64, 0x32, 0x62, 0x31, 0x65, 0x39, 0x37, 0x27, 0x52,
+	0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x22,
+	0x8f, 0x01, 0x0a, 0x14, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x0b, 0xba, 0x47, 0x08, 0x3a, 0x06, 0x12, 0x04,
+	0x34, 0x39, 0x39, 0x39, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12,
+	0x20, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x42,
+	0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x31, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x22, 0xc6, 0x01, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x6c, 0x75,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x1b, 0xba, 0x47, 0x18, 0x3a, 0x16, 0x12,
+	0x14, 0x5b, 0x27, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x27, 0x2c, 0x20, 0x27, 0x62, 0x38, 0x66,
+	0x38, 0x65, 0x61, 0x27, 0x5d, 0x52, 0x05, 0x73, 0x6c, 0x75, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x12, 0x56, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2d, 0xba, 0x47, 0x2a,
+	0x3a, 0x28, 0x12, 0x26, 0x27, 0x33, 0x66, 0x31, 0x63, 0x35, 0x61, 0x35, 0x32, 0x2d, 0x37, 0x64,
+	0x30, 0x65, 0x2d, 0x34, 0x65, 0x38, 0x62, 0x2d, 0x39, 0x61, 0x34, 0x63, 0x2d, 0x30, 0x63, 0x36,
+	0x66, 0x38, 0x64, 0x32, 0x62, 0x31, 0x65, 0x39, 0x37, 0x27, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d,
+	0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x22, 0x8c, 0x01, 0x0a, 0x14, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12,
+	0x26, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x32, 0x52, 0x09, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01,
+	0x30, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0xf1, 0x01, 0x0a, 0x0e, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x12, 0x24, 0x0a, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0c, 0xba, 0x47,
+	0x09, 0x3a, 0x07, 0x12, 0x05, 0x27, 0x63, 0x73, 0x76, 0x27, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x74,
+	0x74, 0x70, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x47, 0x0a, 0x07,
+	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x2d, 0xba,
+	0x47, 0x2a, 0x3a, 0x28, 0x12, 0x26, 0x5b, 0x27, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x3d, 0x6c,
+	0x6f, 0x6e, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x27, 0x2c, 0x20, 0x27, 0x73, 0x6c, 0x75, 0x67, 0x3d,
+	0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x27, 0x5d, 0x52, 0x07, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12,
+	0x08, 0x27, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x27, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x6c,
+	0x69, 0x63, 0x74, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x94, 0x02,
+	0x0a, 0x0f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x2b, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x25,
+	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x42,
+	0x0b, 0xba, 0x47, 0x08, 0x3a, 0x06, 0x12, 0x04, 0x34, 0x39, 0x39, 0x30, 0x52, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x0b, 0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69,
+	0x74, 0x74, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a,
+	0x03, 0x12, 0x01, 0x30, 0x52, 0x0b, 0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65,
+	0x6e, 0x12, 0x22, 0x0a, 0x07, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x38, 0x52, 0x07, 0x72, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x30,
+	0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x06, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03,
+	0x12, 0x01, 0x32, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x64,
+	0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x22, 0x9c, 0x02, 0x0a, 0x0c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x32, 0x52, 0x04, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x11, 0xba, 0x47, 0x0e, 0x3a, 0x0c, 0x12, 0x0a, 0x27, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x2d, 0x32, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x34, 0x0a, 0x0d, 0x6f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x27, 0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x6c, 0x75, 0x67,
+	0x12, 0x32, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x1a, 0xba, 0x47, 0x17, 0x3a, 0x15, 0x12, 0x13, 0x27, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x2f,
+	0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x27, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x10, 0xba, 0x47, 0x0d, 0x3a, 0x0b, 0x12, 0x09, 0x27, 0x72,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x64, 0x27, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x12, 0x31, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x1b, 0xba, 0x47, 0x18, 0x3a, 0x16, 0x12, 0x14, 0x27, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x20, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x20, 0x75, 0x72, 0x6c, 0x27, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x9c, 0x01, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65,
+	0x61, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x4e, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x38, 0xba, 0x47, 0x35, 0x3a, 0x33, 0x12, 0x31,
+	0x27, 0x73, 0x6c, 0x75, 0x67, 0x20, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x20, 0x61, 0x70, 0x70,
+	0x65, 0x61, 0x72, 0x73, 0x20, 0x6d, 0x6f, 0x72, 0x65, 0x20, 0x74, 0x68, 0x61, 0x6e, 0x20, 0x6f,
+	0x6e, 0x63, 0x65, 0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x27, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x62, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x62, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x22, 0xa8, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52,
+	0x75, 0x6c, 0x65, 0x12, 0x30, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x52, 0x75, 0x6c,
+	0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x47, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2f, 0xba, 0x47, 0x2c, 0x3a, 0x2a, 0x12, 0x28, 0x27, 0x68,
+	0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x61, 0x70, 0x70, 0x73, 0x2e, 0x61, 0x70, 0x70, 0x6c,
+	0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x70, 0x2f, 0x69, 0x64, 0x32, 0x38, 0x34, 0x38,
+	0x38, 0x32, 0x32, 0x31, 0x35, 0x27, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1d,
+	0x0a, 0x04, 0x68, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47,
+	0x06, 0x3a, 0x04, 0x12, 0x02, 0x34, 0x32, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x22, 0x8f, 0x01,
+	0x0a, 0x0b, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x3e, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x26, 0xba,
+	0x47, 0x23, 0x3a, 0x21, 0x12, 0x1f, 0x27, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x61, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x2d, 0x62, 0x27, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x21, 0x0a,
+	0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x09, 0xba,
+	0x47, 0x06, 0x3a, 0x04, 0x12, 0x02, 0x33, 0x30, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x1d, 0x0a, 0x04, 0x68, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09,
+	0xba, 0x47, 0x06, 0x3a, 0x04, 0x12, 0x02, 0x34, 0x32, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x22,
+	0x88, 0x02, 0x0a, 0x0d, 0x52, 0x75, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x28, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x42, 0x0e, 0xba, 0x47, 0x0b, 0x3a, 0x09, 0x12, 0x07, 0x5b, 0x27, 0x69, 0x6f, 0x73,
+	0x27, 0x5d, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x16,
+	0xba, 0x47, 0x13, 0x3a, 0x11, 0x12, 0x0f, 0x5b, 0x27, 0x65, 0x73, 0x27, 0x2c, 0x20, 0x27, 0x70,
+	0x74, 0x2d, 0x42, 0x52, 0x27, 0x5d, 0x52, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x31, 0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x42, 0x13, 0xba, 0x47, 0x10, 0x3a, 0x0e, 0x12, 0x0c, 0x5b, 0x27, 0x45,
+	0x53, 0x27, 0x2c, 0x20, 0x27, 0x52, 0x4f, 0x27, 0x5d, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x2d, 0x0a, 0x06, 0x4c, 0x69,
+	0x6e, 0x6b, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38,
+	0x65, 0x61, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x22, 0xac, 0x02, 0x0a, 0x09, 0x4c, 0x69,
+	0x6e, 0x6b, 0x51, 0x52, 0x52, 0x65, 0x71, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62,
+	0x38, 0x66, 0x38, 0x65, 0x61, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x24, 0x0a, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0c, 0xba, 0x47,
+	0x09, 0x3a, 0x07, 0x12, 0x05, 0x27, 0x73, 0x76, 0x67, 0x27, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x1e, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x42, 0x0a, 0xba, 0x47, 0x07, 0x3a, 0x05, 0x12, 0x03, 0x35, 0x31, 0x32, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x20, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0a, 0xba, 0x47, 0x07, 0x3a, 0x05, 0x12, 0x03, 0x27, 0x48, 0x27, 0x52, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x25, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x32, 0x48, 0x00,
+	0x52, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x0a, 0x66,
+	0x6f, 0x72, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x31, 0x61, 0x32, 0x62, 0x33, 0x63, 0x27,
+	0x52, 0x0a, 0x66, 0x6f, 0x72, 0x65, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2f, 0x0a, 0x0a,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x66, 0x66, 0x66, 0x66, 0x66, 0x66,
+	0x27, 0x52, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x22, 0x90, 0x02, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62,
+	0x38, 0x66, 0x38, 0x65, 0x61, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x2e, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02,
+	0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x2f, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x6e,
+	0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0d, 0xba,
+	0x47, 0x0a, 0x3a, 0x08, 0x12, 0x06, 0x27, 0x68, 0x6f, 0x75, 0x72, 0x27, 0x52, 0x0b, 0x67, 0x72,
+	0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0xba, 0x47,
+	0x13, 0x3a, 0x11, 0x12, 0x0f, 0x27, 0x45, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x2f, 0x4d, 0x61, 0x64,
+	0x72, 0x69, 0x64, 0x27, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x66, 0x69, 0x6c, 0x6c, 0x5f, 0x67, 0x61, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x6c, 0x47, 0x61, 0x70, 0x73, 0x22, 0xcb, 0x02, 0x0a, 0x0e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x31,
+	0x0a, 0x05, 0x73, 0x6c, 0x75, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x1b, 0xba,
+	0x47, 0x18, 0x3a, 0x16, 0x12, 0x14, 0x5b, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61, 0x27, 0x2c,
+	0x20, 0x27, 0x63, 0x33, 0x31, 0x32, 0x30, 0x31, 0x27, 0x5d, 0x52, 0x05, 0x73, 0x6c, 0x75, 0x67,
+	0x73, 0x12, 0x27, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x6e, 0x64, 0x6a, 0x73, 0x6f,
+	0x6e, 0x27, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x2f, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75, 0x6c,
+	0x61, 0x72, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0d, 0xba, 0x47, 0x0a,
+	0x3a, 0x08, 0x12, 0x06, 0x27, 0x77, 0x65, 0x65, 0x6b, 0x27, 0x52, 0x0b, 0x67, 0x72, 0x61, 0x6e,
+	0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0xba, 0x47, 0x13, 0x3a,
+	0x11, 0x12, 0x0f, 0x27, 0x45, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x2f, 0x4d, 0x61, 0x64, 0x72, 0x69,
+	0x64, 0x27, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x66, 0x69, 0x6c, 0x6c, 0x5f, 0x67, 0x61, 0x70, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x66, 0x69, 0x6c, 0x6c, 0x47, 0x61, 0x70, 0x73, 0x22, 0x8c, 0x02, 0x0a, 0x0b, 0x4f, 0x76,
+	0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x2f, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75, 0x6c, 0x61,
+	0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0d, 0xba, 0x47, 0x0a, 0x3a,
+	0x08, 0x12, 0x06, 0x27, 0x77, 0x65, 0x65, 0x6b, 0x27, 0x52, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75,
+	0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a,
+	0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0xba, 0x47, 0x13, 0x3a, 0x11,
+	0x12, 0x0f, 0x27, 0x45, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x2f, 0x4d, 0x61, 0x64, 0x72, 0x69, 0x64,
+	0x27, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66,
+	0x69, 0x6c, 0x6c, 0x5f, 0x67, 0x61, 0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x6c, 0x47, 0x61, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01,
+	0x35, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x87, 0x03, 0x0a, 0x08, 0x4f, 0x76, 0x65,
+	0x72, 0x76, 0x69, 0x65, 0x77, 0x12, 0x2a, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6c,
+	0x69, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a,
+	0x04, 0x12, 0x02, 0x31, 0x32, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x69, 0x6e, 0x6b,
+	0x73, 0x12, 0x29, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x68, 0x69, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x42, 0x0a, 0xba, 0x47, 0x07, 0x3a, 0x05, 0x12, 0x03, 0x34, 0x32,
+	0x30, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x48, 0x69, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x08,
+	0x62, 0x6f, 0x74, 0x5f, 0x68, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09,
+	0xba, 0x47, 0x06, 0x3a, 0x04, 0x12, 0x02, 0x33, 0x31, 0x52, 0x07, 0x62, 0x6f, 0x74, 0x48, 0x69,
+	0x74, 0x73, 0x12, 0x2c, 0x0a, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x44, 0x61, 0x69, 0x6c,
+	0x79, 0x48, 0x69, 0x74, 0x73, 0x52, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x12, 0x2d, 0x0a, 0x09, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x08, 0x74, 0x6f, 0x70, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12,
+	0x33, 0x0a, 0x0c, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x4c,
+	0x69, 0x6e, 0x6b, 0x73, 0x12, 0x39, 0x0a, 0x0f, 0x75, 0x6e, 0x76, 0x69, 0x73, 0x69, 0x74, 0x65,
+	0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
+	0x0e, 0x75, 0x6e, 0x76, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12,
+	0x31, 0x0a, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x75, 0x6e, 0x76, 0x69, 0x73, 0x69, 0x74,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12,
+	0x01, 0x33, 0x52, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x55, 0x6e, 0x76, 0x69, 0x73, 0x69, 0x74,
+	0x65, 0x64, 0x22, 0xc0, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61,
+	0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x32, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0xba, 0x47, 0x17, 0x3a, 0x15, 0x12, 0x13,
+	0x27, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x2f, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63,
+	0x6f, 0x6d, 0x27, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x04, 0x68,
+	0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04,
+	0x12, 0x02, 0x34, 0x32, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x37, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x6c, 0x75, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x42, 0x11, 0xba, 0x47, 0x0e, 0x3a, 0x0c, 0x12, 0x0a, 0x5b, 0x27, 0x62,
+	0x38, 0x66, 0x38, 0x65, 0x61, 0x27, 0x5d, 0x52, 0x05, 0x73, 0x6c, 0x75, 0x67, 0x73, 0x22, 0xbc,
+	0x03, 0x0a, 0x08, 0x48, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x04, 0x73,
+	0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a,
+	0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67,
+	0x12, 0x32, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x1a, 0xba, 0x47, 0x17, 0x3a, 0x15, 0x12, 0x13, 0x27, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x2f,
+	0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x27, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1d, 0xba, 0x47, 0x1a, 0x3a, 0x18, 0x12, 0x16, 0x27,
+	0x6e, 0x65, 0x77, 0x73, 0x2e, 0x79, 0x63, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x63, 0x6f, 0x6d, 0x27, 0x52, 0x08, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x12,
+	0x2a, 0x0a, 0x07, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x10, 0xba, 0x47, 0x0d, 0x3a, 0x0b, 0x12, 0x09, 0x27, 0x46, 0x69, 0x72, 0x65, 0x66, 0x6f,
+	0x78, 0x27, 0x52, 0x07, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x10, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0e, 0xba, 0x47, 0x0b, 0x3a, 0x09, 0x12, 0x07, 0x27, 0x4c,
+	0x69, 0x6e, 0x75, 0x78, 0x27, 0x52, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x28, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x10, 0xba, 0x47, 0x0d, 0x3a, 0x0b, 0x12, 0x09, 0x27,
+	0x64, 0x65, 0x73, 0x6b, 0x74, 0x6f, 0x70, 0x27, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x25, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0b, 0xba, 0x47, 0x08, 0x3a, 0x06, 0x12, 0x04, 0x27, 0x45, 0x53, 0x27, 0x52, 0x07,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x6f, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x62, 0x6f, 0x74, 0x12, 0x22, 0x0a, 0x07, 0x64, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a,
+	0x03, 0x12, 0x01, 0x30, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x79, 0x0a,
+	0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x12, 0x35,
+	0x0a, 0x0e, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12, 0x02, 0x34,
+	0x31, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f,
+	0x6e, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6d, 0x69, 0x6c, 0x65, 0x73,
+	0x74, 0x6f, 0x6e, 0x65, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xd4, 0x01, 0x0a, 0x0a, 0x4c, 0x69, 0x6e,
+	0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04,
+	0x12, 0x02, 0x34, 0x32, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x24,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x10, 0xba, 0x47,
+	0x0d, 0x3a, 0x0b, 0x12, 0x09, 0x27, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x27, 0x52, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38,
+	0x65, 0x61, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x6c, 0x69, 0x6e,
+	0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69,
+	0x6e, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x22,
+	0xef, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x52, 0x65, 0x71, 0x12, 0x38, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x26, 0xba, 0x47, 0x23, 0x3a, 0x21, 0x12, 0x1f, 0x27, 0x68, 0x74, 0x74, 0x70, 0x73,
+	0x3a, 0x2f, 0x2f, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x6e, 0x6b, 0x27, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x3f,
+	0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x27,
+	0xba, 0x47, 0x24, 0x3a, 0x22, 0x12, 0x20, 0x5b, 0x27, 0x6c, 0x69, 0x6e, 0x6b, 0x2e, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x27, 0x2c, 0x20, 0x27, 0x6c, 0x69, 0x6e, 0x6b, 0x2e, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x27, 0x5d, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x27, 0x0a, 0x05, 0x73, 0x6c, 0x75, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x11,
+	0xba, 0x47, 0x0e, 0x3a, 0x0c, 0x12, 0x0a, 0x5b, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61, 0x27,
+	0x5d, 0x52, 0x05, 0x73, 0x6c, 0x75, 0x67, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12,
+	0x08, 0x27, 0x73, 0x33, 0x63, 0x72, 0x33, 0x74, 0x27, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x22, 0xd0, 0x02, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x3d, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2d, 0xba, 0x47, 0x2a, 0x3a, 0x28,
+	0x12, 0x26, 0x27, 0x30, 0x62, 0x35, 0x61, 0x32, 0x63, 0x34, 0x65, 0x2d, 0x33, 0x64, 0x31, 0x66,
+	0x2d, 0x34, 0x65, 0x38, 0x61, 0x2d, 0x39, 0x63, 0x37, 0x62, 0x2d, 0x36, 0x66, 0x32, 0x64, 0x31,
+	0x65, 0x30, 0x61, 0x39, 0x62, 0x38, 0x63, 0x27, 0x52, 0x02, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x26, 0xba, 0x47, 0x23, 0x3a, 0x21,
+	0x12, 0x1f, 0x27, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x68, 0x6f, 0x6f, 0x6b, 0x73,
+	0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x6e, 0x6b,
+	0x27, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x3f, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x27, 0xba, 0x47, 0x24, 0x3a, 0x22, 0x12, 0x20, 0x5b,
+	0x27, 0x6c, 0x69, 0x6e, 0x6b, 0x2e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x27, 0x2c, 0x20,
+	0x27, 0x6c, 0x69, 0x6e, 0x6b, 0x2e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x27, 0x5d, 0x52,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x6c, 0x75, 0x67, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x42, 0x11, 0xba, 0x47, 0x0e, 0x3a, 0x0c, 0x12, 0x0a, 0x5b,
+	0x27, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61, 0x27, 0x5d, 0x52, 0x05, 0x73, 0x6c, 0x75, 0x67, 0x73,
+	0x12, 0x27, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x73, 0x33, 0x63, 0x72, 0x33, 0x74,
+	0x27, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0x37, 0x0a, 0x0b, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x52, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x22, 0x4a, 0x0a,
+	0x09, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x49, 0x64, 0x12, 0x3d, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2d, 0xba, 0x47, 0x2a, 0x3a, 0x28, 0x12, 0x26, 0x27,
+	0x30, 0x62, 0x35, 0x61, 0x32, 0x63, 0x34, 0x65, 0x2d, 0x33, 0x64, 0x31, 0x66, 0x2d, 0x34, 0x65,
+	0x38, 0x61, 0x2d, 0x39, 0x63, 0x37, 0x62, 0x2d, 0x36, 0x66, 0x32, 0x64, 0x31, 0x65, 0x30, 0x61,
+	0x39, 0x62, 0x38, 0x63, 0x27, 0x52, 0x02, 0x69, 0x64, 0x22, 0x5e, 0x0a, 0x0e, 0x44, 0x65, 0x61,
+	0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x12, 0x4c, 0x0a, 0x0a, 0x77,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x2d, 0xba, 0x47, 0x2a, 0x3a, 0x28, 0x12, 0x26, 0x27, 0x30, 0x62, 0x35, 0x61, 0x32, 0x63, 0x34,
+	0x65, 0x2d, 0x33, 0x64, 0x31, 0x66, 0x2d, 0x34, 0x65, 0x38, 0x61, 0x2d, 0x39, 0x63, 0x37, 0x62,
+	0x2d, 0x36, 0x66, 0x32, 0x64, 0x31, 0x65, 0x30, 0x61, 0x39, 0x62, 0x38, 0x63, 0x27, 0x52, 0x09,
+	0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x49, 0x64, 0x22, 0x9a, 0x04, 0x0a, 0x08, 0x44, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x3d, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x2d, 0xba, 0x47, 0x2a, 0x3a, 0x28, 0x12, 0x26, 0x27, 0x37, 0x63, 0x39, 0x65,
+	0x36, 0x36, 0x37, 0x39, 0x2d, 0x37, 0x34, 0x32, 0x35, 0x2d, 0x34, 0x30, 0x64, 0x65, 0x2d, 0x39,
+	0x34, 0x34, 0x62, 0x2d, 0x65, 0x30, 0x37, 0x66, 0x63, 0x31, 0x66, 0x39, 0x30, 0x61, 0x65, 0x37,
+	0x27, 0x52, 0x02, 0x69, 0x64, 0x12, 0x4c, 0x0a, 0x0a, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2d, 0xba, 0x47, 0x2a, 0x3a, 0x28,
+	0x12, 0x26, 0x27, 0x30, 0x62, 0x35, 0x61, 0x32, 0x63, 0x34, 0x65, 0x2d, 0x33, 0x64, 0x31, 0x66,
+	0x2d, 0x34, 0x65, 0x38, 0x61, 0x2d, 0x39, 0x63, 0x37, 0x62, 0x2d, 0x36, 0x66, 0x32, 0x64, 0x31,
+	0x65, 0x30, 0x61, 0x39, 0x62, 0x38, 0x63, 0x27, 0x52, 0x09, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x15, 0xba, 0x47, 0x12, 0x3a, 0x10, 0x12, 0x0e, 0x27, 0x6c, 0x69, 0x6e, 0x6b,
+	0x2e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x27, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0xaa, 0x01, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x8f, 0x01, 0xba, 0x47, 0x8b, 0x01, 0x3a, 0x88, 0x01, 0x12, 0x85, 0x01, 0x27,
+	0x7b, 0x22, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x3a, 0x22, 0x6c, 0x69, 0x6e, 0x6b, 0x2e, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0x2c, 0x22, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x22, 0x3a, 0x34, 0x32, 0x2c, 0x22, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x3a, 0x22, 0x32, 0x30,
+	0x32, 0x32, 0x2d, 0x30, 0x36, 0x2d, 0x31, 0x31, 0x54, 0x31, 0x34, 0x3a, 0x30, 0x30, 0x3a, 0x30,
+	0x30, 0x5a, 0x22, 0x2c, 0x22, 0x6c, 0x69, 0x6e, 0x6b, 0x22, 0x3a, 0x7b, 0x22, 0x73, 0x6c, 0x75,
+	0x67, 0x22, 0x3a, 0x22, 0x62, 0x38, 0x66, 0x38, 0x65, 0x61, 0x22, 0x2c, 0x22, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x22, 0x3a, 0x22, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x2f, 0x2f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x22, 0x2c, 0x22, 0x68, 0x69, 0x74, 0x73, 0x22, 0x3a,
+	0x30, 0x7d, 0x7d, 0x27, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x24, 0x0a,
+	0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x42,
+	0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x38, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x73, 0x12, 0x46, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42, 0x27, 0xba, 0x47, 0x24, 0x3a, 0x22, 0x12, 0x20,
+	0x27, 0x75, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x20, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x20, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x20, 0x35, 0x30, 0x33, 0x27,
+	0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x3d, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6c, 0x6e, 0x6b,
+	0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x4b, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x79, 0x49, 0x64, 0x12, 0x3d, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x2d, 0xba, 0x47, 0x2a, 0x3a, 0x28, 0x12, 0x26, 0x27, 0x37, 0x63, 0x39, 0x65, 0x36, 0x36, 0x37,
+	0x39, 0x2d, 0x37, 0x34, 0x32, 0x35, 0x2d, 0x34, 0x30, 0x64, 0x65, 0x2d, 0x39, 0x34, 0x34, 0x62,
+	0x2d, 0x65, 0x30, 0x37, 0x66, 0x63, 0x31, 0x66, 0x39, 0x30, 0x61, 0x65, 0x37, 0x27, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x92, 0x02, 0x0a, 0x0c, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66, 0x38, 0x65,
+	0x61, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x2f, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72,
+	0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0d, 0xba, 0x47, 0x0a, 0x3a, 0x08,
+	0x12, 0x06, 0x27, 0x68, 0x6f, 0x75, 0x72, 0x27, 0x52, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75, 0x6c,
+	0x61, 0x72, 0x69, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f,
+	0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0xba, 0x47, 0x13, 0x3a, 0x11, 0x12,
+	0x0f, 0x27, 0x45, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x2f, 0x4d, 0x61, 0x64, 0x72, 0x69, 0x64, 0x27,
+	0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69,
+	0x6c, 0x6c, 0x5f, 0x67, 0x61, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x6c, 0x47, 0x61, 0x70, 0x73, 0x22, 0xde, 0x03, 0x0a, 0x09, 0x4c, 0x69, 0x6e, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x0f, 0xba, 0x47, 0x0c, 0x3a, 0x0a, 0x12, 0x08, 0x27, 0x62, 0x38, 0x66,
+	0x38, 0x65, 0x61, 0x27, 0x52, 0x04, 0x73, 0x6c, 0x75, 0x67, 0x12, 0x1d, 0x0a, 0x04, 0x68, 0x69,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12,
+	0x02, 0x34, 0x32, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x72, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c,
+	0x6e, 0x6b, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x73, 0x12, 0x2f, 0x0a, 0x08,
+	0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x08, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x73, 0x12, 0x40, 0x0a,
+	0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42,
+	0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12,
+	0x2d, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x31,
+	0x0a, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77,
+	0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x2c, 0x0a, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x44, 0x61, 0x69, 0x6c, 0x79,
+	0x48, 0x69, 0x74, 0x73, 0x52, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12,
+	0x32, 0x0a, 0x0f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12,
+	0x02, 0x31, 0x37, 0x52, 0x0e, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x56, 0x69, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x08, 0x62, 0x6f, 0x74, 0x5f, 0x68, 0x69, 0x74, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x04, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x33, 0x52,
+	0x07, 0x62, 0x6f, 0x74, 0x48, 0x69, 0x74, 0x73, 0x22, 0x57, 0x0a, 0x0e, 0x42, 0x72, 0x65, 0x61,
+	0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x10, 0xba, 0x47, 0x0d, 0x3a, 0x0b,
+	0x12, 0x09, 0x27, 0x46, 0x69, 0x72, 0x65, 0x66, 0x6f, 0x78, 0x27, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1d, 0x0a, 0x04, 0x68, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12, 0x02, 0x34, 0x32, 0x52, 0x04, 0x68, 0x69, 0x74,
+	0x73, 0x22, 0xa0, 0x01, 0x0a, 0x09, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x48, 0x69, 0x74, 0x73, 0x12,
+	0x27, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x13, 0xba,
+	0x47, 0x10, 0x3a, 0x0e, 0x12, 0x0c, 0x27, 0x32, 0x30, 0x32, 0x32, 0x2d, 0x30, 0x36, 0x2d, 0x31,
+	0x31, 0x27, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x04, 0x68, 0x69, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12, 0x02, 0x34,
+	0x32, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x0f, 0x75, 0x6e, 0x69, 0x71, 0x75,
+	0x65, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12, 0x02, 0x31, 0x37, 0x48, 0x00, 0x52, 0x0e, 0x75,
+	0x6e, 0x69, 0x71, 0x75, 0x65, 0x56, 0x69, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x88, 0x01, 0x01,
+	0x42, 0x12, 0x0a, 0x10, 0x5f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x76, 0x69, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x73, 0x22, 0x32, 0x0a, 0x08, 0x4c, 0x69, 0x6e, 0x6b, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x26, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x22, 0xb1, 0x02, 0x0a, 0x10, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x22, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08,
+	0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x31, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x58, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x42, 0x1d, 0xba, 0x47, 0x1a, 0x3a, 0x18, 0x12, 0x16, 0x27, 0x32, 0x30, 0x32, 0x32, 0x2d,
+	0x30, 0x36, 0x2d, 0x31, 0x31, 0x54, 0x31, 0x32, 0x3a, 0x30, 0x30, 0x3a, 0x30, 0x30, 0x5a, 0x27,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x6c,
+	0x69, 0x6e, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x0b, 0xba, 0x47, 0x08, 0x3a,
+	0x06, 0x12, 0x04, 0x35, 0x30, 0x30, 0x30, 0x52, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x24,
+	0x0a, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x32, 0x52, 0x08, 0x77, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x29, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x09, 0xba, 0x47, 0x06, 0x3a, 0x04, 0x12,
+	0x02, 0x31, 0x30, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x2b, 0x0a, 0x0c, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x08, 0xba, 0x47, 0x05, 0x3a, 0x03, 0x12, 0x01, 0x31, 0x52,
+	0x0b, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x32, 0xc2, 0x11, 0x0a,
+	0x05, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x59, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69,
+	0x6e, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0d, 0x2e, 0x6c, 0x6e,
+	0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x25, 0xba, 0x47, 0x10, 0x12,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b,
+	0x73, 0x12, 0x5e, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x12,
+	0x12, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b,
+	0x52, 0x65, 0x71, 0x1a, 0x0b, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x49, 0x64,
+	0x22, 0x2f, 0xba, 0x47, 0x17, 0x12, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x20, 0x73, 0x68,
+	0x6f, 0x72, 0x74, 0x65, 0x6e, 0x65, 0x64, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x3a, 0x01,
+	0x2a, 0x12, 0x8d, 0x01, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x18, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x19, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x44, 0xba, 0x47, 0x20,
+	0x12, 0x1e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x20, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e,
+	0x65, 0x64, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x20, 0x69, 0x6e, 0x20, 0x62, 0x75, 0x6c, 0x6b,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e,
+	0x6b, 0x73, 0x3a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x3a, 0x01,
+	0x2a, 0x12, 0x6a, 0x0a, 0x0b, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x73,
+	0x12, 0x13, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x6e,
+	0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x30, 0xba, 0x47, 0x0e,
+	0x12, 0x0c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x19, 0x22, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x73,
+	0x3a, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x3a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x61, 0x0a,
+	0x07, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x0f, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x47,
+	0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x33, 0xba, 0x47, 0x17,
+	0x12, 0x15, 0x47, 0x65, 0x74, 0x20, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x20, 0x6f, 0x66,
+	0x20, 0x61, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12, 0x11, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x2f, 0x7b, 0x73, 0x6c, 0x75, 0x67, 0x7d,
+	0x12, 0x70, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x11, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x0e, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x22, 0x3d, 0xba, 0x47, 0x1b, 0x12, 0x19, 0x47, 0x65, 0x74, 0x20, 0x76, 0x69,
+	0x73, 0x69, 0x74, 0x20, 0x73, 0x74, 0x61, 0x74, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x20, 0x6c,
+	0x69, 0x6e, 0x6b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x2f, 0x7b, 0x73, 0x6c, 0x75, 0x67, 0x7d, 0x2f, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x69, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x51, 0x52, 0x12,
+	0x0e, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x51, 0x52, 0x52, 0x65, 0x71, 0x1a,
+	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x74, 0x74,
+	0x70, 0x42, 0x6f, 0x64, 0x79, 0x22, 0x36, 0xba, 0x47, 0x17, 0x12, 0x15, 0x47, 0x65, 0x74, 0x20,
+	0x51, 0x52, 0x20, 0x63, 0x6f, 0x64, 0x65, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x20, 0x6c, 0x69, 0x6e,
+	0x6b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69,
+	0x6e, 0x6b, 0x73, 0x2f, 0x7b, 0x73, 0x6c, 0x75, 0x67, 0x7d, 0x2f, 0x71, 0x72, 0x12, 0x6f, 0x0a,
+	0x0b, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x13, 0x2e, 0x6c,
+	0x6e, 0x6b, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48,
+	0x74, 0x74, 0x70, 0x42, 0x6f, 0x64, 0x79, 0x22, 0x33, 0xba, 0x47, 0x17, 0x12, 0x15, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x20, 0x73, 0x74, 0x61, 0x74, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x6c, 0x69,
+	0x6e, 0x6b, 0x73, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12, 0x11, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x30, 0x01, 0x12, 0x63,
+	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x12, 0x10, 0x2e,
+	0x6c, 0x6e, 0x6b, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x1a,
+	0x0d, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65, 0x77, 0x22, 0x33,
+	0xba, 0x47, 0x1b, 0x12, 0x19, 0x47, 0x65, 0x74, 0x20, 0x6f, 0x76, 0x65, 0x72, 0x76, 0x69, 0x65,
+	0x77, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x76, 0x65, 0x72, 0x76,
+	0x69, 0x65, 0x77, 0x12, 0x62, 0x0a, 0x09, 0x57, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x74, 0x73,
+	0x12, 0x11, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x48, 0x69, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x22, 0x31, 0xba, 0x47, 0x17, 0x12, 0x15, 0x57, 0x61, 0x74, 0x63, 0x68, 0x20, 0x76,
+	0x69, 0x73, 0x69, 0x74, 0x73, 0x20, 0x74, 0x6f, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x11, 0x12, 0x0f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x68, 0x69, 0x74, 0x73, 0x2f,
+	0x77, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x12, 0x68, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x12, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x0f, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x33, 0xba, 0x47, 0x18, 0x12,
+	0x16, 0x57, 0x61, 0x74, 0x63, 0x68, 0x20, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x20, 0x74,
+	0x6f, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x30,
+	0x01, 0x12, 0x61, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x12, 0x15, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x0c, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x22, 0x2b, 0xba, 0x47, 0x10, 0x12, 0x0e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x20, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x12, 0x22, 0x0d, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x73, 0x3a, 0x01, 0x2a, 0x12, 0x65, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x6c,
+	0x6e, 0x6b, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x2b,
+	0xba, 0x47, 0x13, 0x12, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x77, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x66, 0x0a, 0x0d, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x0e, 0x2e, 0x6c,
+	0x6e, 0x6b, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x49, 0x64, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x2d, 0xba, 0x47, 0x10, 0x12, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x20, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a,
+	0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x12, 0x72, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x61, 0x64, 0x4c,
+	0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x12, 0x13, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x44, 0x65, 0x61,
+	0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x6c, 0x6e,
+	0x6b, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x37,
+	0xba, 0x47, 0x13, 0x12, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x64, 0x65, 0x61, 0x64, 0x20, 0x6c,
+	0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x2f, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x12, 0x7e, 0x0a, 0x0f, 0x52, 0x65, 0x74, 0x72, 0x79,
+	0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x0f, 0x2e, 0x6c, 0x6e, 0x6b,
+	0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x49, 0x64, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x42, 0xba, 0x47, 0x13, 0x12, 0x11, 0x52, 0x65, 0x74, 0x72, 0x79, 0x20,
+	0x64, 0x65, 0x61, 0x64, 0x20, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x26, 0x22, 0x24, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73,
+	0x2f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x2f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x66, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x0b, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x4c, 0x69, 0x6e, 0x6b,
+	0x49, 0x64, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x33, 0xba, 0x47, 0x17, 0x12,
+	0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x20, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x65,
+	0x64, 0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x2a, 0x11, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x2f, 0x7b, 0x73, 0x6c, 0x75, 0x67, 0x7d, 0x12,
+	0x8d, 0x01, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c,
+	0x69, 0x6e, 0x6b, 0x73, 0x12, 0x18, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x19,
+	0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x22, 0x44, 0xba, 0x47, 0x20, 0x12, 0x1e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x20, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x65, 0x64,
+	0x20, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x20, 0x69, 0x6e, 0x20, 0x62, 0x75, 0x6c, 0x6b, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x6e, 0x6b, 0x73,
+	0x3a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12,
+	0x7b, 0x0a, 0x0d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x42, 0x6f, 0x64, 0x79, 0x22, 0x3c,
+	0xba, 0x47, 0x1e, 0x12, 0x1c, 0x54, 0x61, 0x6b, 0x65, 0x20, 0x61, 0x20, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x82, 0x01, 0x0a,
+	0x0c, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x42,
+	0x6f, 0x64, 0x79, 0x1a, 0x15, 0x2e, 0x6c, 0x6e, 0x6b, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x43, 0xba, 0x47, 0x23, 0x12,
+	0x21, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x20, 0x66, 0x72, 0x6f, 0x6d, 0x20, 0x61, 0x20, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x3a, 0x01, 0x2a, 0x28,
+	0x01, 0x42, 0x98, 0x01, 0x5a, 0x09, 0x6c, 0x6e, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0xba,
+	0x47, 0x89, 0x01, 0x12, 0x86, 0x01, 0x0a, 0x07, 0x4c, 0x6e, 0x6b, 0x20, 0x41, 0x50, 0x49, 0x12,
+	0x43, 0x4c, 0x6e, 0x6b, 0x20, 0x69, 0x73, 0x20, 0x61, 0x20, 0x62, 0x61, 0x73, 0x69, 0x63, 0x20,
+	0x75, 0x72, 0x6c, 0x20, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x20, 0x74, 0x68,
+	0x61, 0x74, 0x20, 0x63, 0x61, 0x6e, 0x20, 0x62, 0x65, 0x20, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x64, 0x20, 0x76, 0x69, 0x61, 0x20, 0x72, 0x65, 0x73, 0x74, 0x20, 0x61, 0x70, 0x69, 0x20, 0x63,
+	0x61, 0x6c, 0x6c, 0x73, 0x22, 0x2f, 0x0a, 0x0e, 0x41, 0x6c, 0x65, 0x78, 0x20, 0x56, 0x69, 0x73,
+	0x63, 0x72, 0x65, 0x61, 0x6e, 0x75, 0x12, 0x1d, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x65, 0x78, 0x76, 0x69,
+	0x72, 0x2f, 0x6c, 0x6e, 0x6b, 0x32, 0x05, 0x30, 0x2e, 0x31, 0x2e, 0x30, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_lnk_proto_rawDescOnce sync.Once
+	file_lnk_proto_rawDescData = file_lnk_proto_rawDesc
+)
+
+func file_lnk_proto_rawDescGZIP() []byte {
+	file_lnk_proto_rawDescOnce.Do(func() {
+		file_lnk_proto_rawDescData = protoimpl.X.CompressGZIP(file_lnk_proto_rawDescData)
+	})
+	return file_lnk_proto_rawDescData
+}
+
+var file_lnk_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
+var file_lnk_proto_goTypes = []interface{}{
+	(*LinkDetails)(nil),           // 0: lnk.LinkDetails
+	(*CreateLinkReq)(nil),         // 1: lnk.CreateLinkReq
+	(*BatchCreateLinksReq)(nil),   // 2: lnk.BatchCreateLinksReq
+	(*BatchCreateLinksResp)(nil),  // 3: lnk.BatchCreateLinksResp
+	(*BatchDeleteLinksReq)(nil),   // 4: lnk.BatchDeleteLinksReq
+	(*BatchDeleteLinksResp)(nil),  // 5: lnk.BatchDeleteLinksResp
+	(*ImportLinksReq)(nil),        // 6: lnk.ImportLinksReq
+	(*ImportLinksResp)(nil),       // 7: lnk.ImportLinksResp
+	(*ImportResult)(nil),          // 8: lnk.ImportResult
+	(*BatchResult)(nil),           // 9: lnk.BatchResult
+	(*RedirectRule)(nil),          // 10: lnk.RedirectRule
+	(*SplitTarget)(nil),           // 11: lnk.SplitTarget
+	(*RuleCondition)(nil),         // 12: lnk.RuleCondition
+	(*LinkId)(nil),                // 13: lnk.LinkId
+	(*LinkQRReq)(nil),             // 14: lnk.LinkQRReq
+	(*GetLinkReq)(nil),            // 15: lnk.GetLinkReq
+	(*ExportStatsReq)(nil),        // 16: lnk.ExportStatsReq
+	(*OverviewReq)(nil),           // 17: lnk.OverviewReq
+	(*Overview)(nil),              // 18: lnk.Overview
+	(*LinkSummary)(nil),           // 19: lnk.LinkSummary
+	(*WatchHitsReq)(nil),          // 20: lnk.WatchHitsReq
+	(*HitEvent)(nil),              // 21: lnk.HitEvent
+	(*WatchLinksReq)(nil),         // 22: lnk.WatchLinksReq
+	(*LinkChange)(nil),            // 23: lnk.LinkChange
+	(*CreateWebhookReq)(nil),      // 24: lnk.CreateWebhookReq
+	(*Webhook)(nil),               // 25: lnk.Webhook
+	(*WebhookList)(nil),           // 26: lnk.WebhookList
+	(*WebhookId)(nil),             // 27: lnk.WebhookId
+	(*DeadLettersReq)(nil),        // 28: lnk.DeadLettersReq
+	(*Delivery)(nil),              // 29: lnk.Delivery
+	(*DeliveryList)(nil),          // 30: lnk.DeliveryList
+	(*DeliveryId)(nil),            // 31: lnk.DeliveryId
+	(*LinkStatsReq)(nil),          // 32: lnk.LinkStatsReq
+	(*LinkStats)(nil),             // 33: lnk.LinkStats
+	(*BreakdownEntry)(nil),        // 34: lnk.BreakdownEntry
+	(*DailyHits)(nil),             // 35: lnk.DailyHits
+	(*LinkList)(nil),              // 36: lnk.LinkList
+	(*RestoreStoreResp)(nil),      // 37: lnk.RestoreStoreResp
+	(*timestamppb.Timestamp)(nil), // 38: google.protobuf.Timestamp
+	(*httpbody.HttpBody)(nil),     // 39: google.api.HttpBody
+	(*emptypb.Empty)(nil),         // 40: google.protobuf.Empty
+}
+var file_lnk_proto_depIdxs = []int32{
+	35, // 0: lnk.LinkDetails.stats:type_name -> lnk.DailyHits
+	10, // 1: lnk.LinkDetails.rules:type_name -> lnk.RedirectRule
+	11, // 2: lnk.LinkDetails.split:type_name -> lnk.SplitTarget
+	38, // 3: lnk.LinkDetails.created_at:type_name -> google.protobuf.Timestamp
+	10, // 4: lnk.CreateLinkReq.rules:type_name -> lnk.RedirectRule
+	11, // 5: lnk.CreateLinkReq.split:type_name -> lnk.SplitTarget
+	1,  // 6: lnk.BatchCreateLinksReq.links:type_name -> lnk.CreateLinkReq
+	9,  // 7: lnk.BatchCreateLinksResp.results:type_name -> lnk.BatchResult
+	9,  // 8: lnk.BatchDeleteLinksResp.results:type_name -> lnk.BatchResult
+	39, // 9: lnk.ImportLinksReq.data:type_name -> google.api.HttpBody
+	8,  // 10: lnk.ImportLinksResp.results:type_name -> lnk.ImportResult
+	12, // 11: lnk.RedirectRule.condition:type_name -> lnk.RuleCondition
+	38, // 12: lnk.RuleCondition.after:type_name -> google.protobuf.Timestamp
+	38, // 13: lnk.RuleCondition.before:type_name -> google.protobuf.Timestamp
+	38, // 14: lnk.GetLinkReq.from:type_name -> google.protobuf.Timestamp
+	38, // 15: lnk.GetLinkReq.to:type_name -> google.protobuf.Timestamp
+	38, // 16: lnk.ExportStatsReq.from:type_name -> google.protobuf.Timestamp
+	38, // 17: lnk.ExportStatsReq.to:type_name -> google.protobuf.Timestamp
+	38, // 18: lnk.OverviewReq.from:type_name -> google.protobuf.Timestamp
+	38, // 19: lnk.OverviewReq.to:type_name -> google.protobuf.Timestamp
+	35, // 20: lnk.Overview.histogram:type_name -> lnk.DailyHits
+	19, // 21: lnk.Overview.top_links:type_name -> lnk.LinkSummary
+	19, // 22: lnk.Overview.newest_links:type_name -> lnk.LinkSummary
+	19, // 23: lnk.Overview.unvisited_links:type_name -> lnk.LinkSummary
+	38, // 24: lnk.LinkSummary.created_at:type_name -> google.protobuf.Timestamp
+	38, // 25: lnk.HitEvent.time:type_name -> google.protobuf.Timestamp
+	38, // 26: lnk.LinkChange.time:type_name -> google.protobuf.Timestamp
+	0,  // 27: lnk.LinkChange.link:type_name -> lnk.LinkDetails
+	38, // 28: lnk.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	25, // 29: lnk.WebhookList.webhooks:type_name -> lnk.Webhook
+	38, // 30: lnk.Delivery.created_at:type_name -> google.protobuf.Timestamp
+	29, // 31: lnk.DeliveryList.deliveries:type_name -> lnk.Delivery
+	38, // 32: lnk.LinkStatsReq.from:type_name -> google.protobuf.Timestamp
+	38, // 33: lnk.LinkStatsReq.to:type_name -> google.protobuf.Timestamp
+	34, // 34: lnk.LinkStats.referrers:type_name -> lnk.BreakdownEntry
+	34, // 35: lnk.LinkStats.browsers:type_name -> lnk.BreakdownEntry
+	34, // 36: lnk.LinkStats.operating_systems:type_name -> lnk.BreakdownEntry
+	34, // 37: lnk.LinkStats.devices:type_name -> lnk.BreakdownEntry
+	34, // 38: lnk.LinkStats.countries:type_name -> lnk.BreakdownEntry
+	35, // 39: lnk.LinkStats.histogram:type_name -> lnk.DailyHits
+	0,  // 40: lnk.LinkList.links:type_name -> lnk.LinkDetails
+	38, // 41: lnk.RestoreStoreResp.created_at:type_name -> google.protobuf.Timestamp
+	40, // 42: lnk.Links.ListLinks:input_type -> google.protobuf.Empty
+	1,  // 43: lnk.Links.CreateLink:input_type -> lnk.CreateLinkReq
+	2,  // 44: lnk.Links.BatchCreateLinks:input_type -> lnk.BatchCreateLinksReq
+	6,  // 45: lnk.Links.ImportLinks:input_type -> lnk.ImportLinksReq
+	15, // 46: lnk.Links.GetLink:input_type -> lnk.GetLinkReq
+	32, // 47: lnk.Links.GetLinkStats:input_type -> lnk.LinkStatsReq
+	14, // 48: lnk.Links.GetLinkQR:input_type -> lnk.LinkQRReq
+	16, // 49: lnk.Links.ExportStats:input_type -> lnk.ExportStatsReq
+	17, // 50: lnk.Links.GetOverview:input_type -> lnk.OverviewReq
+	20, // 51: lnk.Links.WatchHits:input_type -> lnk.WatchHitsReq
+	22, // 52: lnk.Links.WatchLinks:input_type -> lnk.WatchLinksReq
+	24, // 53: lnk.Links.CreateWebhook:input_type -> lnk.CreateWebhookReq
+	40, // 54: lnk.Links.ListWebhooks:input_type -> google.protobuf.Empty
+	27, // 55: lnk.Links.DeleteWebhook:input_type -> lnk.WebhookId
+	28, // 56: lnk.Links.ListDeadLetters:input_type -> lnk.DeadLettersReq
+	31, // 57: lnk.Links.RetryDeadLetter:input_type -> lnk.DeliveryId
+	13, // 58: lnk.Links.DeleteLink:input_type -> lnk.LinkId
+	4,  // 59: lnk.Links.BatchDeleteLinks:input_type -> lnk.BatchDeleteLinksReq
+	40, // 60: lnk.Links.SnapshotStore:input_type -> google.protobuf.Empty
+	39, // 61: lnk.Links.RestoreStore:input_type -> google.api.HttpBody
+	36, // 62: lnk.Links.ListLinks:output_type -> lnk.LinkList
+	13, // 63: lnk.Links.CreateLink:output_type -> lnk.LinkId
+	3,  // 64: lnk.Links.BatchCreateLinks:output_type -> lnk.BatchCreateLinksResp
+	7,  // 65: lnk.Links.ImportLinks:output_type -> lnk.ImportLinksResp
+	0,  // 66: lnk.Links.GetLink:output_type -> lnk.LinkDetails
+	33, // 67: lnk.Links.GetLinkStats:output_type -> lnk.LinkStats
+	39, // 68: lnk.Links.GetLinkQR:output_type -> google.api.HttpBody
+	39, // 69: lnk.Links.ExportStats:output_type -> google.api.HttpBody
+	18, // 70: lnk.Links.GetOverview:output_type -> lnk.Overview
+	21, // 71: lnk.Links.WatchHits:output_type -> lnk.HitEvent
+	23, // 72: lnk.Links.WatchLinks:output_type -> lnk.LinkChange
+	25, // 73: lnk.Links.CreateWebhook:output_type -> lnk.Webhook
+	26, // 74: lnk.Links.ListWebhooks:output_type -> lnk.WebhookList
+	40, // 75: lnk.Links.DeleteWebhook:output_type -> google.protobuf.Empty
+	30, // 76: lnk.Links.ListDeadLetters:output_type -> lnk.DeliveryList
+	40, // 77: lnk.Links.RetryDeadLetter:output_type -> google.protobuf.Empty
+	40, // 78: lnk.Links.DeleteLink:output_type -> google.protobuf.Empty
+	5,  // 79: lnk.Links.BatchDeleteLinks:output_type -> lnk.BatchDeleteLinksResp
+	39, // 80: lnk.Links.SnapshotStore:output_type -> google.api.HttpBody
+	37, // 81: lnk.Links.RestoreStore:output_type -> lnk.RestoreStoreResp
+	62, // [62:82] is the sub-list for method output_type
+	42, // [42:62] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_lnk_proto_init() }
+func file_lnk_proto_init() {
+	if File_lnk_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_lnk_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateLinkReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateLinksReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchCreateLinksResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
 		file_lnk_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchDeleteLinksReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchDeleteLinksResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportLinksReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportLinksResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedirectRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SplitTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuleCondition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkQRReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLinkReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OverviewReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Overview); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchHitsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HitEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchLinksReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateWebhookReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Webhook); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeadLettersReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Delivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BreakdownEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DailyHits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lnk_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LinkList); i {
 			case 0:
 				return &v.state
@@ -489,15 +4411,31 @@ func file_lnk_proto_init() {
 				return nil
 			}
 		}
+		file_lnk_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreStoreResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_lnk_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_lnk_proto_msgTypes[14].OneofWrappers = []interface{}{}
+	file_lnk_proto_msgTypes[22].OneofWrappers = []interface{}{}
+	file_lnk_proto_msgTypes[24].OneofWrappers = []interface{}{}
+	file_lnk_proto_msgTypes[35].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lnk_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
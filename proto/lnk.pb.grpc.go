@@ -8,6 +8,7 @@ package proto
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -27,11 +28,78 @@ type LinksClient interface {
 	ListLinks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LinkList, error)
 	// Create a new shortened link that when visited, it will redirect to the target url.
 	CreateLink(ctx context.Context, in *CreateLinkReq, opts ...grpc.CallOption) (*LinkId, error)
-	// Obtain details for a shortened link, like how many times it was visited and its daily
-	// visits breakdown.
-	GetLink(ctx context.Context, in *LinkId, opts ...grpc.CallOption) (*LinkDetails, error)
+	// Create many shortened links on a single call, e.g. for importing a campaign. Each link
+	// gets its own result, in the same order as requested; links that fail don't prevent the
+	// rest from being created, unless the batch is transactional, in which case either every
+	// link is created or none is.
+	BatchCreateLinks(ctx context.Context, in *BatchCreateLinksReq, opts ...grpc.CallOption) (*BatchCreateLinksResp, error)
+	// Import links exported from lnk or from other link shorteners, keeping their slugs and
+	// their hits over time. The file to import is the request body, either CSV with a header
+	// row, newline delimited JSON with a link per line, or a JSON dump of the link list as
+	// returned by this api. Each link is validated and imported on its own, and gets its own
+	// result; links whose slug already exists are skipped, overwritten or renamed as requested.
+	ImportLinks(ctx context.Context, in *ImportLinksReq, opts ...grpc.CallOption) (*ImportLinksResp, error)
+	// Obtain details for a shortened link, like how many times it was visited and its
+	// visits breakdown over time, which can be customized via the query parameters.
+	GetLink(ctx context.Context, in *GetLinkReq, opts ...grpc.CallOption) (*LinkDetails, error)
+	// Obtain the breakdown of the visits of a shortened link by referrer, browser, operating
+	// system, device and country. Each breakdown is sorted by hits, most common values first.
+	// The hits over time can be customized via the query parameters; the rest of breakdowns
+	// always cover the whole life of the link.
+	GetLinkStats(ctx context.Context, in *LinkStatsReq, opts ...grpc.CallOption) (*LinkStats, error)
+	// Render a QR code pointing to the full url of the shortened link, as a PNG or SVG image.
+	GetLinkQR(ctx context.Context, in *LinkQRReq, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// Export the hits over time of all the shortened links, or only of the requested ones,
+	// as CSV or newline delimited JSON, for loading them on spreadsheets or other tools.
+	// Each streamed message contains a single line of the export without its terminator;
+	// the CSV export starts with a header line, followed by a line per link and bucket,
+	// and the NDJSON one has a line per link.
+	ExportStats(ctx context.Context, in *ExportStatsReq, opts ...grpc.CallOption) (Links_ExportStatsClient, error)
+	// Summarize the usage of all the shortened links; totals, hits over time, most visited
+	// links within the requested time range, newest links and links that were never visited.
+	GetOverview(ctx context.Context, in *OverviewReq, opts ...grpc.CallOption) (*Overview, error)
+	// Stream the visits to the shortened links as they happen, optionally only the ones to the
+	// requested links. Visits are not buffered indefinitely; if the client doesn't keep up,
+	// some are skipped, as reported by `dropped`.
+	// Also available as server-sent events on `/api/hits/events`.
+	WatchHits(ctx context.Context, in *WatchHitsReq, opts ...grpc.CallOption) (Links_WatchHitsClient, error)
+	// Stream the changes done to the shortened links; creations, updates and deletions, and if
+	// asked for, hit milestones, reached when the hits of a link get to a power of ten.
+	// Every change has a sequence number, so clients can reconnect and resume right after the
+	// last change they got. Only the latest changes are kept, so clients that fall too far
+	// behind get an error and have to reload all links before watching again.
+	WatchLinks(ctx context.Context, in *WatchLinksReq, opts ...grpc.CallOption) (Links_WatchLinksClient, error)
+	// Subscribe a url to the events of the links; creations, updates, deletions and hit
+	// milestones. Events are sent as json payloads on POST requests, signed with the webhook
+	// secret on the `Lnk-Signature` header, and retried with exponential backoff until they
+	// are accepted with a 2xx response, or they run out of attempts and become dead letters.
+	// Links don't expire, so there are no expiry events.
+	CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*Webhook, error)
+	// Get a list of all the webhooks. Their secrets are not included.
+	ListWebhooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WebhookList, error)
+	// Delete the specified webhook, along with its pending deliveries and dead letters.
+	DeleteWebhook(ctx context.Context, in *WebhookId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Get a list of the deliveries that ran out of attempts, optionally only the ones of a webhook.
+	ListDeadLetters(ctx context.Context, in *DeadLettersReq, opts ...grpc.CallOption) (*DeliveryList, error)
+	// Queue a dead letter again for delivery, with its attempts reset.
+	RetryDeadLetter(ctx context.Context, in *DeliveryId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Delete the specified shortened link as well including its metadata.
 	DeleteLink(ctx context.Context, in *LinkId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Delete many shortened links on a single call. Each slug gets its own result, in the
+	// same order as requested; unlike when deleting a single link, deleting a link that
+	// doesn't exist fails. Links that fail don't prevent the rest from being deleted, unless
+	// the batch is transactional, in which case either every link is deleted or none is.
+	BatchDeleteLinks(ctx context.Context, in *BatchDeleteLinksReq, opts ...grpc.CallOption) (*BatchDeleteLinksResp, error)
+	// Take a snapshot of the whole store; every link with its hits, histograms and visitor
+	// estimates, and every webhook with its pending deliveries and dead letters. Snapshots
+	// are versioned and compressed, and can be restored on any lnk server, whatever its
+	// database. Requires the admin key of the server as bearer token.
+	SnapshotStore(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// Replace the whole contents of the store with the ones of a snapshot. Links missing from
+	// the snapshot are deleted, and the rest are created or overwritten. The change stream
+	// gets a single `restored` change instead of a change per link, and no webhook events are
+	// sent for it. Nothing is changed if the snapshot can't be read. Requires the admin key of the server as bearer token.
+	RestoreStore(ctx context.Context, opts ...grpc.CallOption) (Links_RestoreStoreClient, error)
 }
 
 type linksClient struct {
@@ -60,7 +128,25 @@ func (c *linksClient) CreateLink(ctx context.Context, in *CreateLinkReq, opts ..
 	return out, nil
 }
 
-func (c *linksClient) GetLink(ctx context.Context, in *LinkId, opts ...grpc.CallOption) (*LinkDetails, error) {
+func (c *linksClient) BatchCreateLinks(ctx context.Context, in *BatchCreateLinksReq, opts ...grpc.CallOption) (*BatchCreateLinksResp, error) {
+	out := new(BatchCreateLinksResp)
+	err := c.cc.Invoke(ctx, "/lnk.Links/BatchCreateLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) ImportLinks(ctx context.Context, in *ImportLinksReq, opts ...grpc.CallOption) (*ImportLinksResp, error) {
+	out := new(ImportLinksResp)
+	err := c.cc.Invoke(ctx, "/lnk.Links/ImportLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) GetLink(ctx context.Context, in *GetLinkReq, opts ...grpc.CallOption) (*LinkDetails, error) {
 	out := new(LinkDetails)
 	err := c.cc.Invoke(ctx, "/lnk.Links/GetLink", in, out, opts...)
 	if err != nil {
@@ -69,6 +155,174 @@ func (c *linksClient) GetLink(ctx context.Context, in *LinkId, opts ...grpc.Call
 	return out, nil
 }
 
+func (c *linksClient) GetLinkStats(ctx context.Context, in *LinkStatsReq, opts ...grpc.CallOption) (*LinkStats, error) {
+	out := new(LinkStats)
+	err := c.cc.Invoke(ctx, "/lnk.Links/GetLinkStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) GetLinkQR(ctx context.Context, in *LinkQRReq, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/lnk.Links/GetLinkQR", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) ExportStats(ctx context.Context, in *ExportStatsReq, opts ...grpc.CallOption) (Links_ExportStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Links_ServiceDesc.Streams[0], "/lnk.Links/ExportStats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linksExportStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Links_ExportStatsClient interface {
+	Recv() (*httpbody.HttpBody, error)
+	grpc.ClientStream
+}
+
+type linksExportStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *linksExportStatsClient) Recv() (*httpbody.HttpBody, error) {
+	m := new(httpbody.HttpBody)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *linksClient) GetOverview(ctx context.Context, in *OverviewReq, opts ...grpc.CallOption) (*Overview, error) {
+	out := new(Overview)
+	err := c.cc.Invoke(ctx, "/lnk.Links/GetOverview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) WatchHits(ctx context.Context, in *WatchHitsReq, opts ...grpc.CallOption) (Links_WatchHitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Links_ServiceDesc.Streams[1], "/lnk.Links/WatchHits", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linksWatchHitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Links_WatchHitsClient interface {
+	Recv() (*HitEvent, error)
+	grpc.ClientStream
+}
+
+type linksWatchHitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *linksWatchHitsClient) Recv() (*HitEvent, error) {
+	m := new(HitEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *linksClient) WatchLinks(ctx context.Context, in *WatchLinksReq, opts ...grpc.CallOption) (Links_WatchLinksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Links_ServiceDesc.Streams[2], "/lnk.Links/WatchLinks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linksWatchLinksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Links_WatchLinksClient interface {
+	Recv() (*LinkChange, error)
+	grpc.ClientStream
+}
+
+type linksWatchLinksClient struct {
+	grpc.ClientStream
+}
+
+func (x *linksWatchLinksClient) Recv() (*LinkChange, error) {
+	m := new(LinkChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *linksClient) CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/lnk.Links/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) ListWebhooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WebhookList, error) {
+	out := new(WebhookList)
+	err := c.cc.Invoke(ctx, "/lnk.Links/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) DeleteWebhook(ctx context.Context, in *WebhookId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/lnk.Links/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) ListDeadLetters(ctx context.Context, in *DeadLettersReq, opts ...grpc.CallOption) (*DeliveryList, error) {
+	out := new(DeliveryList)
+	err := c.cc.Invoke(ctx, "/lnk.Links/ListDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) RetryDeadLetter(ctx context.Context, in *DeliveryId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/lnk.Links/RetryDeadLetter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *linksClient) DeleteLink(ctx context.Context, in *LinkId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/lnk.Links/DeleteLink", in, out, opts...)
@@ -78,6 +332,58 @@ func (c *linksClient) DeleteLink(ctx context.Context, in *LinkId, opts ...grpc.C
 	return out, nil
 }
 
+func (c *linksClient) BatchDeleteLinks(ctx context.Context, in *BatchDeleteLinksReq, opts ...grpc.CallOption) (*BatchDeleteLinksResp, error) {
+	out := new(BatchDeleteLinksResp)
+	err := c.cc.Invoke(ctx, "/lnk.Links/BatchDeleteLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) SnapshotStore(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, "/lnk.Links/SnapshotStore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksClient) RestoreStore(ctx context.Context, opts ...grpc.CallOption) (Links_RestoreStoreClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Links_ServiceDesc.Streams[3], "/lnk.Links/RestoreStore", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linksRestoreStoreClient{stream}
+	return x, nil
+}
+
+type Links_RestoreStoreClient interface {
+	Send(*httpbody.HttpBody) error
+	CloseAndRecv() (*RestoreStoreResp, error)
+	grpc.ClientStream
+}
+
+type linksRestoreStoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *linksRestoreStoreClient) Send(m *httpbody.HttpBody) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *linksRestoreStoreClient) CloseAndRecv() (*RestoreStoreResp, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RestoreStoreResp)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // LinksServer is the server API for Links service.
 // All implementations must embed UnimplementedLinksServer
 // for forward compatibility
@@ -86,11 +392,78 @@ type LinksServer interface {
 	ListLinks(context.Context, *emptypb.Empty) (*LinkList, error)
 	// Create a new shortened link that when visited, it will redirect to the target url.
 	CreateLink(context.Context, *CreateLinkReq) (*LinkId, error)
-	// Obtain details for a shortened link, like how many times it was visited and its daily
-	// visits breakdown.
-	GetLink(context.Context, *LinkId) (*LinkDetails, error)
+	// Create many shortened links on a single call, e.g. for importing a campaign. Each link
+	// gets its own result, in the same order as requested; links that fail don't prevent the
+	// rest from being created, unless the batch is transactional, in which case either every
+	// link is created or none is.
+	BatchCreateLinks(context.Context, *BatchCreateLinksReq) (*BatchCreateLinksResp, error)
+	// Import links exported from lnk or from other link shorteners, keeping their slugs and
+	// their hits over time. The file to import is the request body, either CSV with a header
+	// row, newline delimited JSON with a link per line, or a JSON dump of the link list as
+	// returned by this api. Each link is validated and imported on its own, and gets its own
+	// result; links whose slug already exists are skipped, overwritten or renamed as requested.
+	ImportLinks(context.Context, *ImportLinksReq) (*ImportLinksResp, error)
+	// Obtain details for a shortened link, like how many times it was visited and its
+	// visits breakdown over time, which can be customized via the query parameters.
+	GetLink(context.Context, *GetLinkReq) (*LinkDetails, error)
+	// Obtain the breakdown of the visits of a shortened link by referrer, browser, operating
+	// system, device and country. Each breakdown is sorted by hits, most common values first.
+	// The hits over time can be customized via the query parameters; the rest of breakdowns
+	// always cover the whole life of the link.
+	GetLinkStats(context.Context, *LinkStatsReq) (*LinkStats, error)
+	// Render a QR code pointing to the full url of the shortened link, as a PNG or SVG image.
+	GetLinkQR(context.Context, *LinkQRReq) (*httpbody.HttpBody, error)
+	// Export the hits over time of all the shortened links, or only of the requested ones,
+	// as CSV or newline delimited JSON, for loading them on spreadsheets or other tools.
+	// Each streamed message contains a single line of the export without its terminator;
+	// the CSV export starts with a header line, followed by a line per link and bucket,
+	// and the NDJSON one has a line per link.
+	ExportStats(*ExportStatsReq, Links_ExportStatsServer) error
+	// Summarize the usage of all the shortened links; totals, hits over time, most visited
+	// links within the requested time range, newest links and links that were never visited.
+	GetOverview(context.Context, *OverviewReq) (*Overview, error)
+	// Stream the visits to the shortened links as they happen, optionally only the ones to the
+	// requested links. Visits are not buffered indefinitely; if the client doesn't keep up,
+	// some are skipped, as reported by `dropped`.
+	// Also available as server-sent events on `/api/hits/events`.
+	WatchHits(*WatchHitsReq, Links_WatchHitsServer) error
+	// Stream the changes done to the shortened links; creations, updates and deletions, and if
+	// asked for, hit milestones, reached when the hits of a link get to a power of ten.
+	// Every change has a sequence number, so clients can reconnect and resume right after the
+	// last change they got. Only the latest changes are kept, so clients that fall too far
+	// behind get an error and have to reload all links before watching again.
+	WatchLinks(*WatchLinksReq, Links_WatchLinksServer) error
+	// Subscribe a url to the events of the links; creations, updates, deletions and hit
+	// milestones. Events are sent as json payloads on POST requests, signed with the webhook
+	// secret on the `Lnk-Signature` header, and retried with exponential backoff until they
+	// are accepted with a 2xx response, or they run out of attempts and become dead letters.
+	// Links don't expire, so there are no expiry events.
+	CreateWebhook(context.Context, *CreateWebhookReq) (*Webhook, error)
+	// Get a list of all the webhooks. Their secrets are not included.
+	ListWebhooks(context.Context, *emptypb.Empty) (*WebhookList, error)
+	// Delete the specified webhook, along with its pending deliveries and dead letters.
+	DeleteWebhook(context.Context, *WebhookId) (*emptypb.Empty, error)
+	// Get a list of the deliveries that ran out of attempts, optionally only the ones of a webhook.
+	ListDeadLetters(context.Context, *DeadLettersReq) (*DeliveryList, error)
+	// Queue a dead letter again for delivery, with its attempts reset.
+	RetryDeadLetter(context.Context, *DeliveryId) (*emptypb.Empty, error)
 	// Delete the specified shortened link as well including its metadata.
 	DeleteLink(context.Context, *LinkId) (*emptypb.Empty, error)
+	// Delete many shortened links on a single call. Each slug gets its own result, in the
+	// same order as requested; unlike when deleting a single link, deleting a link that
+	// doesn't exist fails. Links that fail don't prevent the rest from being deleted, unless
+	// the batch is transactional, in which case either every link is deleted or none is.
+	BatchDeleteLinks(context.Context, *BatchDeleteLinksReq) (*BatchDeleteLinksResp, error)
+	// Take a snapshot of the whole store; every link with its hits, histograms and visitor
+	// estimates, and every webhook with its pending deliveries and dead letters. Snapshots
+	// are versioned and compressed, and can be restored on any lnk server, whatever its
+	// database. Requires the admin key of the server as bearer token.
+	SnapshotStore(context.Context, *emptypb.Empty) (*httpbody.HttpBody, error)
+	// Replace the whole contents of the store with the ones of a snapshot. Links missing from
+	// the snapshot are deleted, and the rest are created or overwritten. The change stream
+	// gets a single `restored` change instead of a change per link, and no webhook events are
+	// sent for it. Nothing is changed if the snapshot can't be read. Requires the admin key of the server as bearer token.
+	RestoreStore(Links_RestoreStoreServer) error
 	mustEmbedUnimplementedLinksServer()
 }
 
@@ -104,12 +477,60 @@ func (UnimplementedLinksServer) ListLinks(context.Context, *emptypb.Empty) (*Lin
 func (UnimplementedLinksServer) CreateLink(context.Context, *CreateLinkReq) (*LinkId, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateLink not implemented")
 }
-func (UnimplementedLinksServer) GetLink(context.Context, *LinkId) (*LinkDetails, error) {
+func (UnimplementedLinksServer) BatchCreateLinks(context.Context, *BatchCreateLinksReq) (*BatchCreateLinksResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateLinks not implemented")
+}
+func (UnimplementedLinksServer) ImportLinks(context.Context, *ImportLinksReq) (*ImportLinksResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportLinks not implemented")
+}
+func (UnimplementedLinksServer) GetLink(context.Context, *GetLinkReq) (*LinkDetails, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLink not implemented")
 }
+func (UnimplementedLinksServer) GetLinkStats(context.Context, *LinkStatsReq) (*LinkStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkStats not implemented")
+}
+func (UnimplementedLinksServer) GetLinkQR(context.Context, *LinkQRReq) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkQR not implemented")
+}
+func (UnimplementedLinksServer) ExportStats(*ExportStatsReq, Links_ExportStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStats not implemented")
+}
+func (UnimplementedLinksServer) GetOverview(context.Context, *OverviewReq) (*Overview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOverview not implemented")
+}
+func (UnimplementedLinksServer) WatchHits(*WatchHitsReq, Links_WatchHitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchHits not implemented")
+}
+func (UnimplementedLinksServer) WatchLinks(*WatchLinksReq, Links_WatchLinksServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLinks not implemented")
+}
+func (UnimplementedLinksServer) CreateWebhook(context.Context, *CreateWebhookReq) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedLinksServer) ListWebhooks(context.Context, *emptypb.Empty) (*WebhookList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedLinksServer) DeleteWebhook(context.Context, *WebhookId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedLinksServer) ListDeadLetters(context.Context, *DeadLettersReq) (*DeliveryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedLinksServer) RetryDeadLetter(context.Context, *DeliveryId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryDeadLetter not implemented")
+}
 func (UnimplementedLinksServer) DeleteLink(context.Context, *LinkId) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLink not implemented")
 }
+func (UnimplementedLinksServer) BatchDeleteLinks(context.Context, *BatchDeleteLinksReq) (*BatchDeleteLinksResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteLinks not implemented")
+}
+func (UnimplementedLinksServer) SnapshotStore(context.Context, *emptypb.Empty) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotStore not implemented")
+}
+func (UnimplementedLinksServer) RestoreStore(Links_RestoreStoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method RestoreStore not implemented")
+}
 func (UnimplementedLinksServer) mustEmbedUnimplementedLinksServer() {}
 
 // UnsafeLinksServer may be embedded to opt out of forward compatibility for this service.
@@ -159,8 +580,44 @@ func _Links_CreateLink_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Links_BatchCreateLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateLinksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).BatchCreateLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/BatchCreateLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).BatchCreateLinks(ctx, req.(*BatchCreateLinksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_ImportLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportLinksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).ImportLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/ImportLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).ImportLinks(ctx, req.(*ImportLinksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Links_GetLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LinkId)
+	in := new(GetLinkReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -172,7 +629,214 @@ func _Links_GetLink_Handler(srv interface{}, ctx context.Context, dec func(inter
 		FullMethod: "/lnk.Links/GetLink",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LinksServer).GetLink(ctx, req.(*LinkId))
+		return srv.(LinksServer).GetLink(ctx, req.(*GetLinkReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_GetLinkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStatsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).GetLinkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/GetLinkStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).GetLinkStats(ctx, req.(*LinkStatsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_GetLinkQR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkQRReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).GetLinkQR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/GetLinkQR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).GetLinkQR(ctx, req.(*LinkQRReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_ExportStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStatsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinksServer).ExportStats(m, &linksExportStatsServer{stream})
+}
+
+type Links_ExportStatsServer interface {
+	Send(*httpbody.HttpBody) error
+	grpc.ServerStream
+}
+
+type linksExportStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *linksExportStatsServer) Send(m *httpbody.HttpBody) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Links_GetOverview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverviewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).GetOverview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/GetOverview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).GetOverview(ctx, req.(*OverviewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_WatchHits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchHitsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinksServer).WatchHits(m, &linksWatchHitsServer{stream})
+}
+
+type Links_WatchHitsServer interface {
+	Send(*HitEvent) error
+	grpc.ServerStream
+}
+
+type linksWatchHitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *linksWatchHitsServer) Send(m *HitEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Links_WatchLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLinksReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinksServer).WatchLinks(m, &linksWatchLinksServer{stream})
+}
+
+type Links_WatchLinksServer interface {
+	Send(*LinkChange) error
+	grpc.ServerStream
+}
+
+type linksWatchLinksServer struct {
+	grpc.ServerStream
+}
+
+func (x *linksWatchLinksServer) Send(m *LinkChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Links_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).CreateWebhook(ctx, req.(*CreateWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).ListWebhooks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).DeleteWebhook(ctx, req.(*WebhookId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadLettersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/ListDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).ListDeadLetters(ctx, req.(*DeadLettersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_RetryDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).RetryDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/RetryDeadLetter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).RetryDeadLetter(ctx, req.(*DeliveryId))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -195,6 +859,68 @@ func _Links_DeleteLink_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Links_BatchDeleteLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteLinksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).BatchDeleteLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/BatchDeleteLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).BatchDeleteLinks(ctx, req.(*BatchDeleteLinksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_SnapshotStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServer).SnapshotStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnk.Links/SnapshotStore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServer).SnapshotStore(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Links_RestoreStore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LinksServer).RestoreStore(&linksRestoreStoreServer{stream})
+}
+
+type Links_RestoreStoreServer interface {
+	SendAndClose(*RestoreStoreResp) error
+	Recv() (*httpbody.HttpBody, error)
+	grpc.ServerStream
+}
+
+type linksRestoreStoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *linksRestoreStoreServer) SendAndClose(m *RestoreStoreResp) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *linksRestoreStoreServer) Recv() (*httpbody.HttpBody, error) {
+	m := new(httpbody.HttpBody)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Links_ServiceDesc is the grpc.ServiceDesc for Links service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -210,15 +936,84 @@ var Links_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateLink",
 			Handler:    _Links_CreateLink_Handler,
 		},
+		{
+			MethodName: "BatchCreateLinks",
+			Handler:    _Links_BatchCreateLinks_Handler,
+		},
+		{
+			MethodName: "ImportLinks",
+			Handler:    _Links_ImportLinks_Handler,
+		},
 		{
 			MethodName: "GetLink",
 			Handler:    _Links_GetLink_Handler,
 		},
+		{
+			MethodName: "GetLinkStats",
+			Handler:    _Links_GetLinkStats_Handler,
+		},
+		{
+			MethodName: "GetLinkQR",
+			Handler:    _Links_GetLinkQR_Handler,
+		},
+		{
+			MethodName: "GetOverview",
+			Handler:    _Links_GetOverview_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _Links_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _Links_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _Links_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _Links_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "RetryDeadLetter",
+			Handler:    _Links_RetryDeadLetter_Handler,
+		},
 		{
 			MethodName: "DeleteLink",
 			Handler:    _Links_DeleteLink_Handler,
 		},
+		{
+			MethodName: "BatchDeleteLinks",
+			Handler:    _Links_BatchDeleteLinks_Handler,
+		},
+		{
+			MethodName: "SnapshotStore",
+			Handler:    _Links_SnapshotStore_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportStats",
+			Handler:       _Links_ExportStats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchHits",
+			Handler:       _Links_WatchHits_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchLinks",
+			Handler:       _Links_WatchLinks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreStore",
+			Handler:       _Links_RestoreStore_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "lnk.proto",
 }
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	gateway "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/aexvir/lnk/internal/events"
+	"github.com/aexvir/lnk/internal/idempotency"
+	"github.com/aexvir/lnk/internal/logging"
+	"github.com/aexvir/lnk/internal/recorder"
+	"github.com/aexvir/lnk/internal/storage"
+	"github.com/aexvir/lnk/internal/svc"
+	"github.com/aexvir/lnk/internal/webhooks"
+	"github.com/aexvir/lnk/proto"
+)
+
+const port = 8000
+const grpcaddr = ":9000"
+
+// serveflags are the settings of the server.
+type serveflags struct {
+	snapshot          string
+	baseurl           string
+	idempotencywindow string
+	cookiesecret      string
+	visitorsalt       string
+	countryheader     string
+	clientipheader    string
+	privatewebhooks   bool
+	adminkey          string
+}
+
+func newservecmd() *cobra.Command {
+	var flags serveflags
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the lnk server",
+		Long: fmt.Sprintf("Run the lnk server, serving the redirects and the rest api on port %d and grpc on %s.\n", port, grpcaddr) +
+			"Links are lost when the server stops, unless a snapshot file is set; the store is restored\n" +
+			"from it on startup, if it exists, and it's written on shutdown.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serve(cmd.Context(), flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.snapshot, "snapshot", "", "file the store is restored from on startup and saved to on shutdown")
+	cmd.Flags().StringVar(&flags.baseurl, "base-url", envdefault("LNK_BASE_URL", fmt.Sprintf("http://localhost:%d", port)), "public url the server is reachable at, used for the full urls of the links [$LNK_BASE_URL]")
+	cmd.Flags().StringVar(&flags.cookiesecret, "cookie-secret", os.Getenv("LNK_COOKIE_SECRET"), "key signing the cookies that grant access to protected links, random if unset; keep it across restarts and replicas so they stay valid [$LNK_COOKIE_SECRET]")
+	cmd.Flags().StringVar(&flags.visitorsalt, "visitor-salt", os.Getenv("LNK_VISITOR_SALT"), "salt of the hashes identifying unique visitors, random if unset; keep it across restarts and replicas to count visitors once [$LNK_VISITOR_SALT]")
+	cmd.Flags().StringVar(&flags.countryheader, "country-header", envdefault("LNK_COUNTRY_HEADER", "X-Country-Code"), "request header the country of the visitors is read from, set by the proxy in front [$LNK_COUNTRY_HEADER]")
+	cmd.Flags().StringVar(&flags.clientipheader, "client-ip-header", os.Getenv("LNK_CLIENT_IP_HEADER"), "request header the ip of the visitors is read from, like X-Forwarded-For, instead of the connection [$LNK_CLIENT_IP_HEADER]")
+	cmd.Flags().StringVar(&flags.adminkey, "admin-key", os.Getenv("LNK_ADMIN_KEY"), "bearer token the snapshot and restore endpoints require, which are disabled if unset [$LNK_ADMIN_KEY]")
+	cmd.Flags().BoolVar(&flags.privatewebhooks, "allow-private-webhooks", os.Getenv("LNK_ALLOW_PRIVATE_WEBHOOKS") == "true", "let webhooks deliver to loopback, private and link-local addresses, e.g. for local development [$LNK_ALLOW_PRIVATE_WEBHOOKS]")
+	cmd.Flags().StringVar(&flags.idempotencywindow, "idempotency-window", envdefault("LNK_IDEMPOTENCY_WINDOW", "24h"), "how long the results of the requests with an idempotency key are remembered [$LNK_IDEMPOTENCY_WINDOW]")
+
+	return cmd
+}
+
+// serve runs the server until the context is done, restoring and saving the store
+// from the snapshot file if set.
+func serve(ctx context.Context, flags serveflags) error {
+	log := logging.NewLogger("server")
+
+	if base, err := url.Parse(flags.baseurl); err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return fmt.Errorf("invalid base url %q, expected an absolute http or https url", flags.baseurl)
+	}
+
+	window, err := time.ParseDuration(flags.idempotencywindow)
+	if err != nil {
+		return fmt.Errorf("invalid idempotency window: %w", err)
+	}
+
+	keys, err := idempotency.NewCache[protobuf.Message](idempotency.WithWindow(window))
+	if err != nil {
+		return fmt.Errorf("invalid idempotency window: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", grpcaddr)
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.NewMemoryStorage()
+	if err != nil {
+		return err
+	}
+
+	if flags.snapshot != "" {
+		if err := restore(store, flags.snapshot, log); err != nil {
+			return err
+		}
+	}
+
+	grpcsrv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(svc.UnaryErrors),
+		grpc.ChainStreamInterceptor(svc.StreamErrors),
+		grpc.MaxRecvMsgSize(svc.MaxMessageSize),
+	)
+	broker, err := events.NewBroker()
+	if err != nil {
+		return err
+	}
+
+	linksvc := svc.NewLinksService(
+		store,
+		svc.WithBaseUrl(flags.baseurl),
+		svc.WithEventBroker(broker),
+		svc.WithIdempotencyCache(keys),
+		svc.WithAdminKey(flags.adminkey),
+	)
+
+	proto.RegisterLinksServer(grpcsrv, &linksvc)
+	reflection.Register(grpcsrv)
+
+	go func() {
+		err := grpcsrv.Serve(listener)
+		if err != nil {
+			log.Error("error serving grpc: %s", err)
+		}
+	}()
+
+	apimux := gateway.NewServeMux(
+		gateway.WithIncomingHeaderMatcher(headermatcher),
+		gateway.WithMarshalerOption(gateway.MIMEWildcard, svc.NewRawBodyMarshaler()),
+	)
+	rpcopts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		// snapshots are taken as a single message, whatever their size, and restored in chunks
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(svc.MaxMessageSize), grpc.MaxCallRecvMsgSize(math.MaxInt32)),
+	}
+	err = proto.RegisterLinksHandlerFromEndpoint(context.Background(), apimux, grpcaddr, rpcopts)
+	if err != nil {
+		return err
+	}
+
+	hits, err := recorder.NewRecorder(store)
+	if err != nil {
+		return err
+	}
+
+	var hookopts []webhooks.Option
+	if !flags.privatewebhooks {
+		hookopts = append(hookopts, webhooks.WithPublicDestinationsOnly())
+	}
+
+	notifier, err := webhooks.NewDispatcher(store, hookopts...)
+	if err != nil {
+		return err
+	}
+
+	redirect, err := svc.LinkRedirectHandler(
+		store,
+		svc.WithHitRecorder(hits),
+		svc.WithHitPublisher(broker),
+		svc.WithCookieSecret([]byte(flags.cookiesecret)),
+		svc.WithVisitorSalt([]byte(flags.visitorsalt)),
+		svc.WithCountryHeader(flags.countryheader),
+		svc.WithClientIPHeader(flags.clientipheader),
+	)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+
+	// todo: replace with different mux that allows more advanced routing
+	mux.HandleFunc("/api/docs", svc.OpenapiDocsHandler)
+	mux.HandleFunc("/api/schema.yaml", svc.OpenapiSchemaHandler)
+	mux.HandleFunc("/api/hits/events", svc.HitEventsHandler(broker))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api") {
+			apimux.ServeHTTP(w, r)
+			return
+		}
+		redirect(w, r)
+	})
+
+	server := http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: mux,
+	}
+
+	// drained is closed once both servers are stopped and done with their requests
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-ctx.Done()
+
+		log.Write("shutdown", "draining connections")
+
+		// end the streams, otherwise they keep their connections open
+		broker.Close()
+		linksvc.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		_ = server.Shutdown(ctx)
+
+		stopped := make(chan struct{})
+		go func() {
+			grpcsrv.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcsrv.Stop()
+		}
+	}()
+
+	log.Write("startup", "listening on port %d", port)
+
+	err = server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	// the server returns as soon as it starts shutting down, but the requests being
+	// served may still record hits and change links
+	<-drained
+
+	// flush the hits of the last redirects before exiting
+	flush, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err = hits.Close(flush)
+	if err != nil {
+		log.Error("error flushing hits: %s", err)
+	}
+
+	// pending webhook deliveries stay queued on the store
+	err = notifier.Close(flush)
+	if err != nil {
+		log.Error("error stopping webhooks: %s", err)
+	}
+
+	if flags.snapshot != "" {
+		if err := writefile(flags.snapshot, store.Snapshot); err != nil {
+			return fmt.Errorf("error saving snapshot: %w", err)
+		}
+		log.Write("shutdown", "saved snapshot to %s", flags.snapshot)
+	}
+
+	return nil
+}
+
+// restore the store from the snapshot file, if it exists.
+func restore(store storage.Snapshotter, file string, log *logging.Logger) error {
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error opening snapshot: %w", err)
+	}
+	defer f.Close()
+
+	info, err := store.Restore(f)
+	if err != nil {
+		return fmt.Errorf("error restoring snapshot %s: %w", file, err)
+	}
+
+	log.Write("startup", "restored %d links from %s", info.Links, file)
+	return nil
+}
+
+// headermatcher forwards the idempotency keys of the requests to the service, along
+// the headers the gateway forwards by default.
+func headermatcher(header string) (string, bool) {
+	if strings.EqualFold(header, "Idempotency-Key") {
+		return svc.IdempotencyMetadata, true
+	}
+
+	return gateway.DefaultHeaderMatcher(header)
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+
+	"github.com/aexvir/lnk/internal/output"
+	"github.com/aexvir/lnk/proto"
+)
+
+func newexportcmd(flags *globals) *cobra.Command {
+	var query statsquery
+	var format, file string
+
+	cmd := &cobra.Command{
+		Use:   "export [slug]...",
+		Short: "Export the stats of the links as csv or ndjson",
+		Long: "Export the stats of the links as csv or ndjson, of every link unless slugs are specified.\n" +
+			"The export is streamed as it's produced, to stdout unless a file is specified.",
+		ValidArgsFunction: flags.completeslugs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := proto.ExportStatsReq{Slugs: args, Format: format, Granularity: query.granularity, TimeZone: query.timezone, FillGaps: query.fillgaps}
+			if err := query.bounds(&req.From, &req.To); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return fmt.Errorf("error creating export file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			buffered := bufio.NewWriter(out)
+			if err := lnk.ExportStats(cmd.Context(), &req, buffered); err != nil {
+				return fmt.Errorf("error exporting stats: %w", err)
+			}
+
+			if err := buffered.Flush(); err != nil {
+				return fmt.Errorf("error writing export: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	query.register(cmd)
+	cmd.Flags().StringVar(&format, "format", "csv", "format of the export; csv or ndjson")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write the export to instead of stdout")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", fixedcompletion("csv", "ndjson"))
+
+	return cmd
+}
+
+func newimportcmd(flags *globals) *cobra.Command {
+	var format, conflicts string
+	var columns []string
+	var dryrun bool
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import the links of a csv, ndjson or json file",
+		Long: "Import the links of a csv, ndjson or json file, or of stdin if the file is -, keeping\n" +
+			"their slugs and hits.\n\n" +
+			"Csv files need a header naming the columns; the usual names of other shorteners are\n" +
+			"recognized, and others can be mapped with --columns, e.g. --columns target=long_url.\n" +
+			"Csv files with a date column, like the stats export, have the hits of a link on\n" +
+			"each date per row.\n" +
+			"Ndjson files have a link per line, with the fields returned by the api, e.g.\n" +
+			"  {\"target\": \"https://example.com\", \"slug\": \"example\", \"hits\": 42}\n" +
+			"Json files are dumps of the link list, like the output of lnkctl list -o json.\n\n" +
+			"Links whose slug is taken are skipped, overwritten or renamed as --conflicts says.\n" +
+			"Invalid links are reported, and don't stop the import of the rest.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("error opening import file: %w", err)
+				}
+				defer f.Close()
+				in = f
+
+				if format == "" {
+					format = importformat(args[0])
+				}
+			}
+
+			data, err := io.ReadAll(in)
+			if err != nil {
+				return fmt.Errorf("error reading import file: %w", err)
+			}
+
+			printer, err := flags.printer(cmd)
+			if err != nil {
+				return err
+			}
+
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			imported, err := lnk.ImportLinks(
+				cmd.Context(),
+				&proto.ImportLinksReq{
+					Format:    format,
+					Data:      &httpbody.HttpBody{Data: data},
+					Columns:   columns,
+					Conflicts: conflicts,
+					DryRun:    dryrun,
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("error importing links: %w", err)
+			}
+
+			err = printer.Print(imported, func() []output.Rows {
+				table := output.Rows{Headers: []string{"line", "slug", "target", "result"}}
+				for _, result := range imported.Results {
+					outcome := result.Outcome
+					switch {
+					case result.Error != "":
+						outcome = result.Error
+					case result.OriginalSlug != "":
+						outcome = fmt.Sprintf("%s from %s", outcome, result.OriginalSlug)
+					}
+					table.Rows = append(table.Rows, []string{strconv.Itoa(int(result.Line)), result.Slug, result.Target, outcome})
+				}
+				return []output.Rows{table}
+			})
+			if err != nil {
+				return err
+			}
+
+			if imported.Failed > 0 {
+				return fmt.Errorf("%d of %d links failed to import", imported.Failed, len(imported.Results))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "format of the file; csv, ndjson or json, guessed if not set")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "csv columns holding the fields of the links, as field=column")
+	cmd.Flags().StringVar(&conflicts, "conflicts", "skip", "what to do with the links whose slug is taken; skip, overwrite or rename")
+	cmd.Flags().BoolVar(&dryrun, "dry-run", false, "report what would be imported without storing anything")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", fixedcompletion("csv", "ndjson", "json"))
+	_ = cmd.RegisterFlagCompletionFunc("conflicts", fixedcompletion("skip", "overwrite", "rename"))
+	_ = cmd.RegisterFlagCompletionFunc("columns", fixedcompletion("slug=", "target=", "hits=", "bot_hits=", "created_at=", "date="))
+
+	return cmd
+}
+
+// importformat is the format of the file going by its extension, or empty to let the
+// service guess it from the contents; json files may be dumps or have a link per line.
+func importformat(file string) string {
+	switch ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(file), ".")); ext {
+	case "csv", "ndjson", "jsonl":
+		return ext
+	default:
+		return ""
+	}
+}
+
+func newsnapshotcmd(flags *globals) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Take a snapshot of the whole store",
+		Long: "Take a snapshot of the whole store, with every link, its hits and histograms, and every\n" +
+			"webhook with its pending deliveries. Snapshots are versioned and compressed, and can be\n" +
+			"restored on any lnk server with lnkctl restore.\n" +
+			"The snapshot is written to stdout unless a file is specified.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			snapshot := func(w io.Writer) error {
+				if err := lnk.SnapshotStore(cmd.Context(), w); err != nil {
+					return fmt.Errorf("error taking snapshot: %w", err)
+				}
+				return nil
+			}
+
+			if file == "" {
+				return snapshot(cmd.OutOrStdout())
+			}
+
+			return writefile(file, snapshot)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write the snapshot to instead of stdout")
+
+	return cmd
+}
+
+func newrestorecmd(flags *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Replace the whole store with a snapshot",
+		Long: "Replace the whole store with a snapshot taken by lnkctl snapshot, or read from stdin if\n" +
+			"the file is -. Links missing from the snapshot are deleted, and the rest are overwritten.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("error opening snapshot: %w", err)
+				}
+				defer f.Close()
+				in = f
+			}
+
+			printer, err := flags.printer(cmd)
+			if err != nil {
+				return err
+			}
+
+			lnk, err := flags.connect()
+			if err != nil {
+				return err
+			}
+			defer lnk.Close()
+
+			restored, err := lnk.RestoreStore(cmd.Context(), in)
+			if err != nil {
+				return fmt.Errorf("error restoring snapshot: %w", err)
+			}
+
+			return printer.Print(restored, func() []output.Rows {
+				return []output.Rows{
+					{
+						Rows: [][]string{
+							{"version", strconv.FormatUint(uint64(restored.Version), 10)},
+							{"taken at", formattime(restored.CreatedAt)},
+							{"links", strconv.FormatUint(uint64(restored.Links), 10)},
+							{"webhooks", strconv.FormatUint(uint64(restored.Webhooks), 10)},
+							{"deliveries", strconv.FormatUint(uint64(restored.Deliveries), 10)},
+							{"dead letters", strconv.FormatUint(uint64(restored.DeadLetters), 10)},
+						},
+					},
+				}
+			})
+		},
+	}
+}
+
+// writefile writes the file next to its destination, and renames it once complete, so
+// a failed write doesn't replace a good file.
+// The file is only readable by its owner, as snapshots include secrets.
+func writefile(file string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", file, err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := write(tmp); err != nil {
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", file, err)
+	}
+
+	return os.Rename(tmp.Name(), file)
+}